@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/repository/account"
+	account2 "github.com/shahbaz275817/prismo/internal/services/account"
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
+)
+
+// newCachedAccountService serves account lookups by id from an in-memory cache, invalidated across instances by bus.
+func newCachedAccountService(accountRepository account.Repository, bus *inmemory.InvalidationBus) (account2.CachedService, error) {
+	service := account2.NewAccountService(accountRepository)
+
+	cfg := config.AccountCache()
+	cache, err := inmemory.NewInMemCache(inmemory.CacheConfig{
+		LoaderFunc:         account2.CacheLoader(service),
+		TTLSeconds:         cfg.TTLSeconds,
+		NegativeTTLSeconds: cfg.NegativeTTLSeconds,
+		Size:               cfg.Size,
+		Name:               "accounts",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return account2.NewCachedAccountService(service, bus.Register("accounts", cache)), nil
+}
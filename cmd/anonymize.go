@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/repository/anonymize"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+// RunAnonymizedCopy migrates the database at targetDSN and copies the configured database into it with personal data
+// replaced by pseudonyms, for use in non production environments.
+func RunAnonymizedCopy(ctx context.Context, targetDSN string) (map[string]int64, error) {
+	if targetDSN == config.DB().GetConnectionString() {
+		return nil, errors.New("anonymized copy target must not be the source database")
+	}
+
+	if err := RunDatabaseMigrations(targetDSN, ""); err != nil {
+		return nil, err
+	}
+
+	db, err := repository.NewAccessor(config.DB(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	target, err := anonymize.Open(targetDSN)
+	if err != nil {
+		return nil, err
+	}
+	if sqlDB, err := target.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	var copied map[string]int64
+	err = db.TransactWithoutTx(ctx, func(ctx context.Context, source *gorm.DB) error {
+		copied, err = anonymize.NewCopier(source, target).Copy(ctx, anonymize.Tables())
+		return err
+	})
+	return copied, err
+}
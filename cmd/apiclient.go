@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/repository/apiclient"
+	apiclient2 "github.com/shahbaz275817/prismo/internal/services/apiclient"
+)
+
+// withAPIClientService runs f with an api client service on its own database connection.
+func withAPIClientService(f func(service apiclient2.Service) error) error {
+	db, err := repository.NewAccessor(config.DB(), nil)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// the command does not authenticate clients, so it keeps no verified secrets
+	return f(apiclient2.NewAPIClientService(apiclient.NewAPIClientRepository(db), 0))
+}
+
+func RunSetAPIClientEnabled(ctx context.Context, name string, enabled bool) error {
+	return withAPIClientService(func(service apiclient2.Service) error {
+		return service.SetEnabled(ctx, name, enabled)
+	})
+}
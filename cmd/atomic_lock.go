@@ -0,0 +1,71 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/pkg/cache"
+	"github.com/shahbaz275817/prismo/pkg/locks"
+)
+
+// newLocker returns the backend picked by AL_BACKEND along with a function closing it. The memory backend only
+// guards a single instance; the postgres backend keeps a transaction open on a connection of its own pool for every
+// held lock, so DB_POOL_SIZE bounds how many locks an instance holds, and further locks are reported busy while
+// all of them are held.
+func newLocker(cacheClient cache.Client) (locks.Locker, func(), error) {
+	switch backend := config.AtomicLockBackend(); backend {
+	case config.RedisLockBackend:
+		return locks.NewRedisLocker(cacheClient), func() {}, nil
+	case config.MemoryLockBackend:
+		return locks.NewMemoryLocker(), func() {}, nil
+	case config.PostgresLockBackend:
+		db, err := sql.Open("pgx", config.DB().GetConnectionString())
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = db.Ping(); err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+		db.SetMaxOpenConns(config.DB().MaxPoolSize())
+		return locks.NewPostgresLocker(db), func() { db.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown atomic lock backend %q", backend)
+	}
+}
+
+// newJobLock returns the atomic lock for jobs run from the CLI, along with a function closing its backend.
+func newJobLock() (*locks.AtomicLock, func(), error) {
+	var cacheClient cache.Client
+	if config.AtomicLockBackend() == config.RedisLockBackend {
+		client, err := cache.NewClient(config.Cache())
+		if err != nil {
+			return nil, nil, err
+		}
+		cacheClient = client
+	}
+	closeCache := func() {
+		if cacheClient != nil {
+			cacheClient.Close()
+		}
+	}
+
+	locker, closeLocker, err := newLocker(cacheClient)
+	if err != nil {
+		closeCache()
+		return nil, nil, err
+	}
+	return locks.NewAtomicLock(locker, config.AtomicLockConfig(), nil), func() {
+		closeLocker()
+		closeCache()
+	}, nil
+}
+
+// needsCache reports whether anything the server runs is kept in Redis.
+func needsCache() bool {
+	return config.AtomicLockBackend() == config.RedisLockBackend || config.RateLimit().Enabled ||
+		config.CacheInvalidation().Enabled
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/config"
+)
+
+const jwksFetchTimeout = 5 * time.Second
+
+// newTokenVerifier returns the verifier for bearer tokens, or nil when they are disabled. Keys are loaded on first use.
+func newTokenVerifier(client *http.Client) auth.TokenVerifier {
+	cfg := config.Auth().JWT
+	if !cfg.Enabled {
+		return nil
+	}
+	if client == nil {
+		client = &http.Client{Timeout: jwksFetchTimeout}
+	}
+
+	return auth.NewJWTVerifier(auth.NewJWKS(cfg.JWKS, cfg.JWKSRefresh, client), auth.JWTConfig{
+		Issuer:      cfg.Issuer,
+		Audience:    cfg.Audience,
+		Leeway:      cfg.Leeway,
+		RoleClaim:   cfg.RoleClaim,
+		TenantClaim: cfg.TenantClaim,
+	})
+}
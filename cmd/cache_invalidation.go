@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/pkg/cache"
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
+	"github.com/shahbaz275817/prismo/pkg/reporting"
+)
+
+// startInvalidationBus starts receiving the cache invalidations of other instances, returning the bus along with a
+// function stopping it. The bus is nil when invalidation across instances is disabled.
+func startInvalidationBus(client cache.Client, reporter *reporting.Reporter) (*inmemory.InvalidationBus, func(), error) {
+	cfg := config.CacheInvalidation()
+	if !cfg.Enabled {
+		return nil, func() {}, nil
+	}
+
+	bus, err := inmemory.NewInvalidationBus(client, cfg.Channel, reporter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		bus.Run(ctx)
+	}()
+	return bus, func() {
+		cancel()
+		<-done
+	}, nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"encoding/base64"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	card2 "github.com/shahbaz275817/prismo/internal/services/card"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func newPANCipher() (*card2.PANCipher, error) {
+	encoded := config.Card().PANEncryptionKey
+	if encoded == "" {
+		return nil, errors.New("CARD_PAN_ENCRYPTION_KEY is empty, it must be set from the environment")
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return card2.NewPANCipher(key)
+}
+
+func cardConfig() card2.Config {
+	cfg := config.Card()
+	return card2.Config{
+		BIN:           cfg.BIN,
+		PANLength:     cfg.PANLength,
+		ValidityYears: cfg.ValidityYears,
+	}
+}
@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/shahbaz275817/prismo/internal/auth"
 	"github.com/shahbaz275817/prismo/internal/config"
 	"github.com/shahbaz275817/prismo/internal/handler"
+	apiclient2 "github.com/shahbaz275817/prismo/internal/services/apiclient"
 	"github.com/shahbaz275817/prismo/pkg/logger"
 	"github.com/shahbaz275817/prismo/pkg/server"
 )
@@ -25,6 +29,10 @@ func newCLI() *cobra.Command {
 	cli.AddCommand(newServerCmd())
 	cli.AddCommand(newMigrateCmd())
 	cli.AddCommand(newRollbackCmd())
+	cli.AddCommand(newReconcileCmd())
+	cli.AddCommand(newAccrueInterestCmd())
+	cli.AddCommand(newAnonymizeCmd())
+	cli.AddCommand(newAPIClientCmd())
 	return cli
 }
 
@@ -70,3 +78,177 @@ func newRollbackCmd() *cobra.Command {
 		},
 	}
 }
+
+func newReconcileCmd() *cobra.Command {
+	var file string
+	var tenantID int64
+
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Reconcile a settlement file against transactions",
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := auth.WithPrincipal(context.Background(), auth.NewSystemPrincipal("reconcile", jobTenantID(tenantID)))
+			run, err := RunReconciliation(ctx, file)
+			if err != nil {
+				logger.Fatalf("Reconcile: unable to reconcile settlement file %s: %v", file, err)
+			}
+			logger.Infof("Reconcile: run %d created for %s with %d records", run.RunID, run.FileName, run.TotalRecords)
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "path to the settlement csv file")
+	cmd.Flags().Int64Var(&tenantID, "tenant-id", 0, "tenant the settlement file belongs to, defaults to AUTH_TENANT_ID")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func newAccrueInterestCmd() *cobra.Command {
+	var date string
+	var tenantID int64
+
+	cmd := &cobra.Command{
+		Use:   "accrue-interest",
+		Short: "Post one day of interest on revolving balances",
+		Run: func(_ *cobra.Command, _ []string) {
+			day := time.Now().UTC().AddDate(0, 0, -1)
+			if date != "" {
+				parsed, err := time.Parse("2006-01-02", date)
+				if err != nil {
+					logger.Fatalf("AccrueInterest: invalid date %s: %v", date, err)
+				}
+				day = parsed
+			}
+
+			ctx := auth.WithPrincipal(context.Background(), auth.NewSystemPrincipal("accrue-interest", jobTenantID(tenantID)))
+			charged, err := RunInterestAccrual(ctx, day)
+			if err != nil {
+				logger.Fatalf("AccrueInterest: unable to accrue interest for %s: %v", day.Format("2006-01-02"), err)
+			}
+			logger.Infof("AccrueInterest: charged interest on %d accounts for %s", charged, day.Format("2006-01-02"))
+		},
+	}
+
+	cmd.Flags().StringVar(&date, "date", "", "accrual date as YYYY-MM-DD, defaults to yesterday (UTC)")
+	cmd.Flags().Int64Var(&tenantID, "tenant-id", 0, "tenant to accrue interest for, defaults to AUTH_TENANT_ID")
+	return cmd
+}
+
+// jobTenantID is the tenant a CLI job runs for: the --tenant-id flag when given, the configured tenant otherwise.
+func jobTenantID(flag int64) int64 {
+	if flag != 0 {
+		return flag
+	}
+	return config.Auth().TenantID
+}
+
+func newAnonymizeCmd() *cobra.Command {
+	var target string
+
+	cmd := &cobra.Command{
+		Use:   "anonymize",
+		Short: "Copy the database with personal data replaced by pseudonyms",
+		Run: func(_ *cobra.Command, _ []string) {
+			copied, err := RunAnonymizedCopy(context.Background(), target)
+			if err != nil {
+				logger.Fatalf("Anonymize: unable to copy database: %v", err)
+			}
+			logger.Infof("Anonymize: copied %d tables", len(copied))
+		},
+	}
+
+	cmd.Flags().StringVar(&target, "target", "", "connection string of the database to write the anonymized copy to")
+	_ = cmd.MarkFlagRequired("target")
+	return cmd
+}
+
+func newAPIClientCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api-client",
+		Short: "Manage the clients allowed to call the API",
+	}
+
+	cmd.AddCommand(newAPIClientCreateCmd())
+	cmd.AddCommand(newAPIClientRotateCmd())
+	cmd.AddCommand(newAPIClientEnableCmd("enable", true))
+	cmd.AddCommand(newAPIClientEnableCmd("disable", false))
+	return cmd
+}
+
+func newAPIClientCreateCmd() *cobra.Command {
+	var name, role string
+	var tenantID int64
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an api client and print its secret, which cannot be shown again",
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := auth.WithPrincipal(context.Background(), auth.NewSystemPrincipal("api-client", jobTenantID(tenantID)))
+			err := withAPIClientService(func(service apiclient2.Service) error {
+				client, secret, err := service.Create(ctx, name, auth.Role(role), jobTenantID(tenantID))
+				if err != nil {
+					return err
+				}
+				fmt.Printf("client: %s\nrole: %s\ntenant: %d\nsecret: %s\n", client.Name, client.Role, client.TenantID, secret)
+				return nil
+			})
+			if err != nil {
+				logger.Fatalf("APIClient: unable to create client %s: %v", name, err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "name the client authenticates with")
+	cmd.Flags().StringVar(&role, "role", string(auth.RoleViewer), "role of the client: viewer, operator or admin")
+	cmd.Flags().Int64Var(&tenantID, "tenant-id", 0, "tenant the client acts for, defaults to AUTH_TENANT_ID")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newAPIClientRotateCmd() *cobra.Command {
+	var name string
+	var overlap time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Issue a new secret for an api client and print it",
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := auth.WithPrincipal(context.Background(), auth.NewSystemPrincipal("api-client", jobTenantID(0)))
+			err := withAPIClientService(func(service apiclient2.Service) error {
+				secret, err := service.RotateSecret(ctx, name, overlap)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("client: %s\nsecret: %s\nprevious secret valid for: %s\n", name, secret, overlap)
+				return nil
+			})
+			if err != nil {
+				logger.Fatalf("APIClient: unable to rotate secret of client %s: %v", name, err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "name of the client")
+	cmd.Flags().DurationVar(&overlap, "overlap", 24*time.Hour, "how long the previous secret keeps working")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newAPIClientEnableCmd(use string, enabled bool) *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: fmt.Sprintf("%s an api client", use),
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := auth.WithPrincipal(context.Background(), auth.NewSystemPrincipal("api-client", jobTenantID(0)))
+			if err := RunSetAPIClientEnabled(ctx, name, enabled); err != nil {
+				logger.Fatalf("APIClient: unable to %s client %s: %v", use, name, err)
+			}
+			logger.Infof("APIClient: %sd client %s", use, name)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "name of the client")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
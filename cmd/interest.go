@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/repository/interest"
+	"github.com/shahbaz275817/prismo/internal/repository/operationtype"
+	"github.com/shahbaz275817/prismo/internal/repository/transaction"
+	fee2 "github.com/shahbaz275817/prismo/internal/services/fee"
+	interest2 "github.com/shahbaz275817/prismo/internal/services/interest"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/pkg/locks"
+)
+
+func RunInterestAccrual(ctx context.Context, date time.Time) (int, error) {
+	db, err := repository.NewAccessor(config.DB(), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	interestOperationTypeID, err := sharedOperationTypeID(operationtype.NewOperationTypeRepository(db), models.InterestOperationType)
+	if err != nil {
+		return 0, err
+	}
+
+	cfg := config.Fee()
+	service := interest2.NewInterestService(
+		interest.NewInterestRepository(db),
+		transaction.NewTransactionRepository(db),
+		interest2.Config{
+			Apr:                     cfg.InterestApr,
+			DayCountConvention:      cfg.DayCountConvention,
+			InterestOperationTypeID: interestOperationTypeID,
+		},
+	)
+
+	lock, closeLock, err := newJobLock()
+	if err != nil {
+		return 0, err
+	}
+	defer closeLock()
+
+	// one run at a time per tenant and day, so overlapping schedules do not compete for the same accruals
+	principal, _ := auth.PrincipalFromContext(ctx)
+	key := utils.BuildLockKey("interest_accrual", principal.TenantID, date.Format("2006-01-02"))
+	result, err := lock.Execute(ctx, key, locks.Scheduler, func(*locks.LockState) ([]interface{}, error) {
+		charged, err := service.AccrueDaily(ctx, date)
+		return []interface{}{charged}, err
+	}, &locks.LockState{})
+	if err != nil {
+		return 0, err
+	}
+	return result[0].(int), nil
+}
+
+func feeConfig(operationTypeRepository operationtype.Repository) (fee2.Config, error) {
+	feeOperationTypeID, err := sharedOperationTypeID(operationTypeRepository, models.FeeOperationType)
+	if err != nil {
+		return fee2.Config{}, err
+	}
+	interestOperationTypeID, err := sharedOperationTypeID(operationTypeRepository, models.InterestOperationType)
+	if err != nil {
+		return fee2.Config{}, err
+	}
+	return fee2.Config{
+		FeeOperationTypeID:      feeOperationTypeID,
+		InterestOperationTypeID: interestOperationTypeID,
+	}, nil
+}
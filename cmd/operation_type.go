@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/repository/operationtype"
+	operationType2 "github.com/shahbaz275817/prismo/internal/services/operationtype"
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
+)
+
+// newCachedOperationTypeService serves operation type lookups by id from an in-memory cache, invalidated across
+// instances by bus.
+func newCachedOperationTypeService(operationTypeRepository operationtype.Repository, bus *inmemory.InvalidationBus) (operationType2.Service, error) {
+	service := operationType2.NewOperationTypeService(operationTypeRepository)
+
+	cfg := config.OperationTypeCache()
+	cache, err := inmemory.NewInMemCache(inmemory.CacheConfig{
+		LoaderFunc:         operationType2.CacheLoader(service),
+		TTLSeconds:         cfg.TTLSeconds,
+		NegativeTTLSeconds: cfg.NegativeTTLSeconds,
+		Size:               cfg.Size,
+		Name:               "operation_types",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return operationType2.NewCachedOperationTypeService(service, bus.Register("operation_types", cache)), nil
+}
+
+// sharedOperationTypeID returns the id of the shared operation type seeded with description, failing when it is
+// missing so nothing is posted under a wrong operation type.
+func sharedOperationTypeID(operationTypeRepository operationtype.Repository, description string) (int64, error) {
+	ot, err := operationTypeRepository.GetShared(context.Background(), description)
+	if err != nil {
+		return 0, err
+	}
+	if ot == nil {
+		return 0, fmt.Errorf("operation type %q not found, are the migrations up to date?", description)
+	}
+	return ot.OperationTypeID, nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/middleware"
+	"github.com/shahbaz275817/prismo/pkg/cache"
+)
+
+// newRateLimiter returns the limiter for API routes, or nil when rate limiting is disabled.
+func newRateLimiter(client cache.Client) *middleware.RateLimiter {
+	cfg := config.RateLimit()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	rules := make([]middleware.RateLimitRule, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		rules = append(rules, middleware.RateLimitRule{
+			Route:  rule.Route,
+			Client: rule.Client,
+			Limit:  middleware.RateLimit{RequestsPerMinute: rule.RequestsPerMinute, Burst: rule.Burst},
+		})
+	}
+
+	return middleware.NewRateLimiter(client, middleware.RateLimitConfig{
+		Default: middleware.RateLimit{RequestsPerMinute: cfg.RequestsPerMinute, Burst: cfg.Burst},
+		Rules:   rules,
+		Address: middleware.RateLimit{RequestsPerMinute: cfg.AddressRequestsPerMinute, Burst: cfg.AddressBurst},
+		Timeout: cfg.Timeout,
+	})
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+	"github.com/shahbaz275817/prismo/pkg/reporting"
+)
+
+// withReadReplica sends the reads of primary to the read replica when one is enabled. Without a reachable replica
+// at startup everything runs on the primary.
+func withReadReplica(primary repository.Accessor, reporter *reporting.Reporter) repository.Accessor {
+	cfg := config.ReadReplica()
+	if !cfg.Enabled {
+		return primary
+	}
+
+	replica, err := repository.NewAccessor(config.ReadDB(), reporter)
+	if err != nil {
+		logger.Errorf("unable to setup read replica, reading from primary: %v", err)
+		return primary
+	}
+	return repository.NewReplicaAccessor(primary, replica, repository.ReplicaConfig{
+		MaxLag:        cfg.MaxLag,
+		CheckInterval: cfg.CheckInterval,
+	})
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/repository/operationtype"
+	"github.com/shahbaz275817/prismo/internal/repository/reconciliation"
+	"github.com/shahbaz275817/prismo/internal/repository/transaction"
+	reconciliation2 "github.com/shahbaz275817/prismo/internal/services/reconciliation"
+)
+
+func RunReconciliation(ctx context.Context, path string) (*models.ReconciliationRun, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records, err := reconciliation2.ParseSettlementCSV(f)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := repository.NewAccessor(config.DB(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	matchCfg, err := reconciliationMatchConfig(operationtype.NewOperationTypeRepository(db))
+	if err != nil {
+		return nil, err
+	}
+	service := reconciliation2.NewReconciliationService(
+		reconciliation.NewReconciliationRepository(db),
+		transaction.NewTransactionRepository(db),
+		matchCfg,
+	)
+
+	return service.Reconcile(ctx, filepath.Base(path), records)
+}
+
+func reconciliationMatchConfig(operationTypeRepository operationtype.Repository) (reconciliation2.MatchConfig, error) {
+	// the legs the ledger posts on its own never reach the processor
+	var internalOperationTypeIDs []int64
+	for _, description := range []string{
+		models.FeeOperationType,
+		models.InterestOperationType,
+		models.TransferOutOperationType,
+		models.TransferInOperationType,
+	} {
+		id, err := sharedOperationTypeID(operationTypeRepository, description)
+		if err != nil {
+			return reconciliation2.MatchConfig{}, err
+		}
+		internalOperationTypeIDs = append(internalOperationTypeIDs, id)
+	}
+
+	cfg := config.Reconciliation()
+	return reconciliation2.MatchConfig{
+		MatchKey:                 cfg.MatchKey,
+		DateWindow:               cfg.DateWindow,
+		AmountTolerance:          cfg.AmountTolerance,
+		InternalOperationTypeIDs: internalOperationTypeIDs,
+	}, nil
+}
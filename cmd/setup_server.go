@@ -6,11 +6,28 @@ import (
 
 	"github.com/getsentry/raven-go"
 	"github.com/shahbaz275817/prismo/internal/repository/account"
+	"github.com/shahbaz275817/prismo/internal/repository/apiclient"
+	"github.com/shahbaz275817/prismo/internal/repository/audit"
+	"github.com/shahbaz275817/prismo/internal/repository/card"
+	"github.com/shahbaz275817/prismo/internal/repository/dispute"
+	"github.com/shahbaz275817/prismo/internal/repository/fee"
+	"github.com/shahbaz275817/prismo/internal/repository/merchant"
 	"github.com/shahbaz275817/prismo/internal/repository/operationtype"
+	"github.com/shahbaz275817/prismo/internal/repository/reconciliation"
 	"github.com/shahbaz275817/prismo/internal/repository/transaction"
-	account2 "github.com/shahbaz275817/prismo/internal/services/account"
-	operationType2 "github.com/shahbaz275817/prismo/internal/services/operationtype"
+	"github.com/shahbaz275817/prismo/internal/repository/transfer"
+	"github.com/shahbaz275817/prismo/internal/repository/user"
+	apiclient2 "github.com/shahbaz275817/prismo/internal/services/apiclient"
+	audit2 "github.com/shahbaz275817/prismo/internal/services/audit"
+	card2 "github.com/shahbaz275817/prismo/internal/services/card"
+	dispute2 "github.com/shahbaz275817/prismo/internal/services/dispute"
+	"github.com/shahbaz275817/prismo/internal/services/erasure"
+	fee2 "github.com/shahbaz275817/prismo/internal/services/fee"
+	merchant2 "github.com/shahbaz275817/prismo/internal/services/merchant"
+	reconciliation2 "github.com/shahbaz275817/prismo/internal/services/reconciliation"
 	transaction2 "github.com/shahbaz275817/prismo/internal/services/transaction"
+	transfer2 "github.com/shahbaz275817/prismo/internal/services/transfer"
+	user2 "github.com/shahbaz275817/prismo/internal/services/user"
 	"github.com/shahbaz275817/prismo/pkg/cache"
 	"github.com/shahbaz275817/prismo/pkg/locks"
 
@@ -27,37 +44,137 @@ func InitializeHandlerDependencies() (appcontext.Dependencies, func(), error) {
 
 func InitializeHandlerDependenciesWithExternal(ext appcontext.ExternalDependencies) (appcontext.Dependencies, func(), error) {
 
-	db, err := repository.NewAccessor(config.DB())
+	reporter, err := initReporter(config.StatsD())
+	if err != nil {
+		logger.Fatalf("unable to setup statsd: %v", err)
+		return appcontext.Dependencies{}, nil, err
+	}
+
+	db, err := repository.NewAccessor(config.DB(), reporter)
 	if err != nil {
 		logger.Fatalf("unable to setup db: %v", err)
 		return appcontext.Dependencies{}, nil, err
 	}
+	db = withReadReplica(db, reporter)
 
-	cacheClient, err := cache.NewClient(config.Cache())
+	var cacheClient cache.Client
+	if needsCache() {
+		cacheClient, err = cache.NewClient(config.Cache())
+		if err != nil {
+			logger.Fatalf("unable to connect to redis: %v", err)
+			return appcontext.Dependencies{}, nil, err
+		}
+		logger.Infof("Connection to Redis Cache success")
+	}
+	locker, closeLocker, err := newLocker(cacheClient)
 	if err != nil {
-		logger.Fatalf("unable to connect to redis: %v", err)
+		logger.Fatalf("unable to setup atomic lock: %v", err)
+		return appcontext.Dependencies{}, nil, err
+	}
+	atomicLock := locks.NewAtomicLock(locker, config.AtomicLockConfig(), reporter)
+
+	invalidationBus, stopInvalidationBus, err := startInvalidationBus(cacheClient, reporter)
+	if err != nil {
+		logger.Fatalf("unable to setup cache invalidation: %v", err)
 		return appcontext.Dependencies{}, nil, err
 	}
-	logger.Infof("Connection to Redis Cache success")
-	atomicLock := locks.NewAtomicLock(cacheClient, config.AtomicLockConfig())
 
 	accountRepository := account.NewAccountRepository(db)
-	accountService := account2.NewAccountService(accountRepository)
+	accountService, err := newCachedAccountService(accountRepository, invalidationBus)
+	if err != nil {
+		logger.Fatalf("unable to setup account cache: %v", err)
+		return appcontext.Dependencies{}, nil, err
+	}
 
 	operationTypeRepository := operationtype.NewOperationTypeRepository(db)
-	operationTypeService := operationType2.NewOperationTypeService(operationTypeRepository)
+	operationTypeService, err := newCachedOperationTypeService(operationTypeRepository, invalidationBus)
+	if err != nil {
+		logger.Fatalf("unable to setup operation type cache: %v", err)
+		return appcontext.Dependencies{}, nil, err
+	}
+
+	mccCategories, err := merchant2.LoadCategories(config.Merchant().MCCCategoriesFile)
+	if err != nil {
+		logger.Fatalf("unable to load mcc categories: %v", err)
+		return appcontext.Dependencies{}, nil, err
+	}
+	merchantRepository := merchant.NewMerchantRepository(db)
+	merchantService := merchant2.NewMerchantService(merchantRepository, mccCategories)
 
 	transactionRepository := transaction.NewTransactionRepository(db)
-	transactionService := transaction2.NewTransactionService(transactionRepository)
+	feeScheduleRepository := fee.NewFeeScheduleRepository(db)
+	feeCfg, err := feeConfig(operationTypeRepository)
+	if err != nil {
+		logger.Fatalf("unable to resolve fee operation types: %v", err)
+		return appcontext.Dependencies{}, nil, err
+	}
+	feeService := fee2.NewFeeService(feeScheduleRepository, transactionRepository, feeCfg)
+	transactionService := transaction2.NewTransactionService(transactionRepository, feeService, merchantService)
+
+	transferRepository := transfer.NewTransferRepository(db)
+	transferCfg, err := transferConfig(operationTypeRepository)
+	if err != nil {
+		logger.Fatalf("unable to resolve transfer operation types: %v", err)
+		return appcontext.Dependencies{}, nil, err
+	}
+	transferService := transfer2.NewTransferService(transferRepository, accountService, transactionRepository, atomicLock, transferCfg)
+
+	reconciliationRepository := reconciliation.NewReconciliationRepository(db)
+	reconciliationMatchCfg, err := reconciliationMatchConfig(operationTypeRepository)
+	if err != nil {
+		logger.Fatalf("unable to resolve reconciliation operation types: %v", err)
+		return appcontext.Dependencies{}, nil, err
+	}
+	reconciliationService := reconciliation2.NewReconciliationService(reconciliationRepository, transactionRepository, reconciliationMatchCfg)
+
+	panCipher, err := newPANCipher()
+	if err != nil {
+		logger.Fatalf("unable to setup card pan cipher: %v", err)
+		return appcontext.Dependencies{}, nil, err
+	}
+	cardRepository := card.NewCardRepository(db)
+	cardService := card2.NewCardService(cardRepository, panCipher, cardConfig())
+
+	auditRepository := audit.NewAuditRepository(db)
+	auditService := audit2.NewAuditService(auditRepository)
+
+	disputeRepository := dispute.NewDisputeRepository(db)
+	disputeService := dispute2.NewDisputeService(disputeRepository, accountRepository, transactionRepository, atomicLock)
+	erasureService := erasure.NewErasureService(accountRepository, cardRepository, disputeRepository, auditRepository, atomicLock, accountService)
+
+	userRepository := user.NewUserRepository(db)
+	userCache, err := newUserCache(userRepository, invalidationBus)
+	if err != nil {
+		logger.Fatalf("unable to setup user cache: %v", err)
+		return appcontext.Dependencies{}, nil, err
+	}
+	userService := user2.NewUserService(userRepository, userCache)
+
+	apiClientService := apiclient2.NewAPIClientService(apiclient.NewAPIClientRepository(db), config.Auth().BasicCacheTTL)
+	tokenVerifier := newTokenVerifier(ext.HTTPClient)
 
 	return appcontext.Dependencies{
-			AccountService:        accountService,
-			OperationTypesService: operationTypeService,
-			TransactionService:    transactionService,
-			AtomicLock:            atomicLock,
-		}, func() {
-			db.Close()
-		}, nil
+		AccountService:        accountService,
+		OperationTypesService: operationTypeService,
+		TransactionService:    transactionService,
+		ReconciliationService: reconciliationService,
+		CardService:           cardService,
+		MerchantService:       merchantService,
+		TransferService:       transferService,
+		AuditService:          auditService,
+		DisputeService:        disputeService,
+		ErasureService:        erasureService,
+		UserService:           userService,
+		APIClientService:      apiClientService,
+		TokenVerifier:         tokenVerifier,
+		BasicAuthEnabled:      config.Auth().BasicEnabled,
+		RateLimiter:           newRateLimiter(cacheClient),
+		AtomicLock:            atomicLock,
+	}, func() {
+		stopInvalidationBus()
+		closeLocker()
+		db.Close()
+	}, nil
 }
 
 func initReporter(cfg reporting.StatsDConfig) (reporter *reporting.Reporter, err error) {
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/operationtype"
+	transfer2 "github.com/shahbaz275817/prismo/internal/services/transfer"
+)
+
+func transferConfig(operationTypeRepository operationtype.Repository) (transfer2.Config, error) {
+	transferOutOperationTypeID, err := sharedOperationTypeID(operationTypeRepository, models.TransferOutOperationType)
+	if err != nil {
+		return transfer2.Config{}, err
+	}
+	transferInOperationTypeID, err := sharedOperationTypeID(operationTypeRepository, models.TransferInOperationType)
+	if err != nil {
+		return transfer2.Config{}, err
+	}
+	return transfer2.Config{
+		TransferOutOperationTypeID: transferOutOperationTypeID,
+		TransferInOperationTypeID:  transferInOperationTypeID,
+	}, nil
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/repository/user"
+	user2 "github.com/shahbaz275817/prismo/internal/services/user"
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
+)
+
+func newUserCache(userRepository user.Repository, bus *inmemory.InvalidationBus) (inmemory.InMemCache, error) {
+	cfg := config.User()
+	cache, err := inmemory.NewInMemCache(inmemory.CacheConfig{
+		LoaderFunc: user2.CacheLoader(userRepository),
+		TTLSeconds: cfg.CacheTTLSeconds,
+		Size:       cfg.CacheSize,
+		Name:       "users",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bus.Register("users", cache), nil
+}
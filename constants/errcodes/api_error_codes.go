@@ -4,4 +4,5 @@ const (
 	BadRequest          = "BAD_REQUEST"
 	InternalServerError = "INTERNAL_SERVER_ERROR"
 	NotFound            = "NOT_FOUND"
+	TooManyRequests     = "TOO_MANY_REQUESTS"
 )
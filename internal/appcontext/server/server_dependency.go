@@ -3,9 +3,20 @@ package server
 import (
 	"net/http"
 
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/middleware"
 	"github.com/shahbaz275817/prismo/internal/services/account"
+	"github.com/shahbaz275817/prismo/internal/services/apiclient"
+	"github.com/shahbaz275817/prismo/internal/services/audit"
+	"github.com/shahbaz275817/prismo/internal/services/card"
+	"github.com/shahbaz275817/prismo/internal/services/dispute"
+	"github.com/shahbaz275817/prismo/internal/services/erasure"
+	"github.com/shahbaz275817/prismo/internal/services/merchant"
 	"github.com/shahbaz275817/prismo/internal/services/operationtype"
+	"github.com/shahbaz275817/prismo/internal/services/reconciliation"
 	"github.com/shahbaz275817/prismo/internal/services/transaction"
+	"github.com/shahbaz275817/prismo/internal/services/transfer"
+	"github.com/shahbaz275817/prismo/internal/services/user"
 	"github.com/shahbaz275817/prismo/pkg/locks"
 )
 
@@ -13,7 +24,21 @@ type Dependencies struct {
 	TransactionService    transaction.Service
 	AccountService        account.Service
 	OperationTypesService operationtype.Service
-	AtomicLock            *locks.AtomicLock
+	ReconciliationService reconciliation.Service
+	CardService           card.Service
+	MerchantService       merchant.Service
+	TransferService       transfer.Service
+	AuditService          audit.Service
+	ErasureService        erasure.Service
+	DisputeService        dispute.Service
+	UserService           user.Service
+	APIClientService      apiclient.Service
+	// TokenVerifier is nil when bearer tokens are not accepted.
+	TokenVerifier    auth.TokenVerifier
+	BasicAuthEnabled bool
+	// RateLimiter is nil when requests are not rate limited.
+	RateLimiter *middleware.RateLimiter
+	AtomicLock  *locks.AtomicLock
 }
 
 type ExternalDependencies struct {
@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+// missingKeyRefreshInterval limits how often a token signed with an unknown key id forces a reload, so that tokens
+// with made up key ids cannot be used to hammer the key source.
+const missingKeyRefreshInterval = time.Minute
+
+// fetchTimeout bounds a reload of the keys, which is not tied to the request that started it.
+const fetchTimeout = 10 * time.Second
+
+// JWKS is a JSON Web Key Set loaded from a local file or an http(s) URL. Keys are reloaded once they are older than
+// the refresh interval, or sooner when a token names a key that is not in the set, which happens after the issuer
+// rotates its keys. A single reload runs at a time, and keys already loaded are served while it runs.
+type JWKS struct {
+	source  string
+	refresh time.Duration
+	client  *http.Client
+	now     func() time.Time
+	loads   singleflight.Group
+
+	mu       sync.Mutex
+	keys     map[string]crypto.PublicKey
+	loadedAt time.Time
+}
+
+func NewJWKS(source string, refresh time.Duration, client *http.Client) *JWKS {
+	return &JWKS{
+		source:  source,
+		refresh: refresh,
+		client:  client,
+		now:     time.Now,
+	}
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// Key returns the public key with the given key id. It waits for a reload only when the key is not loaded yet.
+func (j *JWKS) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	j.mu.Lock()
+	age := j.now().Sub(j.loadedAt)
+	key, known := j.keys[kid]
+	loaded := j.keys != nil
+	j.mu.Unlock()
+
+	if loaded && age < j.refresh && (known || age < missingKeyRefreshInterval) {
+		return j.cachedKey(key, known, kid)
+	}
+
+	reload := j.loads.DoChan("jwks", func() (interface{}, error) {
+		return nil, j.reload()
+	})
+	if known {
+		// the stale key keeps working while the reload runs in the background
+		return key, nil
+	}
+
+	select {
+	case res := <-reload:
+		if res.Err != nil && !loaded {
+			return nil, res.Err
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	j.mu.Lock()
+	key, known = j.keys[kid]
+	j.mu.Unlock()
+	return j.cachedKey(key, known, kid)
+}
+
+func (j *JWKS) cachedKey(key crypto.PublicKey, known bool, kid string) (crypto.PublicKey, error) {
+	if !known {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// reload fetches the keys with a context of its own, so that a cancelled request does not fail the callers waiting
+// for the same reload.
+func (j *JWKS) reload() error {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	keys, err := j.load(ctx)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err != nil && j.keys != nil {
+		// the keys loaded before are kept until the source is back
+		logger.Errorf("unable to refresh jwks from %s, using cached keys: %s", j.source, err.Error())
+	} else if err == nil {
+		j.keys = keys
+	}
+	j.loadedAt = j.now()
+	return err
+}
+
+func (j *JWKS) load(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	body, err := j.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &set); err != nil {
+		return nil, fmt.Errorf("invalid jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwk %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (j *JWKS) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(j.source, "http://") && !strings.HasPrefix(j.source, "https://") {
+		return os.ReadFile(j.source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := j.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching jwks", res.StatusCode)
+	}
+	return io.ReadAll(res.Body)
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// TokenVerifier turns a bearer token into the principal it was issued to.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (Principal, error)
+}
+
+type JWTConfig struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+	// RoleClaim names the claim holding the role, either a string or a list of strings of which the most
+	// privileged known role is used.
+	RoleClaim string
+	// TenantClaim names the claim holding the tenant id, as a number or a numeric string.
+	TenantClaim string
+}
+
+type jwtVerifier struct {
+	keys   *JWKS
+	cfg    JWTConfig
+	parser *jwt.Parser
+}
+
+// NewJWTVerifier verifies asymmetrically signed JWTs against keys, requiring the configured issuer and audience and
+// an expiry.
+func NewJWTVerifier(keys *JWKS, cfg JWTConfig) TokenVerifier {
+	return &jwtVerifier{
+		keys: keys,
+		cfg:  cfg,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithAudience(cfg.Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(cfg.Leeway),
+		),
+	}
+}
+
+func (v *jwtVerifier) Verify(ctx context.Context, token string) (Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %s", ErrInvalidToken, err.Error())
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return Principal{}, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	tenantID, ok := tenantClaim(claims[v.cfg.TenantClaim])
+	if !ok {
+		return Principal{}, fmt.Errorf("%w: missing or invalid %s claim", ErrInvalidToken, v.cfg.TenantClaim)
+	}
+
+	return NewUserPrincipal(subject, tenantID, roleClaim(claims[v.cfg.RoleClaim])), nil
+}
+
+func tenantClaim(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case float64:
+		if v != float64(int64(v)) || v <= 0 {
+			return 0, false
+		}
+		return int64(v), true
+	case string:
+		id, err := strconv.ParseInt(v, 10, 64)
+		return id, err == nil && id > 0
+	default:
+		return 0, false
+	}
+}
+
+// roleClaim returns the role in the claim, or an empty role, which grants nothing, when there is no known role.
+func roleClaim(value interface{}) Role {
+	var roles []Role
+	switch v := value.(type) {
+	case string:
+		roles = []Role{Role(v)}
+	case []interface{}:
+		for _, r := range v {
+			if s, ok := r.(string); ok {
+				roles = append(roles, Role(s))
+			}
+		}
+	}
+	return MostPrivileged(roles)
+}
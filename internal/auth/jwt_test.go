@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func jwksJSON(t *testing.T, keys map[string]*rsa.PrivateKey) []byte {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, jsonWebKey{
+			Kid: kid,
+			Kty: "RSA",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	b, err := json.Marshal(set)
+	require.NoError(t, err)
+	return b
+}
+
+func writeJWKS(t *testing.T, keys map[string]*rsa.PrivateKey) string {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksJSON(t, keys), 0o600))
+	return path
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func TestJWTVerifier_Verify(t *testing.T) {
+	key := newRSAKey(t)
+	otherKey := newRSAKey(t)
+	verifier := NewJWTVerifier(NewJWKS(writeJWKS(t, map[string]*rsa.PrivateKey{"k1": key}), time.Hour, http.DefaultClient), JWTConfig{
+		Issuer:      "https://issuer.example",
+		Audience:    "prismo",
+		RoleClaim:   "roles",
+		TenantClaim: "tenant_id",
+	})
+
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":       "https://issuer.example",
+			"aud":       "prismo",
+			"sub":       "jane",
+			"exp":       time.Now().Add(time.Minute).Unix(),
+			"tenant_id": 3,
+			"roles":     []string{"viewer", "operator"},
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims(nil)).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		want    Principal
+		wantErr bool
+	}{
+		{
+			name:  "maps claims to a principal with the most privileged role",
+			token: signToken(t, key, "k1", claims(nil)),
+			want:  NewUserPrincipal("jane", 3, RoleOperator),
+		},
+		{
+			name:  "accepts a single role and a string tenant",
+			token: signToken(t, key, "k1", claims(jwt.MapClaims{"roles": "admin", "tenant_id": "4"})),
+			want:  NewUserPrincipal("jane", 4, RoleAdmin),
+		},
+		{
+			name:  "grants no role when the claim has no known role",
+			token: signToken(t, key, "k1", claims(jwt.MapClaims{"roles": []string{"root"}})),
+			want:  NewUserPrincipal("jane", 3, ""),
+		},
+		{name: "rejects another issuer", token: signToken(t, key, "k1", claims(jwt.MapClaims{"iss": "https://evil.example"})), wantErr: true},
+		{name: "rejects another audience", token: signToken(t, key, "k1", claims(jwt.MapClaims{"aud": "other"})), wantErr: true},
+		{name: "rejects expired tokens", token: signToken(t, key, "k1", claims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})), wantErr: true},
+		{name: "rejects tokens without expiry", token: signToken(t, key, "k1", claims(jwt.MapClaims{"exp": nil})), wantErr: true},
+		{name: "rejects tokens without tenant", token: signToken(t, key, "k1", claims(jwt.MapClaims{"tenant_id": nil})), wantErr: true},
+		{name: "rejects tokens signed by an unknown key", token: signToken(t, otherKey, "k2", claims(nil)), wantErr: true},
+		{name: "rejects tokens signed by another key under a known id", token: signToken(t, otherKey, "k1", claims(nil)), wantErr: true},
+		{name: "rejects symmetric algorithms", token: hmacToken, wantErr: true},
+		{name: "rejects garbage", token: "not.a.token", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifier.Verify(context.Background(), tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJWKS_RefreshesAndKeepsKeysWhenSourceFails(t *testing.T) {
+	first, second := newRSAKey(t), newRSAKey(t)
+	served := atomic.Value{}
+	served.Store(jwksJSON(t, map[string]*rsa.PrivateKey{"k1": first}))
+	var fetches, failing int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(served.Load().([]byte))
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	jwks := NewJWKS(server.URL, 10*time.Minute, server.Client())
+	jwks.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := jwks.Key(ctx, "k1")
+	assert.NoError(t, err)
+	_, err = jwks.Key(ctx, "k1")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches), "keys are cached")
+
+	// the issuer rotates keys: an unknown key id reloads, but at most once per interval
+	served.Store(jwksJSON(t, map[string]*rsa.PrivateKey{"k1": first, "k2": second}))
+	_, err = jwks.Key(ctx, "k2")
+	assert.Error(t, err)
+	now = now.Add(missingKeyRefreshInterval)
+	_, err = jwks.Key(ctx, "k2")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+
+	// once stale the keys are reloaded in the background, and kept when the source is down
+	atomic.StoreInt32(&failing, 1)
+	now = now.Add(10 * time.Minute)
+	_, err = jwks.Key(ctx, "k1")
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&fetches) == 3 }, time.Second, 5*time.Millisecond)
+	_, err = jwks.Key(ctx, "k1")
+	assert.NoError(t, err)
+}
+
+func TestJWKS_SharesOneFetchAndServesCachedKeysWhileReloading(t *testing.T) {
+	key := newRSAKey(t)
+	body := jwksJSON(t, map[string]*rsa.PrivateKey{"k1": key})
+	release := make(chan struct{})
+	var fetches int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fetches, 1) > 1 {
+			<-release
+		}
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	jwks := NewJWKS(server.URL, 10*time.Minute, server.Client())
+	jwks.now = func() time.Time { return now }
+
+	_, err := jwks.Key(context.Background(), "k1")
+	require.NoError(t, err)
+
+	// the reload of stale keys hangs, but callers are answered from the cache
+	now = now.Add(10 * time.Minute)
+	for i := 0; i < 5; i++ {
+		_, err = jwks.Key(context.Background(), "k1")
+		assert.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&fetches) == 2 }, time.Second, 5*time.Millisecond)
+
+	// a caller waiting for an unknown key gives up with its own context, without cancelling the shared reload
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = jwks.Key(ctx, "k2")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
@@ -0,0 +1,58 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/shahbaz275817/prismo/internal/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTokenVerifier is an autogenerated mock type for the TokenVerifier type
+type MockTokenVerifier struct {
+	mock.Mock
+}
+
+// Verify provides a mock function with given fields: ctx, token
+func (_m *MockTokenVerifier) Verify(ctx context.Context, token string) (auth.Principal, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 auth.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (auth.Principal, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) auth.Principal); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(auth.Principal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockTokenVerifier creates a new instance of MockTokenVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTokenVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTokenVerifier {
+	mock := &MockTokenVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+
+	contextWrapper "github.com/shahbaz275817/prismo/pkg/context"
+)
+
+type principalKey struct{}
+
+const (
+	PrincipalTypeUser   = "user"
+	PrincipalTypeClient = "client"
+	PrincipalTypeSystem = "system"
+)
+
+// Principal is the authenticated caller of a request, or the job acting on its own behalf, and the tenant it acts for.
+type Principal struct {
+	Subject  string
+	Type     string
+	TenantID int64
+	// Role limits the routes a user or client principal may call. System principals do not go through the router.
+	Role Role
+}
+
+// Actor identifies the principal in audit records, e.g. "client:lms_portal" or "system:reconcile".
+func (p Principal) Actor() string {
+	return p.Type + ":" + p.Subject
+}
+
+func NewUserPrincipal(subject string, tenantID int64, role Role) Principal {
+	return Principal{Subject: subject, Type: PrincipalTypeUser, TenantID: tenantID, Role: role}
+}
+
+func NewClientPrincipal(name string, tenantID int64, role Role) Principal {
+	return Principal{Subject: name, Type: PrincipalTypeClient, TenantID: tenantID, Role: role}
+}
+
+func NewSystemPrincipal(job string, tenantID int64) Principal {
+	return Principal{Subject: job, Type: PrincipalTypeSystem, TenantID: tenantID}
+}
+
+// WithPrincipal stores the principal in ctx and scopes ctx to the principal's tenant.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	ctx = contextWrapper.WithTenantID(ctx, p.TenantID)
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
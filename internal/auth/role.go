@@ -0,0 +1,91 @@
+package auth
+
+// Role is the set of permissions granted to a principal.
+type Role string
+
+// Permission is an action a route requires, named <resource>:<action>.
+type Permission string
+
+const (
+	RoleViewer   Role = "viewer"
+	RoleOperator Role = "operator"
+	RoleAdmin    Role = "admin"
+)
+
+const (
+	PermAccountsRead        Permission = "accounts:read"
+	PermAccountsWrite       Permission = "accounts:write"
+	PermAccountsErase       Permission = "accounts:erase"
+	PermTransactionsRead    Permission = "transactions:read"
+	PermTransactionsWrite   Permission = "transactions:write"
+	PermTransfersWrite      Permission = "transfers:write"
+	PermCardsRead           Permission = "cards:read"
+	PermCardsWrite          Permission = "cards:write"
+	PermDisputesWrite       Permission = "disputes:write"
+	PermReconciliationsRead Permission = "reconciliations:read"
+	PermAuditRead           Permission = "audit:read"
+	PermUsersRead           Permission = "users:read"
+	PermUsersWrite          Permission = "users:write"
+)
+
+var viewerPermissions = []Permission{
+	PermAccountsRead,
+	PermTransactionsRead,
+	PermCardsRead,
+	PermReconciliationsRead,
+	PermUsersRead,
+}
+
+var operatorPermissions = append([]Permission{
+	PermAccountsWrite,
+	PermTransactionsWrite,
+	PermTransfersWrite,
+	PermCardsWrite,
+	PermDisputesWrite,
+}, viewerPermissions...)
+
+var adminPermissions = append([]Permission{
+	PermAccountsErase,
+	PermAuditRead,
+	PermUsersWrite,
+}, operatorPermissions...)
+
+// rolesByPrivilege orders the roles from the most to the least privileged.
+var rolesByPrivilege = []Role{RoleAdmin, RoleOperator, RoleViewer}
+
+var rolePermissions = map[Role]map[Permission]bool{
+	RoleViewer:   permissionSet(viewerPermissions),
+	RoleOperator: permissionSet(operatorPermissions),
+	RoleAdmin:    permissionSet(adminPermissions),
+}
+
+func permissionSet(permissions []Permission) map[Permission]bool {
+	set := make(map[Permission]bool, len(permissions))
+	for _, p := range permissions {
+		set[p] = true
+	}
+	return set
+}
+
+// Valid reports whether r is one of the known roles.
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can reports whether the role grants permission p. Unknown roles grant nothing.
+func (r Role) Can(p Permission) bool {
+	return rolePermissions[r][p]
+}
+
+// MostPrivileged returns the most privileged known role in roles, or an empty role when there is none.
+func MostPrivileged(roles []Role) Role {
+	for _, candidate := range rolesByPrivilege {
+		for _, r := range roles {
+			if r == candidate {
+				return r
+			}
+		}
+	}
+	return ""
+}
@@ -7,13 +7,48 @@ import (
 	"github.com/shahbaz275817/prismo/pkg/locks"
 )
 
+// Backends for AL_BACKEND, deciding where atomic locks are kept.
+const (
+	RedisLockBackend    = "redis"
+	MemoryLockBackend   = "memory"
+	PostgresLockBackend = "postgres"
+)
+
+// lockKeyTypePrefixes are the key prefixes the config of each lock type is read from, e.g. AL_DEF_LOCK_EXPIRY_MS.
+var lockKeyTypePrefixes = map[locks.KeyType]string{
+	locks.Def:             "AL_DEF",
+	locks.AccountCreation: "AL_ACCOUNT_CREATION",
+	locks.AccountPosting:  "AL_ACCOUNT_POSTING",
+	locks.Scheduler:       "AL_SCHEDULER",
+}
+
+// newAtomicLockConfig requires the AL_DEF_* keys. Other lock types are only configured when their
+// <prefix>_LOCK_EXPIRY_MS is set, and use the default config otherwise.
 func newAtomicLockConfig() map[locks.KeyType]locks.LockConfig {
 	a := make(map[locks.KeyType]locks.LockConfig)
-	a[locks.Def] = locks.LockConfig{
-		LockExpiry:    time.Duration(config2.MustGetInt("AL_DEF_LOCK_EXPIRY_MS")) * time.Millisecond,
-		RetryAttempts: uint(config2.MustGetInt("AL_DEF_RETRY_ATTEMPTS")),
-		RetryDelay:    time.Duration(config2.MustGetInt("AL_DEF_RETRY_DELAY")) * time.Millisecond,
+	for keyType, prefix := range lockKeyTypePrefixes {
+		if keyType != locks.Def && config2.GetString(prefix+"_LOCK_EXPIRY_MS") == "" {
+			continue
+		}
+		a[keyType] = newLockConfig(prefix)
 	}
 
 	return a
 }
+
+func newLockConfig(prefix string) locks.LockConfig {
+	return locks.LockConfig{
+		LockExpiry:     time.Duration(config2.MustGetInt(prefix+"_LOCK_EXPIRY_MS")) * time.Millisecond,
+		RetryAttempts:  uint(config2.MustGetInt(prefix + "_RETRY_ATTEMPTS")),
+		RetryDelay:     time.Duration(config2.MustGetInt(prefix+"_RETRY_DELAY")) * time.Millisecond,
+		RetryMaxDelay:  time.Duration(config2.GetInt(prefix+"_RETRY_MAX_DELAY_MS")) * time.Millisecond,
+		RetryMaxJitter: time.Duration(config2.GetInt(prefix+"_RETRY_MAX_JITTER_MS")) * time.Millisecond,
+	}
+}
+
+func newAtomicLockBackend() string {
+	if backend := config2.GetString("AL_BACKEND"); backend != "" {
+		return backend
+	}
+	return RedisLockBackend
+}
@@ -1,15 +1,54 @@
 package config
 
-import cfg "github.com/shahbaz275817/prismo/pkg/config"
+import (
+	"time"
+
+	cfg "github.com/shahbaz275817/prismo/pkg/config"
+)
 
 type AuthConfig struct {
-	Username string
-	Password string
+	// TenantID is the tenant CLI jobs act for when none is given.
+	TenantID int64
+	// BasicEnabled allows API clients to authenticate with basic auth.
+	BasicEnabled bool
+	// BasicCacheTTL is how long a verified client secret is accepted without comparing its hash again.
+	BasicCacheTTL time.Duration
+	JWT           JWTConfig
+}
+
+// JWTConfig configures bearer token authentication. JWKS is a file path or an http(s) URL.
+type JWTConfig struct {
+	Enabled     bool
+	JWKS        string
+	JWKSRefresh time.Duration
+	Issuer      string
+	Audience    string
+	Leeway      time.Duration
+	RoleClaim   string
+	TenantClaim string
 }
 
 func newAuthConfig() AuthConfig {
 	return AuthConfig{
-		Username: cfg.MustGetString("AUTH_USERNAME"),
-		Password: cfg.MustGetString("AUTH_PASSWORD"),
+		TenantID:      cfg.MustGetInt64("AUTH_TENANT_ID"),
+		BasicEnabled:  cfg.MustGetBool("AUTH_BASIC_ENABLED"),
+		BasicCacheTTL: time.Duration(cfg.MustGetInt("AUTH_BASIC_CACHE_TTL_SECONDS")) * time.Second,
+		JWT:           newJWTConfig(),
+	}
+}
+
+func newJWTConfig() JWTConfig {
+	if !cfg.MustGetBool("AUTH_JWT_ENABLED") {
+		return JWTConfig{}
+	}
+	return JWTConfig{
+		Enabled:     true,
+		JWKS:        cfg.MustGetString("AUTH_JWT_JWKS"),
+		JWKSRefresh: time.Duration(cfg.MustGetInt("AUTH_JWT_JWKS_REFRESH_SECONDS")) * time.Second,
+		Issuer:      cfg.MustGetString("AUTH_JWT_ISSUER"),
+		Audience:    cfg.MustGetString("AUTH_JWT_AUDIENCE"),
+		Leeway:      time.Duration(cfg.MustGetInt("AUTH_JWT_LEEWAY_SECONDS")) * time.Second,
+		RoleClaim:   cfg.MustGetString("AUTH_JWT_ROLE_CLAIM"),
+		TenantClaim: cfg.MustGetString("AUTH_JWT_TENANT_CLAIM"),
 	}
 }
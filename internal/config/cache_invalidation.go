@@ -0,0 +1,20 @@
+package config
+
+import cfg "github.com/shahbaz275817/prismo/pkg/config"
+
+// CacheInvalidationConfig decides whether in-memory caches are invalidated across instances over the Redis channel
+// Channel.
+type CacheInvalidationConfig struct {
+	Enabled bool
+	Channel string
+}
+
+func newCacheInvalidationConfig() CacheInvalidationConfig {
+	if !cfg.MustGetBool("CACHE_INVALIDATION_ENABLED") {
+		return CacheInvalidationConfig{}
+	}
+	return CacheInvalidationConfig{
+		Enabled: true,
+		Channel: cfg.MustGetString("CACHE_INVALIDATION_CHANNEL"),
+	}
+}
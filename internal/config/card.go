@@ -0,0 +1,19 @@
+package config
+
+import cfg "github.com/shahbaz275817/prismo/pkg/config"
+
+type CardConfig struct {
+	BIN              string
+	PANLength        int
+	ValidityYears    int
+	PANEncryptionKey string
+}
+
+func newCardConfig() CardConfig {
+	return CardConfig{
+		BIN:              cfg.MustGetString("CARD_BIN"),
+		PANLength:        cfg.MustGetInt("CARD_PAN_LENGTH"),
+		ValidityYears:    cfg.MustGetInt("CARD_VALIDITY_YEARS"),
+		PANEncryptionKey: cfg.MustGetString("CARD_PAN_ENCRYPTION_KEY"),
+	}
+}
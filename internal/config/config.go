@@ -12,13 +12,24 @@ import (
 var appConfig config
 
 type config struct {
-	app              AppConfig
-	logger           logger.Config
-	db               DBConfig
-	readDB           DBConfig
-	cache            cache.Options
-	auth             AuthConfig
-	atomicLockConfig map[locks.KeyType]locks.LockConfig
+	app                AppConfig
+	logger             logger.Config
+	db                 DBConfig
+	readDB             DBConfig
+	readReplica        ReadReplicaConfig
+	cache              cache.Options
+	auth               AuthConfig
+	atomicLockConfig   map[locks.KeyType]locks.LockConfig
+	atomicLockBackend  string
+	reconciliation     ReconciliationConfig
+	fee                FeeConfig
+	card               CardConfig
+	merchant           MerchantConfig
+	user               UserConfig
+	accountCache       EntityCacheConfig
+	operationTypeCache EntityCacheConfig
+	cacheInvalidation  CacheInvalidationConfig
+	rateLimit          RateLimitConfig
 }
 
 func Load() {
@@ -27,13 +38,24 @@ func Load() {
 	initStatsDConfig()
 
 	appConfig = config{
-		app:              newAppConfig(),
-		logger:           logger.NewConfig(),
-		db:               dbConf(),
-		readDB:           readDBConf(),
-		cache:            cache.NewCacheConfig(),
-		auth:             newAuthConfig(),
-		atomicLockConfig: newAtomicLockConfig(),
+		app:                newAppConfig(),
+		logger:             logger.NewConfig(),
+		db:                 dbConf(),
+		readDB:             readDBConf(),
+		readReplica:        newReadReplicaConfig(),
+		cache:              cache.NewCacheConfig(),
+		auth:               newAuthConfig(),
+		atomicLockConfig:   newAtomicLockConfig(),
+		atomicLockBackend:  newAtomicLockBackend(),
+		reconciliation:     newReconciliationConfig(),
+		fee:                newFeeConfig(),
+		card:               newCardConfig(),
+		merchant:           newMerchantConfig(),
+		user:               newUserConfig(),
+		accountCache:       newEntityCacheConfig("ACCOUNT"),
+		operationTypeCache: newEntityCacheConfig("OPERATION_TYPE"),
+		cacheInvalidation:  newCacheInvalidationConfig(),
+		rateLimit:          newRateLimitConfig(),
 	}
 }
 
@@ -43,5 +65,16 @@ func Log() logger.Config                                   { return appConfig.lo
 func Auth() AuthConfig                                     { return appConfig.auth }
 func DB() DBConfig                                         { return appConfig.db }
 func ReadDB() DBConfig                                     { return appConfig.readDB }
+func ReadReplica() ReadReplicaConfig                       { return appConfig.readReplica }
 func Cache() cache.Options                                 { return appConfig.cache }
 func AtomicLockConfig() map[locks.KeyType]locks.LockConfig { return appConfig.atomicLockConfig }
+func AtomicLockBackend() string                            { return appConfig.atomicLockBackend }
+func Reconciliation() ReconciliationConfig                 { return appConfig.reconciliation }
+func Fee() FeeConfig                                       { return appConfig.fee }
+func Card() CardConfig                                     { return appConfig.card }
+func Merchant() MerchantConfig                             { return appConfig.merchant }
+func AccountCache() EntityCacheConfig                      { return appConfig.accountCache }
+func OperationTypeCache() EntityCacheConfig                { return appConfig.operationTypeCache }
+func CacheInvalidation() CacheInvalidationConfig           { return appConfig.cacheInvalidation }
+func User() UserConfig                                     { return appConfig.user }
+func RateLimit() RateLimitConfig                           { return appConfig.rateLimit }
@@ -18,6 +18,7 @@ func readDBConf() DBConfig {
 }
 
 type DBConfig struct {
+	pool                   string
 	host                   string
 	port                   string
 	name                   string
@@ -33,6 +34,7 @@ type DBConfig struct {
 
 func initDBConfig() {
 	dbCfg = DBConfig{
+		pool:                   "primary",
 		host:                   configUtil.MustGetString("DB_HOST"),
 		port:                   configUtil.MustGetString("DB_PORT"),
 		name:                   configUtil.MustGetString("DB_NAME"),
@@ -47,6 +49,7 @@ func initDBConfig() {
 	}
 
 	readDBCfg = DBConfig{
+		pool:                   "replica",
 		host:                   getStringOrPanic("READ_DB_HOST"),
 		port:                   getStringOrPanic("READ_DB_PORT"),
 		name:                   getStringOrPanic("DB_NAME"),
@@ -84,6 +87,11 @@ func (config DBConfig) ConnMaxLifetimeMinutes() int {
 	return config.connMaxLifetimeMinutes
 }
 
+// Pool names the connection pool in metrics.
+func (config DBConfig) Pool() string {
+	return config.pool
+}
+
 func (config DBConfig) Host() string {
 	return config.host
 }
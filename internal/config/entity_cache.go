@@ -0,0 +1,19 @@
+package config
+
+import cfg "github.com/shahbaz275817/prismo/pkg/config"
+
+// EntityCacheConfig sizes the read-through cache of an entity. Entities that do not exist are cached for
+// NegativeTTLSeconds.
+type EntityCacheConfig struct {
+	Size               int
+	TTLSeconds         int64
+	NegativeTTLSeconds int64
+}
+
+func newEntityCacheConfig(prefix string) EntityCacheConfig {
+	return EntityCacheConfig{
+		Size:               cfg.MustGetInt(prefix + "_CACHE_SIZE"),
+		TTLSeconds:         cfg.MustGetInt64(prefix + "_CACHE_TTL_SECONDS"),
+		NegativeTTLSeconds: cfg.MustGetInt64(prefix + "_CACHE_NEGATIVE_TTL_SECONDS"),
+	}
+}
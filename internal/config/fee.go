@@ -0,0 +1,15 @@
+package config
+
+import cfg "github.com/shahbaz275817/prismo/pkg/config"
+
+type FeeConfig struct {
+	InterestApr        float64
+	DayCountConvention string
+}
+
+func newFeeConfig() FeeConfig {
+	return FeeConfig{
+		InterestApr:        cfg.MustGetFloat64("INTEREST_APR"),
+		DayCountConvention: cfg.MustGetString("INTEREST_DAY_COUNT_CONVENTION"),
+	}
+}
@@ -0,0 +1,13 @@
+package config
+
+import cfg "github.com/shahbaz275817/prismo/pkg/config"
+
+type MerchantConfig struct {
+	MCCCategoriesFile string
+}
+
+func newMerchantConfig() MerchantConfig {
+	return MerchantConfig{
+		MCCCategoriesFile: cfg.MustGetString("MCC_CATEGORIES_FILE"),
+	}
+}
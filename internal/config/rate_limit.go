@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	cfg "github.com/shahbaz275817/prismo/pkg/config"
+)
+
+type RateLimitConfig struct {
+	Enabled           bool
+	RequestsPerMinute float64
+	Burst             int
+	Rules             []RateLimitRule
+	Timeout           time.Duration
+	// AddressRequestsPerMinute and AddressBurst limit each address before its credentials are checked.
+	AddressRequestsPerMinute float64
+	AddressBurst             int
+}
+
+// RateLimitRule overrides the default limit for a route ("POST /v1/transfers"), a client, or both.
+type RateLimitRule struct {
+	Route             string  `json:"route"`
+	Client            string  `json:"client"`
+	RequestsPerMinute float64 `json:"requests_per_minute"`
+	Burst             int     `json:"burst"`
+}
+
+func newRateLimitConfig() RateLimitConfig {
+	if !cfg.MustGetBool("RATE_LIMIT_ENABLED") {
+		return RateLimitConfig{}
+	}
+
+	c := RateLimitConfig{
+		Enabled:           true,
+		RequestsPerMinute: cfg.MustGetFloat64("RATE_LIMIT_REQUESTS_PER_MINUTE"),
+		Burst:             cfg.MustGetInt("RATE_LIMIT_BURST"),
+		Timeout:           cfg.MustGetTimeoutInMS("RATE_LIMIT_TIMEOUT_MS"),
+
+		AddressRequestsPerMinute: cfg.MustGetFloat64("RATE_LIMIT_ADDRESS_REQUESTS_PER_MINUTE"),
+		AddressBurst:             cfg.MustGetInt("RATE_LIMIT_ADDRESS_BURST"),
+	}
+	panicIfErrorForKey(cfg.MustGetJSON("RATE_LIMIT_RULES", &c.Rules), "RATE_LIMIT_RULES")
+
+	panicIfErrorForKey(validRateLimit(c.RequestsPerMinute, c.Burst), "RATE_LIMIT_REQUESTS_PER_MINUTE")
+	panicIfErrorForKey(validRateLimit(c.AddressRequestsPerMinute, c.AddressBurst), "RATE_LIMIT_ADDRESS_REQUESTS_PER_MINUTE")
+	for _, rule := range c.Rules {
+		panicIfErrorForKey(validRateLimit(rule.RequestsPerMinute, rule.Burst), "RATE_LIMIT_RULES")
+	}
+	return c
+}
+
+func validRateLimit(requestsPerMinute float64, burst int) error {
+	if requestsPerMinute <= 0 || burst < 1 {
+		return fmt.Errorf("requests per minute must be positive and burst at least 1, got %v and %d", requestsPerMinute, burst)
+	}
+	return nil
+}
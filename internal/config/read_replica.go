@@ -0,0 +1,25 @@
+package config
+
+import (
+	"time"
+
+	cfg "github.com/shahbaz275817/prismo/pkg/config"
+)
+
+// ReadReplicaConfig decides whether reads go to the database configured by the READ_DB_* keys.
+type ReadReplicaConfig struct {
+	Enabled       bool
+	MaxLag        time.Duration
+	CheckInterval time.Duration
+}
+
+func newReadReplicaConfig() ReadReplicaConfig {
+	if !cfg.MustGetBool("READ_DB_ENABLED") {
+		return ReadReplicaConfig{}
+	}
+	return ReadReplicaConfig{
+		Enabled:       true,
+		MaxLag:        cfg.MustGetTimeoutInMS("READ_DB_MAX_LAG_MS"),
+		CheckInterval: cfg.MustGetTimeoutInMS("READ_DB_HEALTH_CHECK_INTERVAL_MS"),
+	}
+}
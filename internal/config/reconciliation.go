@@ -0,0 +1,21 @@
+package config
+
+import (
+	"time"
+
+	cfg "github.com/shahbaz275817/prismo/pkg/config"
+)
+
+type ReconciliationConfig struct {
+	MatchKey        string
+	DateWindow      time.Duration
+	AmountTolerance float64
+}
+
+func newReconciliationConfig() ReconciliationConfig {
+	return ReconciliationConfig{
+		MatchKey:        cfg.MustGetString("RECON_MATCH_KEY"),
+		DateWindow:      time.Duration(cfg.MustGetInt("RECON_DATE_WINDOW_MINUTES")) * time.Minute,
+		AmountTolerance: cfg.MustGetFloat64("RECON_AMOUNT_TOLERANCE"),
+	}
+}
@@ -0,0 +1,15 @@
+package config
+
+import cfg "github.com/shahbaz275817/prismo/pkg/config"
+
+type UserConfig struct {
+	CacheSize       int
+	CacheTTLSeconds int64
+}
+
+func newUserConfig() UserConfig {
+	return UserConfig{
+		CacheSize:       cfg.MustGetInt("USER_CACHE_SIZE"),
+		CacheTTLSeconds: cfg.MustGetInt64("USER_CACHE_TTL_SECONDS"),
+	}
+}
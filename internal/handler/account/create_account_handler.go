@@ -39,9 +39,10 @@ func CreateAccountHandler(accountService account.Service, lock *locks.AtomicLock
 			return err
 		}
 
+		// the document number is hashed so that the locker does not keep it
 		lockState := locks.LockState{}
-		_, err = lock.Execute(ctx, utils.BuildLockKey("doc_number", caReq.DocumentNumber), locks.Def, func(lockState *locks.LockState) ([]interface{}, error) {
-			return nil, accountService.Create(ctx, models.Account{DocumentNumber: caReq.DocumentNumber})
+		_, err = lock.Execute(ctx, utils.BuildLockKey("doc_number", utils.HashLockKeyPart(caReq.DocumentNumber)), locks.AccountCreation, func(lockState *locks.LockState) ([]interface{}, error) {
+			return nil, accountService.Create(ctx, &models.Account{DocumentNumber: caReq.DocumentNumber})
 		}, &lockState)
 		if err != nil {
 			lgr.Errorf("Error while creating account : %s", err.Error())
@@ -0,0 +1,56 @@
+package account
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	handlerUtils "github.com/shahbaz275817/prismo/internal/handler/utils"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/erasure"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func EraseAccountHandler(erasureService erasure.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		accountIDStr := mux.Vars(r)["account_id"]
+
+		accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+		if err != nil {
+			lgr.Errorf("invalid account_id: %s, error: %s", accountIDStr, err.Error())
+			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: "Invalid account ID",
+			}))
+			return err
+		}
+
+		version, err := handlerUtils.IfMatchVersion(r)
+		if err != nil {
+			lgr.Errorf("invalid If-Match for account %d: %s", accountID, err.Error())
+			responder.WriteError(w, r, err)
+			return err
+		}
+
+		accountData, err := erasureService.EraseAccount(ctx, accountID, version)
+		if err != nil {
+			lgr.Errorf("error in erasing account %d error: %s", accountID, err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		res := getAccountResponse{
+			AccountID:      accountData.AccountID,
+			DocumentNumber: accountData.DocumentNumber,
+			ErasedAt:       accountData.ErasedAt,
+		}
+		handlerUtils.SetETag(w, accountData.Version)
+		responder.WriteAnyResponse(ctx, w, res)
+		return nil
+	})
+}
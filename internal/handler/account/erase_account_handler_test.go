@@ -0,0 +1,118 @@
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/services/erasure/mocks"
+	pkgErrors "github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func TestEraseAccountHandler(t *testing.T) {
+	erasedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		accountID  string
+		ifMatch    string
+		mockFunc   func(svc *mocks.MockErasureService)
+		statusCode int
+		etag       string
+	}{
+		{
+			name:       "Invalid Account ID",
+			accountID:  "abc",
+			mockFunc:   func(svc *mocks.MockErasureService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid If-Match",
+			accountID:  "7",
+			ifMatch:    `"x"`,
+			mockFunc:   func(svc *mocks.MockErasureService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Erases The Expected Version",
+			accountID: "7",
+			ifMatch:   `"3"`,
+			mockFunc: func(svc *mocks.MockErasureService) {
+				svc.On("EraseAccount", mock.Anything, int64(7), int64(3)).
+					Return(&models.Account{AccountID: 7, DocumentNumber: "X0a1b2c3d4e5f6", ErasedAt: &erasedAt, Version: 4}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+			etag:       `"4"`,
+		},
+		{
+			name:      "Account Not Found",
+			accountID: "7",
+			mockFunc: func(svc *mocks.MockErasureService) {
+				svc.On("EraseAccount", mock.Anything, int64(7), int64(0)).
+					Return(nil, pkgErrors.NewNotFoundError("account_not_found", &pkgErrors.ErrDetails{})).Once()
+			},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:      "Open Disputes",
+			accountID: "7",
+			mockFunc: func(svc *mocks.MockErasureService) {
+				svc.On("EraseAccount", mock.Anything, int64(7), int64(0)).
+					Return(nil, pkgErrors.NewStatusUnprocessableEntity("open_disputes", &pkgErrors.ErrDetails{})).Once()
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "Stale Version",
+			accountID: "7",
+			ifMatch:   `"2"`,
+			mockFunc: func(svc *mocks.MockErasureService) {
+				svc.On("EraseAccount", mock.Anything, int64(7), int64(2)).
+					Return(nil, pkgErrors.NewPreconditionFailedError("version_mismatch", &pkgErrors.ErrDetails{})).Once()
+			},
+			statusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:      "Service Error",
+			accountID: "7",
+			mockFunc: func(svc *mocks.MockErasureService) {
+				svc.On("EraseAccount", mock.Anything, int64(7), int64(0)).Return(nil, errors.New("some error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mocks.MockErasureService{}
+			tt.mockFunc(svc)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/accounts/"+tt.accountID+"/erase", nil)
+			req = mux.SetURLVars(req, map[string]string{"account_id": tt.accountID})
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+
+			EraseAccountHandler(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, tt.etag, w.Header().Get("ETag"))
+			if tt.statusCode == http.StatusOK {
+				var res getAccountResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+				assert.Equal(t, "X0a1b2c3d4e5f6", res.DocumentNumber)
+				assert.Equal(t, &erasedAt, res.ErasedAt)
+			}
+			svc.AssertExpectations(t)
+		})
+	}
+}
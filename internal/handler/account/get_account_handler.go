@@ -3,9 +3,11 @@ package account
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/shahbaz275817/prismo/constants/errcodes"
+	handlerUtils "github.com/shahbaz275817/prismo/internal/handler/utils"
 	"github.com/shahbaz275817/prismo/internal/models"
 	"github.com/shahbaz275817/prismo/internal/responder"
 	"github.com/shahbaz275817/prismo/internal/services/account"
@@ -32,7 +34,7 @@ func GetAccountHandler(accountService account.Service) http.HandlerFunc {
 		accountData, err := accountService.Get(ctx, &models.Account{AccountID: accountID})
 		if err != nil {
 			lgr.Errorf("error in fetching account id error: %s", err.Error())
-			responder.WriteError(w, r, errors.NewInternalServerError(errcodes.InternalServerError, &errors.ErrDetails{}))
+			responder.WriteServiceError(w, r, err)
 			return err
 		}
 
@@ -45,13 +47,16 @@ func GetAccountHandler(accountService account.Service) http.HandlerFunc {
 		res := getAccountResponse{
 			AccountID:      accountData.AccountID,
 			DocumentNumber: accountData.DocumentNumber,
+			ErasedAt:       accountData.ErasedAt,
 		}
+		handlerUtils.SetETag(w, accountData.Version)
 		responder.WriteAnyResponse(ctx, w, res)
 		return nil
 	})
 }
 
 type getAccountResponse struct {
-	AccountID      int64  `json:"account_id"`
-	DocumentNumber string `json:"document_number"`
+	AccountID      int64      `json:"account_id"`
+	DocumentNumber string     `json:"document_number"`
+	ErasedAt       *time.Time `json:"erased_at,omitempty"`
 }
@@ -0,0 +1,78 @@
+package account
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	accountRepo "github.com/shahbaz275817/prismo/internal/repository/account"
+	"github.com/shahbaz275817/prismo/internal/services/account"
+)
+
+func TestGetAccountHandler_IsScopedToTenant(t *testing.T) {
+	tests := []struct {
+		name       string
+		tenantID   int64
+		rows       *sqlmock.Rows
+		statusCode int
+		etag       string
+	}{
+		{
+			name:       "Account Of The Caller's Tenant",
+			tenantID:   1,
+			rows:       sqlmock.NewRows([]string{"account_id", "tenant_id", "document_number", "version"}).AddRow(7, 1, "12345678900", 4),
+			statusCode: http.StatusOK,
+			etag:       `"4"`,
+		},
+		{
+			name:       "Account Of Another Tenant",
+			tenantID:   2,
+			rows:       sqlmock.NewRows([]string{"account_id", "tenant_id", "document_number"}),
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+			assert.NoError(t, err)
+			assert.NoError(t, repository.RegisterTenantCallbacks(db))
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1 AND "accounts"."tenant_id" = $2`)).
+				WithArgs(int64(7), tt.tenantID, 1).
+				WillReturnRows(tt.rows)
+			if tt.statusCode == http.StatusOK {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			service := account.NewAccountService(accountRepo.NewAccountRepository(repository.NewAccessorFromDB(db, 1000)))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/accounts/7", nil)
+			req = mux.SetURLVars(req, map[string]string{"account_id": "7"})
+			req = req.WithContext(auth.WithPrincipal(req.Context(), auth.NewUserPrincipal("ops", tt.tenantID, auth.RoleViewer)))
+			w := httptest.NewRecorder()
+
+			GetAccountHandler(service).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, tt.etag, w.Header().Get("ETag"))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	handlerUtils "github.com/shahbaz275817/prismo/internal/handler/utils"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/audit"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const (
+	listSortColumn = "created_at"
+	listIDColumn   = "audit_id"
+)
+
+func ListAuditLogsHandler(auditService audit.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		query, filter, err := parseListAuditLogsRequest(r.URL.Query())
+		if err != nil {
+			lgr.Errorf("invalid list audit logs request error: %s", err.Error())
+			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: err.Error(),
+			}))
+			return err
+		}
+
+		logs, page, err := auditService.GetPage(ctx, query, filter)
+		if err != nil {
+			lgr.Errorf("error in listing audit logs error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		if logs == nil {
+			logs = []models.AuditLog{}
+		}
+		responder.WriteAnyResponse(ctx, w, listAuditLogsResponse{
+			AuditLogs:  logs,
+			NextCursor: page.NextCursor,
+			TotalCount: page.TotalCount,
+		})
+		return nil
+	})
+}
+
+func parseListAuditLogsRequest(values url.Values) (*models.AuditLog, repository.FilterRequest, error) {
+	query := &models.AuditLog{
+		Entity: values.Get("entity"),
+		Actor:  values.Get("actor"),
+	}
+	if v := values.Get("entity_id"); v != "" {
+		query.EntityID = &v
+	}
+	if v := values.Get("request_id"); v != "" {
+		query.RequestID = &v
+	}
+
+	filter, err := handlerUtils.ParsePageRequest(values, listSortColumn, listIDColumn)
+	if err != nil {
+		return nil, filter, err
+	}
+
+	from, to := values.Get("from"), values.Get("to")
+	if (from == "") != (to == "") {
+		return nil, filter, errors.New("invalid date range: from and to must be given together")
+	}
+	if from != "" {
+		createdFrom, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, filter, errors.New("invalid from: must be an RFC3339 timestamp")
+		}
+		createdTo, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, filter, errors.New("invalid to: must be an RFC3339 timestamp")
+		}
+		filter.CreatedFrom, filter.CreatedTo = &createdFrom, &createdTo
+	}
+
+	return query, filter, nil
+}
+
+type listAuditLogsResponse struct {
+	AuditLogs  []models.AuditLog `json:"audit_logs"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	TotalCount *int64            `json:"total_count,omitempty"`
+}
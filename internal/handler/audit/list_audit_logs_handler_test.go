@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/services/audit/mocks"
+)
+
+func TestListAuditLogsHandler(t *testing.T) {
+	auditSvc := mocks.MockAuditService{}
+
+	entityID := "7"
+	count := int64(1)
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func()
+		statusCode int
+		wantIDs    []int64
+		wantPage   repository.Page
+	}{
+		{
+			name:       "Invalid Limit",
+			query:      "?limit=0",
+			mockFunc:   func() {},
+			statusCode: 400,
+		},
+		{
+			name:       "Date Range Without End",
+			query:      "?from=2024-03-01T00:00:00Z",
+			mockFunc:   func() {},
+			statusCode: 400,
+		},
+		{
+			name:       "Invalid Date",
+			query:      "?from=2024-03-01&to=2024-03-02",
+			mockFunc:   func() {},
+			statusCode: 400,
+		},
+		{
+			name:  "Service Error",
+			query: "?entity=accounts",
+			mockFunc: func() {
+				auditSvc.On("GetPage", mock.Anything, &models.AuditLog{Entity: "accounts"}, mock.Anything).
+					Return(nil, repository.Page{}, errors.New("some error")).Once()
+			},
+			statusCode: 500,
+		},
+		{
+			name:  "No Audit Logs",
+			query: "?entity=cards",
+			mockFunc: func() {
+				auditSvc.On("GetPage", mock.Anything, &models.AuditLog{Entity: "cards"}, mock.Anything).
+					Return(nil, repository.Page{}, nil).Once()
+			},
+			statusCode: 200,
+			wantIDs:    []int64{},
+		},
+		{
+			name:  "Filters By Entity And Date Range",
+			query: "?entity=accounts&entity_id=7&actor=user:ops&from=2024-03-01T00:00:00Z&to=2024-03-02T00:00:00Z&limit=10",
+			mockFunc: func() {
+				auditSvc.On("GetPage", mock.Anything, &models.AuditLog{Entity: "accounts", EntityID: &entityID, Actor: "user:ops"}, repository.FilterRequest{
+					Limit:       10,
+					CreatedFrom: &from,
+					CreatedTo:   &to,
+					Keyset:      &repository.KeysetPagination{Column: listSortColumn, IDColumn: listIDColumn, Descending: true},
+				}).Return([]models.AuditLog{{AuditID: 3, Entity: "accounts", EntityID: &entityID}}, repository.Page{NextCursor: "next", TotalCount: &count}, nil).Once()
+			},
+			statusCode: 200,
+			wantIDs:    []int64{3},
+			wantPage:   repository.Page{NextCursor: "next", TotalCount: &count},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Load()
+			w := httptest.NewRecorder()
+			tt.mockFunc()
+			r, err := http.NewRequest(http.MethodGet, "/v1/audit-logs"+tt.query, nil)
+			assert.NoError(t, err)
+
+			handler := ListAuditLogsHandler(&auditSvc)
+			handler(w, r)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.statusCode == 200 {
+				var res listAuditLogsResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+				ids := []int64{}
+				for _, log := range res.AuditLogs {
+					ids = append(ids, log.AuditID)
+				}
+				assert.Equal(t, tt.wantIDs, ids)
+				assert.Equal(t, tt.wantPage.NextCursor, res.NextCursor)
+				assert.Equal(t, tt.wantPage.TotalCount, res.TotalCount)
+			}
+			auditSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package card
+
+import (
+	"net/http"
+
+	"github.com/shahbaz275817/prismo/internal/services/card"
+)
+
+func ActivateCardHandler(cardService card.Service) http.HandlerFunc {
+	return cardActionHandler(cardService.Activate, http.StatusOK)
+}
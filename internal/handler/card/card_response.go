@@ -0,0 +1,59 @@
+package card
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+)
+
+type cardResponse struct {
+	CardToken        string            `json:"card_token"`
+	AccountID        int64             `json:"account_id"`
+	MaskedPan        string            `json:"masked_pan"`
+	ExpiryMonth      int               `json:"expiry_month"`
+	ExpiryYear       int               `json:"expiry_year"`
+	Status           models.CardStatus `json:"status"`
+	ReplacedByCardID *int64            `json:"replaced_by_card_id,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+}
+
+func newCardResponse(c *models.Card) cardResponse {
+	return cardResponse{
+		CardToken:        c.Token,
+		AccountID:        c.AccountID,
+		MaskedPan:        c.MaskedPan,
+		ExpiryMonth:      c.ExpiryMonth,
+		ExpiryYear:       c.ExpiryYear,
+		Status:           c.Status,
+		ReplacedByCardID: c.ReplacedByCardID,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
+
+// cardActionHandler serves the card lifecycle endpoints, which all resolve a card by token and apply a single service call.
+func cardActionHandler(action func(ctx context.Context, token string) (*models.Card, error), successStatus int) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		token := mux.Vars(r)["card_token"]
+
+		c, err := action(ctx, token)
+		if err != nil {
+			lgr.Errorf("error in updating card %s error: %s", token, err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		responder.WriteAnyResponse(ctx, w, newCardResponse(c), successStatus)
+		return nil
+	})
+}
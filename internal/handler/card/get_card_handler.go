@@ -0,0 +1,37 @@
+package card
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/card"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func GetCardHandler(cardService card.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		token := mux.Vars(r)["card_token"]
+
+		c, err := cardService.GetByToken(ctx, token)
+		if err != nil {
+			lgr.Errorf("error in fetching card error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		if c == nil {
+			lgr.Errorf("card not found with card token %s", token)
+			responder.WriteError(w, r, errors.NewNotFoundError("card_not_found", &errors.ErrDetails{}))
+			return errors.Errorf("card not found")
+		}
+
+		responder.WriteAnyResponse(ctx, w, newCardResponse(c))
+		return nil
+	})
+}
@@ -0,0 +1,75 @@
+package card
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	cardRepo "github.com/shahbaz275817/prismo/internal/repository/card"
+	"github.com/shahbaz275817/prismo/internal/services/card"
+)
+
+func TestGetCardHandler_IsScopedToTenant(t *testing.T) {
+	tests := []struct {
+		name       string
+		tenantID   int64
+		rows       *sqlmock.Rows
+		statusCode int
+	}{
+		{
+			name:       "Card Of The Caller's Tenant",
+			tenantID:   1,
+			rows:       sqlmock.NewRows([]string{"card_id", "tenant_id", "account_id", "token", "masked_pan", "status"}).AddRow(3, 1, 7, "tok", "400000******1234", "active"),
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Card Of Another Tenant",
+			tenantID:   2,
+			rows:       sqlmock.NewRows([]string{"card_id", "tenant_id", "account_id", "token"}),
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+			assert.NoError(t, err)
+			assert.NoError(t, repository.RegisterTenantCallbacks(db))
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "cards" WHERE "cards"."token" = $1 AND "cards"."tenant_id" = $2`)).
+				WithArgs("tok", tt.tenantID, 1).
+				WillReturnRows(tt.rows)
+			if tt.statusCode == http.StatusOK {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			service := card.NewCardService(cardRepo.NewCardRepository(repository.NewAccessorFromDB(db, 1000)), nil, card.Config{})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/cards/tok", nil)
+			req = mux.SetURLVars(req, map[string]string{"card_token": "tok"})
+			req = req.WithContext(auth.WithPrincipal(req.Context(), auth.NewUserPrincipal("ops", tt.tenantID, auth.RoleViewer)))
+			w := httptest.NewRecorder()
+
+			GetCardHandler(service).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
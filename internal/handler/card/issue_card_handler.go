@@ -0,0 +1,65 @@
+package card
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/account"
+	"github.com/shahbaz275817/prismo/internal/services/card"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func IssueCardHandler(cardService card.Service, accountService account.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		accountIDStr := mux.Vars(r)["account_id"]
+
+		accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
+		if err != nil {
+			lgr.Errorf("invalid account_id: %s, error: %s", accountIDStr, err.Error())
+			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: "Invalid account ID",
+			}))
+			return err
+		}
+
+		acc, err := accountService.Get(ctx, &models.Account{AccountID: accountID})
+		if err != nil {
+			lgr.Errorf("error in fetching account id error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		if acc == nil {
+			lgr.Errorf("account not found with account id %d", accountID)
+			responder.WriteError(w, r, errors.NewNotFoundError("account_not_found", &errors.ErrDetails{}))
+			return errors.Errorf("account not found")
+		}
+		if acc.Erased() {
+			lgr.Errorf("account id %d is erased", accountID)
+			err = errors.NewStatusUnprocessableEntity("account_erased", &errors.ErrDetails{
+				Message: "account is erased",
+			})
+			responder.WriteError(w, r, err)
+			return err
+		}
+
+		c, err := cardService.Issue(ctx, accountID)
+		if err != nil {
+			lgr.Errorf("error in issuing card error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		responder.WriteAnyResponse(ctx, w, newCardResponse(c), http.StatusCreated)
+		return nil
+	})
+}
@@ -0,0 +1,12 @@
+package card
+
+import (
+	"net/http"
+
+	"github.com/shahbaz275817/prismo/internal/services/card"
+)
+
+// ReplaceCardHandler responds with the newly issued card; the old card is marked replaced.
+func ReplaceCardHandler(cardService card.Service) http.HandlerFunc {
+	return cardActionHandler(cardService.Replace, http.StatusCreated)
+}
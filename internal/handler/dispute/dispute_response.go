@@ -0,0 +1,66 @@
+package dispute
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+type disputeResponse struct {
+	DisputeID     int64                `json:"dispute_id"`
+	AccountID     int64                `json:"account_id"`
+	TransactionID *int64               `json:"transaction_id,omitempty"`
+	Status        models.DisputeStatus `json:"status"`
+	Reason        *string              `json:"reason,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	ResolvedAt    *time.Time           `json:"resolved_at,omitempty"`
+}
+
+func newDisputeResponse(d *models.Dispute) disputeResponse {
+	return disputeResponse{
+		DisputeID:     d.DisputeID,
+		AccountID:     d.AccountID,
+		TransactionID: d.TransactionID,
+		Status:        d.Status,
+		Reason:        d.Reason,
+		CreatedAt:     d.CreatedAt,
+		ResolvedAt:    d.ResolvedAt,
+	}
+}
+
+func disputeActionHandler(action func(ctx context.Context, disputeID int64) (*models.Dispute, error)) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		disputeIDStr := mux.Vars(r)["dispute_id"]
+
+		disputeID, err := strconv.ParseInt(disputeIDStr, 10, 64)
+		if err != nil {
+			lgr.Errorf("invalid dispute_id: %s, error: %s", disputeIDStr, err.Error())
+			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: "Invalid dispute ID",
+			}))
+			return err
+		}
+
+		d, err := action(ctx, disputeID)
+		if err != nil {
+			lgr.Errorf("error in updating dispute %d error: %s", disputeID, err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		responder.WriteAnyResponse(ctx, w, newDisputeResponse(d))
+		return nil
+	})
+}
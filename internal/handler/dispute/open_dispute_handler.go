@@ -0,0 +1,74 @@
+package dispute
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/dispute"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const reasonMaxLength = 1000
+
+func OpenDisputeHandler(disputeService dispute.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		var odReq openDisputeRequest
+		if err := json.NewDecoder(r.Body).Decode(&odReq); err != nil {
+			lgr.Errorf("error while decoding open_dispute_request body: %s", err.Error())
+			err = errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: "Something went wrong while parsing request",
+			})
+			responder.WriteError(w, r, err)
+			return err
+		}
+
+		err := validateOpenDisputeRequest(odReq)
+		if err != nil {
+			lgr.Errorf("invalid open dispute request error: %s", err.Error())
+			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: err.Error(),
+			}))
+			return err
+		}
+
+		d, err := disputeService.Open(ctx, models.Dispute{
+			AccountID:     odReq.AccountID,
+			TransactionID: odReq.TransactionID,
+			Reason:        odReq.Reason,
+		})
+		if err != nil {
+			lgr.Errorf("error in opening dispute error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		responder.WriteAnyResponse(ctx, w, newDisputeResponse(d), http.StatusCreated)
+		return nil
+	})
+}
+
+func validateOpenDisputeRequest(req openDisputeRequest) error {
+	if req.AccountID <= 0 {
+		return errors.New("invalid account_id: must be a positive integer")
+	}
+	if req.TransactionID != nil && *req.TransactionID <= 0 {
+		return errors.New("invalid transaction_id: must be a positive integer")
+	}
+	if req.Reason != nil && len(*req.Reason) > reasonMaxLength {
+		return errors.New("invalid reason: must be at most 1000 characters")
+	}
+	return nil
+}
+
+type openDisputeRequest struct {
+	AccountID     int64   `json:"account_id"`
+	TransactionID *int64  `json:"transaction_id"`
+	Reason        *string `json:"reason"`
+}
@@ -0,0 +1,98 @@
+package dispute
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/services/dispute/mocks"
+	pkgErrors "github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func TestOpenDisputeHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func(svc *mocks.MockDisputeService)
+		statusCode int
+	}{
+		{
+			name:       "Invalid Request Body",
+			body:       `{"account_id": "one"}`,
+			mockFunc:   func(svc *mocks.MockDisputeService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Validation Fails",
+			body:       `{"account_id": 1, "transaction_id": 0}`,
+			mockFunc:   func(svc *mocks.MockDisputeService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Opens A Dispute",
+			body: `{"account_id": 1, "reason": "not recognised"}`,
+			mockFunc: func(svc *mocks.MockDisputeService) {
+				svc.On("Open", mock.Anything, mock.MatchedBy(func(d models.Dispute) bool {
+					return d.AccountID == 1 && d.Reason != nil && *d.Reason == "not recognised"
+				})).Return(&models.Dispute{DisputeID: 3, AccountID: 1, Status: models.DisputeOpen}, nil).Once()
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name: "Erased Account",
+			body: `{"account_id": 1}`,
+			mockFunc: func(svc *mocks.MockDisputeService) {
+				svc.On("Open", mock.Anything, mock.Anything).
+					Return(nil, pkgErrors.NewStatusUnprocessableEntity("account_erased", &pkgErrors.ErrDetails{})).Once()
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Unknown Transaction",
+			body: `{"account_id": 1, "transaction_id": 9}`,
+			mockFunc: func(svc *mocks.MockDisputeService) {
+				svc.On("Open", mock.Anything, mock.Anything).
+					Return(nil, pkgErrors.NewStatusUnprocessableEntity("invalid_reference", &pkgErrors.ErrDetails{})).Once()
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Concurrent Update",
+			body: `{"account_id": 1}`,
+			mockFunc: func(svc *mocks.MockDisputeService) {
+				svc.On("Open", mock.Anything, mock.Anything).
+					Return(nil, pkgErrors.NewRetryableError("concurrent_update", &pkgErrors.ErrDetails{})).Once()
+			},
+			statusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name: "Service Error",
+			body: `{"account_id": 1}`,
+			mockFunc: func(svc *mocks.MockDisputeService) {
+				svc.On("Open", mock.Anything, mock.Anything).Return(nil, errors.New("some error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mocks.MockDisputeService{}
+			tt.mockFunc(svc)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/disputes", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			OpenDisputeHandler(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			svc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package dispute
+
+import (
+	"net/http"
+
+	"github.com/shahbaz275817/prismo/internal/services/dispute"
+)
+
+func RejectDisputeHandler(disputeService dispute.Service) http.HandlerFunc {
+	return disputeActionHandler(disputeService.Reject)
+}
@@ -0,0 +1,74 @@
+package reconciliation
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/reconciliation"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func GetReconciliationSummaryHandler(reconciliationService reconciliation.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		runIDStr := mux.Vars(r)["run_id"]
+
+		runID, err := strconv.ParseInt(runIDStr, 10, 64)
+		if err != nil {
+			lgr.Errorf("invalid run_id: %s, error: %s", runIDStr, err.Error())
+			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: "Invalid run ID",
+			}))
+			return err
+		}
+
+		run, err := reconciliationService.GetRun(ctx, runID)
+		if err != nil {
+			lgr.Errorf("error in fetching reconciliation run error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		if run == nil {
+			lgr.Errorf("reconciliation run not found with run id %d", runID)
+			responder.WriteError(w, r, errors.NewNotFoundError("reconciliation_run_not_found", &errors.ErrDetails{}))
+			return errors.Errorf("reconciliation run not found")
+		}
+
+		summary, err := reconciliationService.GetSummary(ctx, runID)
+		if err != nil {
+			lgr.Errorf("error in fetching reconciliation summary error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		res := getReconciliationSummaryResponse{
+			RunID:        run.RunID,
+			FileName:     run.FileName,
+			MatchKey:     run.MatchKey,
+			TotalRecords: run.TotalRecords,
+			CreatedAt:    run.CreatedAt,
+			Statuses:     summary,
+		}
+		responder.WriteAnyResponse(ctx, w, res)
+		return nil
+	})
+}
+
+type getReconciliationSummaryResponse struct {
+	RunID        int64                                `json:"run_id"`
+	FileName     string                               `json:"file_name"`
+	MatchKey     string                               `json:"match_key"`
+	TotalRecords int                                  `json:"total_records"`
+	CreatedAt    time.Time                            `json:"created_at"`
+	Statuses     []models.ReconciliationStatusSummary `json:"statuses"`
+}
@@ -4,8 +4,15 @@ import (
 	"net/http"
 
 	"github.com/shahbaz275817/prismo/internal/appcontext/server"
+	"github.com/shahbaz275817/prismo/internal/auth"
 	"github.com/shahbaz275817/prismo/internal/handler/account"
+	"github.com/shahbaz275817/prismo/internal/handler/audit"
+	"github.com/shahbaz275817/prismo/internal/handler/card"
+	"github.com/shahbaz275817/prismo/internal/handler/dispute"
+	"github.com/shahbaz275817/prismo/internal/handler/reconciliation"
 	"github.com/shahbaz275817/prismo/internal/handler/transaction"
+	"github.com/shahbaz275817/prismo/internal/handler/transfer"
+	"github.com/shahbaz275817/prismo/internal/handler/user"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/handlers"
@@ -38,19 +45,78 @@ func NewRouter(deps server.Dependencies) http.Handler {
 	router.Handle("/ping", PingHandler()).Methods(http.MethodGet)
 
 	appRouter := router.PathPrefix("/prismo").Subrouter()
-	appRouter.Use(middleware.WithHTTPAuth)
+	appRouter.Use(middleware.WithReadYourWrites)
+	appRouter.Use(middleware.WithAddressRateLimit(deps.RateLimiter))
+	appRouter.Use(middleware.WithHTTPAuth(middleware.HTTPAuthConfig{
+		Clients:      deps.APIClientService,
+		Tokens:       deps.TokenVerifier,
+		BasicEnabled: deps.BasicAuthEnabled,
+	}))
 
-	// Account Handlers
-	appRouter.Handle("/v1/accounts", account.CreateAccountHandler(deps.AccountService, deps.AtomicLock)).Methods(http.MethodPost)
-	appRouter.Handle("/v1/accounts/{account_id}", account.GetAccountHandler(deps.AccountService)).Methods(http.MethodGet)
-
-	// Transaction Handlers
-	appRouter.Handle("/v1/transactions", transaction.CreateTransactionHandler(deps.TransactionService, deps.OperationTypesService, deps.AccountService)).Methods(http.MethodPost)
+	registerRoutes(appRouter, appRoutes(deps), deps.RateLimiter)
 
 	newRouter := withAccessLog(withDefaultResponseHeaders(router))
 	return http.HandlerFunc(newRouter.ServeHTTP)
 }
 
+// route is an application endpoint and the permission a caller needs to reach it.
+type route struct {
+	method     string
+	path       string
+	permission auth.Permission
+	handler    http.Handler
+}
+
+func appRoutes(deps server.Dependencies) []route {
+	return []route{
+		// Account Handlers
+		{http.MethodPost, "/v1/accounts", auth.PermAccountsWrite, account.CreateAccountHandler(deps.AccountService, deps.AtomicLock)},
+		{http.MethodGet, "/v1/accounts/{account_id}", auth.PermAccountsRead, account.GetAccountHandler(deps.AccountService)},
+		{http.MethodPost, "/v1/accounts/{account_id}/erase", auth.PermAccountsErase, account.EraseAccountHandler(deps.ErasureService)},
+
+		// Transaction Handlers
+		{http.MethodPost, "/v1/transactions", auth.PermTransactionsWrite, transaction.CreateTransactionHandler(deps.TransactionService, deps.OperationTypesService, deps.AccountService, deps.CardService)},
+		{http.MethodGet, "/v1/transactions", auth.PermTransactionsRead, transaction.ListTransactionsHandler(deps.TransactionService, deps.MerchantService)},
+
+		// Transfer Handlers
+		{http.MethodPost, "/v1/transfers", auth.PermTransfersWrite, transfer.CreateTransferHandler(deps.TransferService)},
+
+		// Card Handlers
+		{http.MethodPost, "/v1/accounts/{account_id}/cards", auth.PermCardsWrite, card.IssueCardHandler(deps.CardService, deps.AccountService)},
+		{http.MethodGet, "/v1/cards/{card_token}", auth.PermCardsRead, card.GetCardHandler(deps.CardService)},
+		{http.MethodPost, "/v1/cards/{card_token}/activate", auth.PermCardsWrite, card.ActivateCardHandler(deps.CardService)},
+		{http.MethodPost, "/v1/cards/{card_token}/block", auth.PermCardsWrite, card.BlockCardHandler(deps.CardService)},
+		{http.MethodPost, "/v1/cards/{card_token}/replace", auth.PermCardsWrite, card.ReplaceCardHandler(deps.CardService)},
+
+		// Dispute Handlers
+		{http.MethodPost, "/v1/disputes", auth.PermDisputesWrite, dispute.OpenDisputeHandler(deps.DisputeService)},
+		{http.MethodPost, "/v1/disputes/{dispute_id}/resolve", auth.PermDisputesWrite, dispute.ResolveDisputeHandler(deps.DisputeService)},
+		{http.MethodPost, "/v1/disputes/{dispute_id}/reject", auth.PermDisputesWrite, dispute.RejectDisputeHandler(deps.DisputeService)},
+
+		// Reconciliation Handlers
+		{http.MethodGet, "/v1/reconciliations/{run_id}/summary", auth.PermReconciliationsRead, reconciliation.GetReconciliationSummaryHandler(deps.ReconciliationService)},
+
+		// User Handlers
+		{http.MethodPost, "/v1/users", auth.PermUsersWrite, user.CreateUserHandler(deps.UserService)},
+		{http.MethodGet, "/v1/users/{user_id}", auth.PermUsersRead, user.GetUserHandler(deps.UserService)},
+		{http.MethodPatch, "/v1/users/{user_id}", auth.PermUsersWrite, user.UpdateUserHandler(deps.UserService)},
+		{http.MethodPost, "/v1/users/{user_id}/activate", auth.PermUsersWrite, user.ActivateUserHandler(deps.UserService)},
+		{http.MethodPost, "/v1/users/{user_id}/deactivate", auth.PermUsersWrite, user.DeactivateUserHandler(deps.UserService)},
+
+		// Audit Handlers
+		{http.MethodGet, "/v1/audit-logs", auth.PermAuditRead, audit.ListAuditLogsHandler(deps.AuditService)},
+	}
+}
+
+// registerRoutes adds routes to router. Callers are checked for the route's permission before they use up their rate
+// limit, which is tracked per route under "<method> <path>".
+func registerRoutes(router *mux.Router, routes []route, limiter *middleware.RateLimiter) {
+	for _, r := range routes {
+		handler := middleware.WithRateLimit(limiter, r.method+" "+r.path, r.handler)
+		router.Handle(r.path, middleware.WithPermission(r.permission, handler)).Methods(r.method)
+	}
+}
+
 func withDefaultResponseHeaders(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
 		wr.Header().Add(frameOptionsKey, "SAMEORIGIN")
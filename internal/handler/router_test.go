@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shahbaz275817/prismo/internal/appcontext/server"
+	"github.com/shahbaz275817/prismo/internal/auth"
+	cardMocks "github.com/shahbaz275817/prismo/internal/services/card/mocks"
+	disputeMocks "github.com/shahbaz275817/prismo/internal/services/dispute/mocks"
+)
+
+func TestAppRoutesPermissions(t *testing.T) {
+	tests := []struct {
+		method  string
+		path    string
+		allowed []auth.Role
+	}{
+		{http.MethodPost, "/v1/accounts", []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodGet, "/v1/accounts/1", []auth.Role{auth.RoleViewer, auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPost, "/v1/accounts/1/erase", []auth.Role{auth.RoleAdmin}},
+		{http.MethodPost, "/v1/transactions", []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodGet, "/v1/transactions", []auth.Role{auth.RoleViewer, auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPost, "/v1/transfers", []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPost, "/v1/accounts/1/cards", []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodGet, "/v1/cards/tok", []auth.Role{auth.RoleViewer, auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPost, "/v1/cards/tok/activate", []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPost, "/v1/cards/tok/block", []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPost, "/v1/cards/tok/replace", []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPost, "/v1/disputes", []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPost, "/v1/disputes/1/resolve", []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPost, "/v1/disputes/1/reject", []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodGet, "/v1/reconciliations/1/summary", []auth.Role{auth.RoleViewer, auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPost, "/v1/users", []auth.Role{auth.RoleAdmin}},
+		{http.MethodGet, "/v1/users/1", []auth.Role{auth.RoleViewer, auth.RoleOperator, auth.RoleAdmin}},
+		{http.MethodPatch, "/v1/users/1", []auth.Role{auth.RoleAdmin}},
+		{http.MethodPost, "/v1/users/1/activate", []auth.Role{auth.RoleAdmin}},
+		{http.MethodPost, "/v1/users/1/deactivate", []auth.Role{auth.RoleAdmin}},
+		{http.MethodGet, "/v1/audit-logs", []auth.Role{auth.RoleAdmin}},
+	}
+
+	// card and dispute handlers bind service methods when they are built, so the services cannot be nil
+	routes := appRoutes(server.Dependencies{CardService: &cardMocks.MockCardService{}, DisputeService: &disputeMocks.MockDisputeService{}})
+	assert.Len(t, routes, len(tests), "every route needs a permission test")
+
+	// handlers are replaced so only routing and the permission check run
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	for i := range routes {
+		routes[i].handler = okHandler
+	}
+	router := mux.NewRouter()
+	registerRoutes(router, routes, nil)
+
+	for _, tt := range tests {
+		allowed := map[auth.Role]bool{}
+		for _, role := range tt.allowed {
+			allowed[role] = true
+		}
+
+		for _, role := range []auth.Role{auth.RoleViewer, auth.RoleOperator, auth.RoleAdmin} {
+			t.Run(tt.method+" "+tt.path+" as "+string(role), func(t *testing.T) {
+				req := httptest.NewRequest(tt.method, tt.path, nil)
+				req = req.WithContext(auth.WithPrincipal(req.Context(), auth.NewUserPrincipal("ops", 1, role)))
+				w := httptest.NewRecorder()
+
+				router.ServeHTTP(w, req)
+
+				want := http.StatusForbidden
+				if allowed[role] {
+					want = http.StatusOK
+				}
+				assert.Equal(t, want, w.Code)
+			})
+		}
+	}
+}
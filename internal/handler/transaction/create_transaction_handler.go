@@ -10,6 +10,8 @@ import (
 	"github.com/shahbaz275817/prismo/internal/models"
 	"github.com/shahbaz275817/prismo/internal/responder"
 	"github.com/shahbaz275817/prismo/internal/services/account"
+	"github.com/shahbaz275817/prismo/internal/services/card"
+	"github.com/shahbaz275817/prismo/internal/services/merchant"
 	"github.com/shahbaz275817/prismo/internal/services/operationtype"
 	"github.com/shahbaz275817/prismo/internal/services/transaction"
 	"github.com/shahbaz275817/prismo/internal/utils"
@@ -18,7 +20,15 @@ import (
 	"github.com/shahbaz275817/prismo/pkg/logger"
 )
 
-func CreateTransactionHandler(txnService transaction.Service, ots operationtype.Service, as account.Service) http.HandlerFunc {
+const (
+	externalReferenceMaxLength = 64
+	merchantIDMaxLength        = 64
+	merchantNameMaxLength      = 255
+	merchantCityMaxLength      = 64
+	merchantCountryLength      = 2
+)
+
+func CreateTransactionHandler(txnService transaction.Service, ots operationtype.Service, as account.Service, cs card.Service) http.HandlerFunc {
 	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
 
 		ctx, lgr := utils.ContextLogger(r)
@@ -42,24 +52,38 @@ func CreateTransactionHandler(txnService transaction.Service, ots operationtype.
 			return err
 		}
 
-		acc, err := as.Get(ctx, &models.Account{AccountID: ctReq.AccountID})
-		if err != nil {
-			lgr.Errorf("error in fetching account id error: %s", err.Error())
-			responder.WriteError(w, r, errors.NewInternalServerError(errcodes.InternalServerError, &errors.ErrDetails{}))
-			return err
-		}
-		if acc == nil {
-			lgr.Errorf("account not found for account id %d", ctReq.AccountID)
-			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
-				Message: "account not found",
-			}))
-			return err
+		var cardID *int64
+		if ctReq.CardToken != nil {
+			c, err := cs.GetByToken(ctx, *ctReq.CardToken)
+			if err != nil {
+				lgr.Errorf("error in fetching card error: %s", err.Error())
+				responder.WriteServiceError(w, r, err)
+				return err
+			}
+			if c == nil || c.Status != models.CardActive {
+				lgr.Errorf("no active card found for card token %s", *ctReq.CardToken)
+				err = errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+					Message: "card not found or not active",
+				})
+				responder.WriteError(w, r, err)
+				return err
+			}
+			if ctReq.AccountID > 0 && ctReq.AccountID != c.AccountID {
+				lgr.Errorf("card %s does not belong to account id %d", *ctReq.CardToken, ctReq.AccountID)
+				err = errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+					Message: "card does not belong to account",
+				})
+				responder.WriteError(w, r, err)
+				return err
+			}
+			ctReq.AccountID = c.AccountID
+			cardID = &c.CardID
 		}
 
 		ot, err := ots.Get(ctx, &models.OperationsType{OperationTypeID: ctReq.OperationTypeID})
 		if err != nil {
 			lgr.Errorf("error in fetching operation type error: %s", err.Error())
-			responder.WriteError(w, r, errors.NewInternalServerError(errcodes.InternalServerError, &errors.ErrDetails{}))
+			responder.WriteServiceError(w, r, err)
 			return err
 		}
 		if ot == nil {
@@ -71,17 +95,42 @@ func CreateTransactionHandler(txnService transaction.Service, ots operationtype.
 		}
 
 		err = txnService.Transact(ctx, func(ctx context.Context) error {
+			// the account stays locked until the posting commits, so it cannot be erased in between
+			acc, err := as.GetForShare(ctx, &models.Account{AccountID: ctReq.AccountID})
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return errors.NewNotFoundError("account_not_found", &errors.ErrDetails{
+					Message: "account not found",
+				})
+			}
+			if acc.Erased() {
+				return errors.NewStatusUnprocessableEntity("account_erased", &errors.ErrDetails{
+					Message: "account is erased",
+				})
+			}
+
 			_, err = txnService.Create(ctx, models.Transaction{
 				AccountID:       ctReq.AccountID,
 				OperationTypeID: ctReq.OperationTypeID,
 				Amount:          ctReq.Amount,
 				EventDate:       time.Now().UTC(),
+
+				ExternalReference: ctReq.ExternalReference,
+				CardID:            cardID,
+
+				MerchantID:      ctReq.MerchantID,
+				MerchantName:    ctReq.MerchantName,
+				MCC:             ctReq.MCC,
+				MerchantCity:    ctReq.MerchantCity,
+				MerchantCountry: ctReq.MerchantCountry,
 			})
 			return err
 		})
 		if err != nil {
 			logger.Errorf("error in creating transaction error: %s", err.Error())
-			responder.WriteError(w, r, errors.NewInternalServerError(errcodes.InternalServerError, &errors.ErrDetails{}))
+			responder.WriteServiceError(w, r, err)
 			return err
 		}
 		responder.WriteAnyResponse(ctx, w, map[string]string{
@@ -93,7 +142,7 @@ func CreateTransactionHandler(txnService transaction.Service, ots operationtype.
 }
 
 func validateCreateTransactionRequest(req createTransactionRequest) error {
-	if req.AccountID <= 0 {
+	if req.AccountID <= 0 && req.CardToken == nil {
 		return errors.New("invalid account_id: must be a positive integer")
 	}
 	if req.OperationTypeID <= 0 {
@@ -102,11 +151,50 @@ func validateCreateTransactionRequest(req createTransactionRequest) error {
 	if req.Amount <= 0 {
 		return errors.New("invalid amount: must be a positive value")
 	}
+	if req.ExternalReference != nil && len(*req.ExternalReference) > externalReferenceMaxLength {
+		return errors.New("invalid external_reference: must be at most 64 characters")
+	}
+	if req.MerchantID != nil && (*req.MerchantID == "" || len(*req.MerchantID) > merchantIDMaxLength) {
+		return errors.New("invalid merchant_id: must be between 1 and 64 characters")
+	}
+	if req.MerchantName != nil && len(*req.MerchantName) > merchantNameMaxLength {
+		return errors.New("invalid merchant_name: must be at most 255 characters")
+	}
+	if req.MCC != nil && !merchant.ValidMCC(*req.MCC) {
+		return errors.New("invalid mcc: must be 4 digits")
+	}
+	if req.MerchantCity != nil && len(*req.MerchantCity) > merchantCityMaxLength {
+		return errors.New("invalid merchant_city: must be at most 64 characters")
+	}
+	if req.MerchantCountry != nil && !validCountryCode(*req.MerchantCountry) {
+		return errors.New("invalid merchant_country: must be an ISO 3166-1 alpha-2 code")
+	}
 	return nil
 }
 
+func validCountryCode(code string) bool {
+	if len(code) != merchantCountryLength {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
 type createTransactionRequest struct {
 	AccountID       int64   `json:"account_id"`
 	OperationTypeID int64   `json:"operation_type_id"`
 	Amount          float64 `json:"amount"`
+
+	ExternalReference *string `json:"external_reference"`
+	CardToken         *string `json:"card_token"`
+
+	MerchantID      *string `json:"merchant_id"`
+	MerchantName    *string `json:"merchant_name"`
+	MCC             *string `json:"mcc"`
+	MerchantCity    *string `json:"merchant_city"`
+	MerchantCountry *string `json:"merchant_country"`
 }
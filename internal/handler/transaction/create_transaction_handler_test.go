@@ -8,13 +8,24 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
 	"github.com/shahbaz275817/prismo/internal/config"
 	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	accountRepo "github.com/shahbaz275817/prismo/internal/repository/account"
 	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/account"
 	mocks2 "github.com/shahbaz275817/prismo/internal/services/account/mocks"
+	mocks4 "github.com/shahbaz275817/prismo/internal/services/card/mocks"
 	mocks3 "github.com/shahbaz275817/prismo/internal/services/operationtype/mocks"
 	"github.com/shahbaz275817/prismo/internal/services/transaction/mocks"
 	"github.com/stretchr/testify/assert"
@@ -25,6 +36,7 @@ func TestCreateTransactionHandler(t *testing.T) {
 	txnSvc := mocks.MockTransactionService{}
 	accSvc := mocks2.MockAccountService{}
 	optSvc := mocks3.MockOperationtypeService{}
+	cardSvc := mocks4.MockCardService{}
 
 	type response struct {
 		body       responder.Response
@@ -79,7 +91,11 @@ func TestCreateTransactionHandler(t *testing.T) {
 			name:    "Fetch Account Returned Error",
 			request: getValidCreateTxnRequest(),
 			mockFunc: func() {
-				accSvc.On("Get", mock.Anything, &models.Account{AccountID: int64(1)}).Return(nil, errors.New("some error")).Once()
+				optSvc.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: int64(1)}).Return(&models.OperationsType{}, nil).Once()
+				txnSvc.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).Once()
+				accSvc.On("GetForShare", mock.Anything, &models.Account{AccountID: int64(1)}).Return(nil, errors.New("some error")).Once()
 			},
 			want: response{
 				body: responder.Response{
@@ -97,10 +113,60 @@ func TestCreateTransactionHandler(t *testing.T) {
 			},
 		},
 		{
-			name:    "Account not found",
+			name:    "Account Erased",
+			request: getValidCreateTxnRequest(),
+			mockFunc: func() {
+				erasedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+				optSvc.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: int64(1)}).Return(&models.OperationsType{}, nil).Once()
+				txnSvc.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).Once()
+				accSvc.On("GetForShare", mock.Anything, &models.Account{AccountID: int64(1)}).Return(&models.Account{AccountID: 1, ErasedAt: &erasedAt}, nil).Once()
+			},
+			want: response{
+				body: responder.Response{
+					Success: false,
+					Data:    nil,
+					Errors: []responder.ErrorItem{
+						{
+							Message:      "account is erased",
+							MessageTitle: "Unprocessable Entity",
+							Code:         "account_erased",
+						},
+					},
+				},
+				statusCode: 422,
+			},
+		},
+		{
+			name:    "Account Not Found",
+			request: getValidCreateTxnRequest(),
+			mockFunc: func() {
+				optSvc.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: int64(1)}).Return(&models.OperationsType{}, nil).Once()
+				txnSvc.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).Once()
+				accSvc.On("GetForShare", mock.Anything, &models.Account{AccountID: int64(1)}).Return(nil, nil).Once()
+			},
+			want: response{
+				body: responder.Response{
+					Success: false,
+					Data:    nil,
+					Errors: []responder.ErrorItem{
+						{
+							Message:      "account not found",
+							MessageTitle: "Not Found",
+							Code:         "account_not_found",
+						},
+					},
+				},
+				statusCode: 404,
+			},
+		},
+		{
+			name:    "Operation Type Not Found",
 			request: getValidCreateTxnRequest(),
 			mockFunc: func() {
-				accSvc.On("Get", mock.Anything, &models.Account{AccountID: int64(1)}).Return(&models.Account{}, nil).Once()
 				optSvc.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: int64(1)}).Return(nil, nil).Once()
 			},
 			want: response{
@@ -122,13 +188,13 @@ func TestCreateTransactionHandler(t *testing.T) {
 			name:    "Return Success",
 			request: getValidCreateTxnRequest(),
 			mockFunc: func() {
-				accSvc.On("Get", mock.Anything, &models.Account{AccountID: int64(1)}).Return(&models.Account{}, nil).Once()
 				optSvc.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: int64(1)}).Return(&models.OperationsType{}, nil).Once()
 				txnSvc.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
 					fn := args.Get(1).(func(context.Context) error)
 					err := fn(context.Background())
 					assert.NoError(t, err)
 				}).Once()
+				accSvc.On("GetForShare", mock.Anything, &models.Account{AccountID: int64(1)}).Return(&models.Account{}, nil).Once()
 				txnSvc.On("Create", mock.Anything, mock.Anything).Return(nil, nil).Once()
 			},
 			want: response{
@@ -139,6 +205,51 @@ func TestCreateTransactionHandler(t *testing.T) {
 				statusCode: 201,
 			},
 		},
+		{
+			name:    "Card Not Active",
+			request: getCardCreateTxnRequest(),
+			mockFunc: func() {
+				cardSvc.On("GetByToken", mock.Anything, "card-token").Return(&models.Card{CardID: 7, AccountID: 2, Status: models.CardBlocked}, nil).Once()
+			},
+			want: response{
+				body: responder.Response{
+					Success: false,
+					Data:    nil,
+					Errors: []responder.ErrorItem{
+						{
+							Message:      "card not found or not active",
+							MessageTitle: "Bad Request",
+							Code:         "BAD_REQUEST",
+						},
+					},
+				},
+				statusCode: 400,
+			},
+		},
+		{
+			name:    "Return Success With Card Token",
+			request: getCardCreateTxnRequest(),
+			mockFunc: func() {
+				cardSvc.On("GetByToken", mock.Anything, "card-token").Return(&models.Card{CardID: 7, AccountID: 2, Status: models.CardActive}, nil).Once()
+				accSvc.On("GetForShare", mock.Anything, &models.Account{AccountID: int64(2)}).Return(&models.Account{}, nil).Once()
+				optSvc.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: int64(1)}).Return(&models.OperationsType{}, nil).Once()
+				txnSvc.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					err := fn(context.Background())
+					assert.NoError(t, err)
+				}).Once()
+				txnSvc.On("Create", mock.Anything, mock.MatchedBy(func(txn models.Transaction) bool {
+					return txn.AccountID == 2 && txn.CardID != nil && *txn.CardID == 7
+				})).Return(nil, nil).Once()
+			},
+			want: response{
+				body: responder.Response{
+					Success: true,
+					Errors:  []responder.ErrorItem{},
+				},
+				statusCode: 201,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -148,7 +259,7 @@ func TestCreateTransactionHandler(t *testing.T) {
 			r, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/transactions"), tt.request)
 			assert.NoError(t, err)
 
-			handler := CreateTransactionHandler(&txnSvc, &optSvc, &accSvc)
+			handler := CreateTransactionHandler(&txnSvc, &optSvc, &accSvc, &cardSvc)
 			handler(w, r)
 
 			assert.Equal(t, tt.want.statusCode, w.Code)
@@ -162,6 +273,7 @@ func TestCreateTransactionHandler(t *testing.T) {
 			txnSvc.AssertExpectations(t)
 			accSvc.AssertExpectations(t)
 			optSvc.AssertExpectations(t)
+			cardSvc.AssertExpectations(t)
 		})
 	}
 }
@@ -176,6 +288,16 @@ func getValidCreateTxnRequest() io.Reader {
 	return strings.NewReader(string(b))
 }
 
+func getCardCreateTxnRequest() io.Reader {
+	bodyMap := map[string]interface{}{
+		"card_token":        "card-token",
+		"operation_type_id": 1,
+		"amount":            123.1,
+	}
+	b, _ := json.Marshal(bodyMap)
+	return strings.NewReader(string(b))
+}
+
 func getValidationFailCreateTxnRequest() io.Reader {
 	bodyMap := map[string]interface{}{
 		"operation_type_id": 1,
@@ -194,3 +316,40 @@ func getInvalidCreateTxnRequest() io.Reader {
 	b, _ := json.Marshal(bodyMap)
 	return strings.NewReader(string(b))
 }
+
+func TestCreateTransactionHandler_IsScopedToTenant(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, repository.RegisterTenantCallbacks(db))
+
+	// account 1 belongs to tenant 1, so tenant 2 cannot post to it
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1 AND "accounts"."tenant_id" = $2 ORDER BY "accounts"."account_id" LIMIT $3 FOR SHARE`)).
+		WithArgs(int64(1), int64(2), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id"}))
+	sqlMock.ExpectRollback()
+
+	txnSvc := mocks.MockTransactionService{}
+	txnSvc.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}).Once()
+	optSvc := mocks3.MockOperationtypeService{}
+	optSvc.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: int64(1)}).Return(&models.OperationsType{}, nil).Once()
+	cardSvc := mocks4.MockCardService{}
+	accSvc := account.NewAccountService(accountRepo.NewAccountRepository(repository.NewAccessorFromDB(db, 1000)))
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/transactions", getValidCreateTxnRequest())
+	r = r.WithContext(auth.WithPrincipal(r.Context(), auth.NewUserPrincipal("ops", 2, auth.RoleOperator)))
+	w := httptest.NewRecorder()
+
+	CreateTransactionHandler(&txnSvc, &optSvc, accSvc, &cardSvc)(w, r)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+	txnSvc.AssertExpectations(t)
+	optSvc.AssertExpectations(t)
+}
@@ -0,0 +1,162 @@
+package transaction
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	handlerUtils "github.com/shahbaz275817/prismo/internal/handler/utils"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/merchant"
+	"github.com/shahbaz275817/prismo/internal/services/transaction"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const (
+	listSortColumn = "eventdate"
+	listIDColumn   = "transaction_id"
+)
+
+func ListTransactionsHandler(txnService transaction.Service, ms merchant.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		query, filter, err := parseListTransactionsRequest(r.URL.Query(), ms)
+		if err != nil {
+			lgr.Errorf("invalid list transactions request error: %s", err.Error())
+			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: err.Error(),
+			}))
+			return err
+		}
+
+		transactions, page, err := txnService.GetPage(ctx, query, filter)
+		if err != nil {
+			lgr.Errorf("error in listing transactions error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		res := listTransactionsResponse{
+			Transactions: make([]transactionResponse, 0, len(transactions)),
+			NextCursor:   page.NextCursor,
+			TotalCount:   page.TotalCount,
+		}
+		for _, txn := range transactions {
+			res.Transactions = append(res.Transactions, newTransactionResponse(txn, ms.Category(txn.MCC)))
+		}
+		responder.WriteAnyResponse(ctx, w, res)
+		return nil
+	})
+}
+
+func parseListTransactionsRequest(values url.Values, ms merchant.Service) (*models.Transaction, repository.FilterRequest, error) {
+	query := &models.Transaction{}
+	filter, err := handlerUtils.ParsePageRequest(values, listSortColumn, listIDColumn)
+	if err != nil {
+		return nil, filter, err
+	}
+	filter.In = map[string][]string{}
+
+	if v := values.Get("account_id"); v != "" {
+		accountID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || accountID <= 0 {
+			return nil, filter, errors.New("invalid account_id: must be a positive integer")
+		}
+		query.AccountID = accountID
+	}
+
+	if v := values.Get("merchant_id"); v != "" {
+		query.MerchantID = &v
+	}
+
+	var mccs []string
+	if v := values.Get("mcc"); v != "" {
+		for _, mcc := range strings.Split(v, ",") {
+			mcc = strings.TrimSpace(mcc)
+			if !merchant.ValidMCC(mcc) {
+				return nil, filter, errors.New("invalid mcc: must be a comma separated list of 4 digit codes")
+			}
+			mccs = append(mccs, mcc)
+		}
+	}
+
+	if v := values.Get("category"); v != "" {
+		categoryMCCs := ms.MCCs(v)
+		if len(categoryMCCs) == 0 {
+			return nil, filter, errors.New("invalid category: unknown category")
+		}
+		if mccs != nil {
+			categoryMCCs = intersect(mccs, categoryMCCs)
+		}
+		mccs = categoryMCCs
+	}
+	if mccs != nil {
+		filter.In["mcc"] = mccs
+	}
+
+	return query, filter, nil
+}
+
+// intersect keeps the codes of a that are also in b. An empty result still filters, so it matches no transactions.
+func intersect(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+
+	res := []string{}
+	for _, v := range a {
+		if in[v] {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+type listTransactionsResponse struct {
+	Transactions []transactionResponse `json:"transactions"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+	TotalCount   *int64                `json:"total_count,omitempty"`
+}
+
+type transactionResponse struct {
+	TransactionID     int64     `json:"transaction_id"`
+	AccountID         int64     `json:"account_id"`
+	OperationTypeID   int64     `json:"operation_type_id"`
+	Amount            float64   `json:"amount"`
+	EventDate         time.Time `json:"event_date"`
+	ExternalReference *string   `json:"external_reference,omitempty"`
+	CardID            *int64    `json:"card_id,omitempty"`
+	MerchantID        *string   `json:"merchant_id,omitempty"`
+	MerchantName      *string   `json:"merchant_name,omitempty"`
+	MCC               *string   `json:"mcc,omitempty"`
+	MerchantCity      *string   `json:"merchant_city,omitempty"`
+	MerchantCountry   *string   `json:"merchant_country,omitempty"`
+	Category          string    `json:"category"`
+}
+
+func newTransactionResponse(txn models.Transaction, category string) transactionResponse {
+	return transactionResponse{
+		TransactionID:     txn.TransactionID,
+		AccountID:         txn.AccountID,
+		OperationTypeID:   txn.OperationTypeID,
+		Amount:            txn.Amount,
+		EventDate:         txn.EventDate,
+		ExternalReference: txn.ExternalReference,
+		CardID:            txn.CardID,
+		MerchantID:        txn.MerchantID,
+		MerchantName:      txn.MerchantName,
+		MCC:               txn.MCC,
+		MerchantCity:      txn.MerchantCity,
+		MerchantCountry:   txn.MerchantCountry,
+		Category:          category,
+	}
+}
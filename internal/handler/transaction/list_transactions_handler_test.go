@@ -0,0 +1,128 @@
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	handlerUtils "github.com/shahbaz275817/prismo/internal/handler/utils"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	mocks5 "github.com/shahbaz275817/prismo/internal/services/merchant/mocks"
+	"github.com/shahbaz275817/prismo/internal/services/transaction/mocks"
+)
+
+func TestListTransactionsHandler(t *testing.T) {
+	txnSvc := mocks.MockTransactionService{}
+	merchantSvc := mocks5.MockMerchantService{}
+
+	mcc := "5812"
+	count := int64(1)
+	cursor, _ := repository.EncodeCursor(repository.Cursor{Column: listSortColumn, Value: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), ID: 3})
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func()
+		statusCode int
+		wantBody   string
+		wantPage   repository.Page
+	}{
+		{
+			name:       "Invalid MCC",
+			query:      "?mcc=58a2",
+			mockFunc:   func() {},
+			statusCode: 400,
+		},
+		{
+			name:  "Unknown Category",
+			query: "?category=travel",
+			mockFunc: func() {
+				merchantSvc.On("MCCs", "travel").Return(nil).Once()
+			},
+			statusCode: 400,
+		},
+		{
+			name:       "Invalid Cursor",
+			query:      "?cursor=bm90LWEtY3Vyc29y",
+			mockFunc:   func() {},
+			statusCode: 400,
+		},
+		{
+			name:  "Next Page Without Count",
+			query: "?account_id=1&cursor=" + cursor + "&skip_count=true",
+			mockFunc: func() {
+				txnSvc.On("GetPage", mock.Anything, &models.Transaction{AccountID: 1}, repository.FilterRequest{
+					Limit:     handlerUtils.DefaultPageLimit,
+					In:        map[string][]string{},
+					SkipCount: true,
+					Keyset: &repository.KeysetPagination{
+						Column:     listSortColumn,
+						IDColumn:   listIDColumn,
+						Descending: true,
+						After:      &repository.Cursor{Column: listSortColumn, Value: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), ID: 3},
+					},
+				}).Return([]models.Transaction{{TransactionID: 2, AccountID: 1}}, repository.Page{NextCursor: "next"}, nil).Once()
+				merchantSvc.On("Category", (*string)(nil)).Return("uncategorized").Once()
+			},
+			statusCode: 200,
+			wantBody:   "uncategorized",
+			wantPage:   repository.Page{NextCursor: "next"},
+		},
+		{
+			name:  "Service Error",
+			query: "?account_id=1",
+			mockFunc: func() {
+				txnSvc.On("GetPage", mock.Anything, &models.Transaction{AccountID: 1}, mock.Anything).
+					Return(nil, repository.Page{}, errors.New("some error")).Once()
+			},
+			statusCode: 500,
+		},
+		{
+			name:  "Filters By Category And MCC",
+			query: "?account_id=1&category=restaurants&mcc=5812,5411&limit=10",
+			mockFunc: func() {
+				merchantSvc.On("MCCs", "restaurants").Return([]string{"5811", "5812", "5813", "5814"}).Once()
+				txnSvc.On("GetPage", mock.Anything, &models.Transaction{AccountID: 1}, repository.FilterRequest{
+					Limit:  10,
+					In:     map[string][]string{"mcc": {"5812"}},
+					Keyset: &repository.KeysetPagination{Column: listSortColumn, IDColumn: listIDColumn, Descending: true},
+				}).Return([]models.Transaction{{TransactionID: 3, AccountID: 1, MCC: &mcc}}, repository.Page{TotalCount: &count}, nil).Once()
+				merchantSvc.On("Category", &mcc).Return("restaurants").Once()
+			},
+			statusCode: 200,
+			wantBody:   "restaurants",
+			wantPage:   repository.Page{TotalCount: &count},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Load()
+			w := httptest.NewRecorder()
+			tt.mockFunc()
+			r, err := http.NewRequest(http.MethodGet, "/v1/transactions"+tt.query, nil)
+			assert.NoError(t, err)
+
+			handler := ListTransactionsHandler(&txnSvc, &merchantSvc)
+			handler(w, r)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if tt.statusCode == 200 {
+				var res listTransactionsResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+				assert.Equal(t, tt.wantBody, res.Transactions[0].Category)
+				assert.Equal(t, tt.wantPage.NextCursor, res.NextCursor)
+				assert.Equal(t, tt.wantPage.TotalCount, res.TotalCount)
+			}
+			txnSvc.AssertExpectations(t)
+			merchantSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package transfer
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/transfer"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const clientReferenceMaxLength = 64
+
+func CreateTransferHandler(transferService transfer.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		var ctReq createTransferRequest
+		if err := json.NewDecoder(r.Body).Decode(&ctReq); err != nil {
+			lgr.Errorf("error while decoding create_transfer_request body: %s", err.Error())
+			err = errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: "Something went wrong while parsing request",
+			})
+			responder.WriteError(w, r, err)
+			return err
+		}
+
+		err := validateCreateTransferRequest(ctReq)
+		if err != nil {
+			lgr.Errorf("invalid create transfer request error: %s", err.Error())
+			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: err.Error(),
+			}))
+			return err
+		}
+
+		trf, created, err := transferService.Create(ctx, models.Transfer{
+			ClientReference:      ctReq.ClientReference,
+			SourceAccountID:      ctReq.SourceAccountID,
+			DestinationAccountID: ctReq.DestinationAccountID,
+			Amount:               ctReq.Amount,
+		})
+		if err != nil {
+			lgr.Errorf("error in creating transfer error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+		responder.WriteAnyResponse(ctx, w, transferResponse{
+			TransferID:           trf.TransferID,
+			ClientReference:      trf.ClientReference,
+			SourceAccountID:      trf.SourceAccountID,
+			DestinationAccountID: trf.DestinationAccountID,
+			Amount:               trf.Amount,
+			DebitTransactionID:   trf.DebitTransactionID,
+			CreditTransactionID:  trf.CreditTransactionID,
+			CreatedAt:            trf.CreatedAt,
+		}, status)
+		return nil
+	})
+}
+
+func validateCreateTransferRequest(req createTransferRequest) error {
+	if req.ClientReference == "" || len(req.ClientReference) > clientReferenceMaxLength {
+		return errors.New("invalid client_reference: must be between 1 and 64 characters")
+	}
+	if req.SourceAccountID <= 0 {
+		return errors.New("invalid source_account_id: must be a positive integer")
+	}
+	if req.DestinationAccountID <= 0 {
+		return errors.New("invalid destination_account_id: must be a positive integer")
+	}
+	if req.SourceAccountID == req.DestinationAccountID {
+		return errors.New("invalid destination_account_id: must differ from source_account_id")
+	}
+	if req.Amount <= 0 {
+		return errors.New("invalid amount: must be a positive value")
+	}
+	return nil
+}
+
+type createTransferRequest struct {
+	ClientReference      string  `json:"client_reference"`
+	SourceAccountID      int64   `json:"source_account_id"`
+	DestinationAccountID int64   `json:"destination_account_id"`
+	Amount               float64 `json:"amount"`
+}
+
+type transferResponse struct {
+	TransferID           int64     `json:"transfer_id"`
+	ClientReference      string    `json:"client_reference"`
+	SourceAccountID      int64     `json:"source_account_id"`
+	DestinationAccountID int64     `json:"destination_account_id"`
+	Amount               float64   `json:"amount"`
+	DebitTransactionID   int64     `json:"debit_transaction_id"`
+	CreditTransactionID  int64     `json:"credit_transaction_id"`
+	CreatedAt            time.Time `json:"created_at"`
+}
@@ -0,0 +1,66 @@
+package transfer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	accountRepo "github.com/shahbaz275817/prismo/internal/repository/account"
+	transactionRepo "github.com/shahbaz275817/prismo/internal/repository/transaction"
+	transferRepo "github.com/shahbaz275817/prismo/internal/repository/transfer"
+	"github.com/shahbaz275817/prismo/internal/services/account"
+	"github.com/shahbaz275817/prismo/internal/services/transfer"
+	"github.com/shahbaz275817/prismo/pkg/locks"
+)
+
+func TestCreateTransferHandler_IsScopedToTenant(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, repository.RegisterTenantCallbacks(db))
+
+	// the source account belongs to tenant 1, so tenant 2 finds nothing to debit
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transfers" WHERE "transfers"."client_reference" = $1 AND "transfers"."tenant_id" = $2`)).
+		WithArgs("ref-1", int64(2), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"transfer_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE "accounts"."account_id" = $1 AND "accounts"."tenant_id" = $2`)).
+		WithArgs(int64(7), int64(2), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id"}))
+	mock.ExpectRollback()
+
+	accessor := repository.NewAccessorFromDB(db, 1000)
+	lock := locks.NewAtomicLock(locks.NewMemoryLocker(), map[locks.KeyType]locks.LockConfig{
+		locks.Def: {LockExpiry: time.Second, RetryAttempts: 1, RetryDelay: time.Millisecond},
+	}, nil)
+	service := transfer.NewTransferService(
+		transferRepo.NewTransferRepository(accessor),
+		account.NewAccountService(accountRepo.NewAccountRepository(accessor)),
+		transactionRepo.NewTransactionRepository(accessor),
+		lock,
+		transfer.Config{TransferOutOperationTypeID: 7, TransferInOperationTypeID: 8},
+	)
+
+	body := `{"client_reference": "ref-1", "source_account_id": 7, "destination_account_id": 8, "amount": 10}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/transfers", strings.NewReader(body))
+	req = req.WithContext(auth.WithPrincipal(req.Context(), auth.NewUserPrincipal("ops", 2, auth.RoleOperator)))
+	w := httptest.NewRecorder()
+
+	CreateTransferHandler(service).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
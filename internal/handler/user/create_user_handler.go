@@ -0,0 +1,60 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/user"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func CreateUserHandler(userService user.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		var cuReq createUserRequest
+		if err := json.NewDecoder(r.Body).Decode(&cuReq); err != nil {
+			lgr.Errorf("error while decoding create_user_request body: %s", err.Error())
+			err = errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: "Something went wrong while parsing request",
+			})
+			responder.WriteError(w, r, err)
+			return err
+		}
+
+		if err := validateCreateUserRequest(cuReq); err != nil {
+			lgr.Errorf("invalid create user request error: %s", err.Error())
+			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: err.Error(),
+			}))
+			return err
+		}
+
+		u := &models.User{Name: cuReq.Name, Email: cuReq.Email, IsActive: true}
+		if err := userService.Create(ctx, u); err != nil {
+			lgr.Errorf("error in creating user error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		responder.WriteAnyResponse(ctx, w, newUserResponse(u), http.StatusCreated)
+		return nil
+	})
+}
+
+func validateCreateUserRequest(req createUserRequest) error {
+	if err := validateName(req.Name); err != nil {
+		return err
+	}
+	return validateEmail(req.Email)
+}
+
+type createUserRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
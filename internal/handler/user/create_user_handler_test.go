@@ -0,0 +1,95 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/services/user/mocks"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func TestCreateUserHandler(t *testing.T) {
+	newUser := &models.User{Name: "Jane", Email: "jane@example.com", IsActive: true}
+
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func(svc *mocks.MockUserService)
+		statusCode int
+		wantBody   string
+	}{
+		{
+			name:       "Invalid Body",
+			body:       `{"name":`,
+			mockFunc:   func(svc *mocks.MockUserService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Missing Name",
+			body:       `{"email":"jane@example.com"}`,
+			mockFunc:   func(svc *mocks.MockUserService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid Email",
+			body:       `{"name":"Jane","email":"Jane <jane@example.com>"}`,
+			mockFunc:   func(svc *mocks.MockUserService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Duplicate Email",
+			body: `{"name":"Jane","email":"jane@example.com"}`,
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("Create", mock.Anything, newUser).Return(errors.NewStatusUnprocessableEntity("email_already_registered", &errors.ErrDetails{
+					Message: "a user with this email already exists",
+				})).Once()
+			},
+			statusCode: http.StatusUnprocessableEntity,
+			wantBody:   `"code":"email_already_registered"`,
+		},
+		{
+			name: "Create Fails",
+			body: `{"name":"Jane","email":"jane@example.com"}`,
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("Create", mock.Anything, newUser).Return(errors.New("db down")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "Return Success",
+			body: `{"name":"Jane","email":"jane@example.com"}`,
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("Create", mock.Anything, newUser).Run(func(args mock.Arguments) {
+					id := int64(9)
+					args.Get(1).(*models.User).ID = &id
+				}).Return(nil).Once()
+			},
+			statusCode: http.StatusCreated,
+			wantBody:   `"user_id":9`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Load()
+			svc := &mocks.MockUserService{}
+			tt.mockFunc(svc)
+
+			r, err := http.NewRequest(http.MethodPost, "/v1/users", strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			w := httptest.NewRecorder()
+
+			CreateUserHandler(svc)(w, r)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+			svc.AssertExpectations(t)
+		})
+	}
+}
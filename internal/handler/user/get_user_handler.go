@@ -0,0 +1,39 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/user"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func GetUserHandler(userService user.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		userID, err := parseUserID(w, r)
+		if err != nil {
+			lgr.Errorf("invalid user_id error: %s", err.Error())
+			return err
+		}
+
+		u, err := userService.GetByID(ctx, userID)
+		if err != nil {
+			lgr.Errorf("error in fetching user error: %s", err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		if u == nil {
+			lgr.Errorf("user not found with user id %d", userID)
+			responder.WriteError(w, r, errors.NewNotFoundError("user_not_found", &errors.ErrDetails{}))
+			return errors.Errorf("user not found")
+		}
+
+		responder.WriteAnyResponse(ctx, w, newUserResponse(u))
+		return nil
+	})
+}
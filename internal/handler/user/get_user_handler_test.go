@@ -0,0 +1,147 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	userRepo "github.com/shahbaz275817/prismo/internal/repository/user"
+	"github.com/shahbaz275817/prismo/internal/services/user"
+	"github.com/shahbaz275817/prismo/internal/services/user/mocks"
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func TestGetUserHandler(t *testing.T) {
+	userID := int64(3)
+
+	tests := []struct {
+		name       string
+		userID     string
+		mockFunc   func(svc *mocks.MockUserService)
+		statusCode int
+		wantBody   string
+	}{
+		{
+			name:       "Invalid User ID",
+			userID:     "abc",
+			mockFunc:   func(svc *mocks.MockUserService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "User Not Found",
+			userID: "3",
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("GetByID", mock.Anything, userID).Return(nil, nil).Once()
+			},
+			statusCode: http.StatusNotFound,
+			wantBody:   `"code":"user_not_found"`,
+		},
+		{
+			name:   "Service Error",
+			userID: "3",
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("GetByID", mock.Anything, userID).Return(nil, errors.New("db down")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "Return Success",
+			userID: "3",
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("GetByID", mock.Anything, userID).Return(&models.User{ID: &userID, Name: "Jane", Email: "jane@example.com", IsActive: true}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+			wantBody:   `"email":"jane@example.com"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Load()
+			svc := &mocks.MockUserService{}
+			tt.mockFunc(svc)
+
+			r, err := http.NewRequest(http.MethodGet, "/v1/users/"+tt.userID, nil)
+			assert.NoError(t, err)
+			r = mux.SetURLVars(r, map[string]string{"user_id": tt.userID})
+			w := httptest.NewRecorder()
+
+			GetUserHandler(svc)(w, r)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+			svc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetUserHandler_IsScopedToTenant(t *testing.T) {
+	tests := []struct {
+		name       string
+		tenantID   int64
+		rows       *sqlmock.Rows
+		statusCode int
+	}{
+		{
+			name:       "User Of The Caller's Tenant",
+			tenantID:   1,
+			rows:       sqlmock.NewRows([]string{"id", "tenant_id", "name", "email", "is_active"}).AddRow(5, 1, "Jane Doe", "jane@example.com", true),
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "User Of Another Tenant",
+			tenantID:   2,
+			rows:       sqlmock.NewRows([]string{"id", "tenant_id", "name", "email", "is_active"}),
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer sqlDB.Close()
+
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+			assert.NoError(t, err)
+			assert.NoError(t, repository.RegisterTenantCallbacks(db))
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."id" = $1 AND "users"."tenant_id" = $2`)).
+				WithArgs(int64(5), tt.tenantID, 1).
+				WillReturnRows(tt.rows)
+			if tt.statusCode == http.StatusOK {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			repo := userRepo.NewUserRepository(repository.NewAccessorFromDB(db, 1000))
+			cache, err := inmemory.NewInMemCache(inmemory.CacheConfig{LoaderFunc: user.CacheLoader(repo), Size: 10, Name: "users"})
+			assert.NoError(t, err)
+			service := user.NewUserService(repo, cache)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/5", nil)
+			req = mux.SetURLVars(req, map[string]string{"user_id": "5"})
+			req = req.WithContext(auth.WithPrincipal(req.Context(), auth.NewUserPrincipal("ops", tt.tenantID, auth.RoleViewer)))
+			w := httptest.NewRecorder()
+
+			GetUserHandler(service).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/user"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func UpdateUserHandler(userService user.Service) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		userID, err := parseUserID(w, r)
+		if err != nil {
+			lgr.Errorf("invalid user_id error: %s", err.Error())
+			return err
+		}
+
+		var uuReq updateUserRequest
+		if err := json.NewDecoder(r.Body).Decode(&uuReq); err != nil {
+			lgr.Errorf("error while decoding update_user_request body: %s", err.Error())
+			err = errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: "Something went wrong while parsing request",
+			})
+			responder.WriteError(w, r, err)
+			return err
+		}
+
+		update, err := updateUserFields(uuReq)
+		if err != nil {
+			lgr.Errorf("invalid update user request error: %s", err.Error())
+			responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+				Message: err.Error(),
+			}))
+			return err
+		}
+
+		u, err := userService.GetByID(ctx, userID)
+		if err == nil && u == nil {
+			err = errors.NewNotFoundError("user_not_found", &errors.ErrDetails{Message: "user not found"})
+		}
+		if err == nil && uuReq.Email != nil && *uuReq.Email != u.Email {
+			err = checkEmailAvailable(ctx, userService, *uuReq.Email)
+		}
+		if err == nil {
+			err = userService.Update(ctx, u, update)
+		}
+		if err == nil {
+			u, err = userService.GetByID(ctx, userID)
+		}
+		if err != nil {
+			lgr.Errorf("error in updating user %d error: %s", userID, err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		responder.WriteAnyResponse(ctx, w, newUserResponse(u))
+		return nil
+	})
+}
+
+func updateUserFields(req updateUserRequest) (map[string]interface{}, error) {
+	update := map[string]interface{}{}
+	if req.Name != nil {
+		if err := validateName(*req.Name); err != nil {
+			return nil, err
+		}
+		update["name"] = *req.Name
+	}
+	if req.Email != nil {
+		if err := validateEmail(*req.Email); err != nil {
+			return nil, err
+		}
+		update["email"] = *req.Email
+	}
+	if len(update) == 0 {
+		return nil, errors.New("nothing to update: name or email is required")
+	}
+	return update, nil
+}
+
+type updateUserRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+func checkEmailAvailable(ctx context.Context, userService user.Service, email string) error {
+	// a lagging replica could miss a user just registered with the email
+	existing, err := userService.GetByEmail(repository.WithPrimaryReads(ctx), email)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.NewStatusUnprocessableEntity("email_already_registered", &errors.ErrDetails{
+			Message: "a user with this email already exists",
+		})
+	}
+	return nil
+}
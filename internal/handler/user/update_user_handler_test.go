@@ -0,0 +1,117 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/services/user/mocks"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func TestUpdateUserHandler(t *testing.T) {
+	userID := int64(3)
+	existing := func() *models.User {
+		return &models.User{ID: &userID, Name: "Jane", Email: "jane@example.com", IsActive: true}
+	}
+
+	tests := []struct {
+		name       string
+		userID     string
+		body       string
+		mockFunc   func(svc *mocks.MockUserService)
+		statusCode int
+	}{
+		{
+			name:       "Invalid User ID",
+			userID:     "abc",
+			body:       `{"name":"Janet"}`,
+			mockFunc:   func(svc *mocks.MockUserService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Nothing To Update",
+			userID:     "3",
+			body:       `{}`,
+			mockFunc:   func(svc *mocks.MockUserService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid Email",
+			userID:     "3",
+			body:       `{"email":"not-an-email"}`,
+			mockFunc:   func(svc *mocks.MockUserService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "User Not Found",
+			userID: "3",
+			body:   `{"name":"Janet"}`,
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("GetByID", mock.Anything, userID).Return(nil, nil).Once()
+			},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:   "Email Owned By Another User",
+			userID: "3",
+			body:   `{"email":"john@example.com"}`,
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("GetByID", mock.Anything, userID).Return(existing(), nil).Once()
+				svc.On("GetByEmail", mock.Anything, "john@example.com").Return(&models.User{Email: "john@example.com"}, nil).Once()
+			},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "Update Fails",
+			userID: "3",
+			body:   `{"name":"Janet"}`,
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("GetByID", mock.Anything, userID).Return(existing(), nil).Once()
+				svc.On("Update", mock.Anything, existing(), map[string]interface{}{"name": "Janet"}).Return(errors.New("db down")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "Return Success",
+			userID: "3",
+			body:   `{"name":"Janet","email":"janet@example.com"}`,
+			mockFunc: func(svc *mocks.MockUserService) {
+				updated := existing()
+				updated.Name, updated.Email = "Janet", "janet@example.com"
+				svc.On("GetByID", mock.Anything, userID).Return(existing(), nil).Once()
+				svc.On("GetByEmail", mock.Anything, "janet@example.com").Return(nil, nil).Once()
+				svc.On("Update", mock.Anything, existing(), map[string]interface{}{"name": "Janet", "email": "janet@example.com"}).Return(nil).Once()
+				svc.On("GetByID", mock.Anything, userID).Return(updated, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Load()
+			svc := &mocks.MockUserService{}
+			tt.mockFunc(svc)
+
+			r, err := http.NewRequest(http.MethodPatch, "/v1/users/"+tt.userID, strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			r = mux.SetURLVars(r, map[string]string{"user_id": tt.userID})
+			w := httptest.NewRecorder()
+
+			UpdateUserHandler(svc)(w, r)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			if w.Code == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"email":"janet@example.com"`)
+			}
+			svc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"net/http"
+	"net/mail"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const fieldMaxLength = 255
+
+type userResponse struct {
+	UserID    int64     `json:"user_id"`
+	HubID     int64     `json:"hub_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newUserResponse(u *models.User) userResponse {
+	res := userResponse{
+		HubID:     u.HubID,
+		Name:      u.Name,
+		Email:     u.Email,
+		IsActive:  u.IsActive,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+	if u.ID != nil {
+		res.UserID = *u.ID
+	}
+	return res
+}
+
+// parseUserID reads the user_id path variable, writing a bad request response when it is invalid.
+func parseUserID(w http.ResponseWriter, r *http.Request) (int64, error) {
+	userIDStr := mux.Vars(r)["user_id"]
+
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		responder.WriteError(w, r, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+			Message: "Invalid user ID",
+		}))
+		return 0, err
+	}
+	return userID, nil
+}
+
+func validateName(name string) error {
+	if name == "" || len(name) > fieldMaxLength {
+		return errors.New("invalid name: must be between 1 and 255 characters")
+	}
+	return nil
+}
+
+func validateEmail(email string) error {
+	if len(email) > fieldMaxLength {
+		return errors.New("invalid email: must be at most 255 characters")
+	}
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return errors.New("invalid email")
+	}
+	return nil
+}
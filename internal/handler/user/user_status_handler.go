@@ -0,0 +1,40 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/user"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/internal/wrappers"
+)
+
+func ActivateUserHandler(userService user.Service) http.HandlerFunc {
+	return userStatusHandler(userService, true)
+}
+
+func DeactivateUserHandler(userService user.Service) http.HandlerFunc {
+	return userStatusHandler(userService, false)
+}
+
+func userStatusHandler(userService user.Service, active bool) http.HandlerFunc {
+	return wrappers.DefaultWrapper(func(w http.ResponseWriter, r *http.Request) error {
+		ctx, lgr := utils.ContextLogger(r)
+
+		userID, err := parseUserID(w, r)
+		if err != nil {
+			lgr.Errorf("invalid user_id error: %s", err.Error())
+			return err
+		}
+
+		u, err := userService.SetActive(ctx, userID, active)
+		if err != nil {
+			lgr.Errorf("error in changing status of user %d error: %s", userID, err.Error())
+			responder.WriteServiceError(w, r, err)
+			return err
+		}
+
+		responder.WriteAnyResponse(ctx, w, newUserResponse(u))
+		return nil
+	})
+}
@@ -0,0 +1,93 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/services/user/mocks"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func TestUserStatusHandlers(t *testing.T) {
+	userID := int64(3)
+
+	tests := []struct {
+		name       string
+		handler    func(svc *mocks.MockUserService) http.HandlerFunc
+		userID     string
+		mockFunc   func(svc *mocks.MockUserService)
+		statusCode int
+		wantBody   string
+	}{
+		{
+			name:       "Invalid User ID",
+			handler:    func(svc *mocks.MockUserService) http.HandlerFunc { return ActivateUserHandler(svc) },
+			userID:     "abc",
+			mockFunc:   func(svc *mocks.MockUserService) {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "User Not Found",
+			handler: func(svc *mocks.MockUserService) http.HandlerFunc { return DeactivateUserHandler(svc) },
+			userID:  "3",
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("SetActive", mock.Anything, userID, false).Return(nil, errors.NewNotFoundError("user_not_found", &errors.ErrDetails{})).Once()
+			},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:    "Status Change Fails",
+			handler: func(svc *mocks.MockUserService) http.HandlerFunc { return ActivateUserHandler(svc) },
+			userID:  "3",
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("SetActive", mock.Anything, userID, true).Return(nil, errors.New("db down")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:    "Activates User",
+			handler: func(svc *mocks.MockUserService) http.HandlerFunc { return ActivateUserHandler(svc) },
+			userID:  "3",
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("SetActive", mock.Anything, userID, true).Return(&models.User{ID: &userID, IsActive: true}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+			wantBody:   `"is_active":true`,
+		},
+		{
+			name:    "Deactivates User",
+			handler: func(svc *mocks.MockUserService) http.HandlerFunc { return DeactivateUserHandler(svc) },
+			userID:  "3",
+			mockFunc: func(svc *mocks.MockUserService) {
+				svc.On("SetActive", mock.Anything, userID, false).Return(&models.User{ID: &userID, IsActive: false}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+			wantBody:   `"is_active":false`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Load()
+			svc := &mocks.MockUserService{}
+			tt.mockFunc(svc)
+
+			r, err := http.NewRequest(http.MethodPost, "/v1/users/"+tt.userID+"/activate", nil)
+			assert.NoError(t, err)
+			r = mux.SetURLVars(r, map[string]string{"user_id": tt.userID})
+			w := httptest.NewRecorder()
+
+			tt.handler(svc)(w, r)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+			svc.AssertExpectations(t)
+		})
+	}
+}
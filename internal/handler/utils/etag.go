@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+// ETag is the entity tag of a resource at version.
+func ETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// SetETag sends the entity tag of a resource at version.
+func SetETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", ETag(version))
+}
+
+// IfMatchVersion returns the version in the request's If-Match header, or 0 when the header is missing or "*" and
+// any version may be changed.
+func IfMatchVersion(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	tag, err := strconv.Unquote(strings.TrimPrefix(value, "W/"))
+	if err != nil {
+		return 0, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+			Message: "If-Match must be a single ETag",
+		})
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errors.NewBadRequestError(errcodes.BadRequest, &errors.ErrDetails{
+			Message: "If-Match does not match any version",
+		})
+	}
+	return version, nil
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    int64
+		wantErr bool
+	}{
+		{name: "missing", header: "", want: 0},
+		{name: "any", header: "*", want: 0},
+		{name: "strong", header: `"7"`, want: 7},
+		{name: "weak", header: `W/"7"`, want: 7},
+		{name: "unquoted", header: "7", wantErr: true},
+		{name: "list", header: `"7", "8"`, wantErr: true},
+		{name: "not a version", header: `"abc"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", nil)
+			req.Header.Set("If-Match", tt.header)
+
+			got, err := IfMatchVersion(req)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.Equal(t, `"7"`, ETag(7))
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// ParsePageRequest reads the limit, cursor and skip_count query parameters shared by list endpoints into a keyset
+// paginated filter ordered by column and idColumn, newest first.
+func ParsePageRequest(values url.Values, column string, idColumn string) (repository.FilterRequest, error) {
+	filter := repository.FilterRequest{
+		Limit: DefaultPageLimit,
+		Keyset: &repository.KeysetPagination{
+			Column:     column,
+			IDColumn:   idColumn,
+			Descending: true,
+		},
+	}
+
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > MaxPageLimit {
+			return filter, errors.Errorf("invalid limit: must be between 1 and %d", MaxPageLimit)
+		}
+		filter.Limit = limit
+	}
+
+	if v := values.Get("cursor"); v != "" {
+		cursor, err := repository.DecodeCursor(v, column)
+		if err != nil {
+			return filter, errors.New("invalid cursor")
+		}
+		filter.Keyset.After = cursor
+	}
+
+	if v := values.Get("skip_count"); v != "" {
+		skipCount, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, errors.New("invalid skip_count: must be a boolean")
+		}
+		filter.SkipCount = skipCount
+	}
+
+	return filter, nil
+}
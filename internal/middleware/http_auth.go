@@ -2,28 +2,82 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
-	"github.com/shahbaz275817/prismo/internal/config"
+	"github.com/gorilla/mux"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/internal/auth"
 	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/internal/services/apiclient"
 	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/logger"
 )
 
-func WithHTTPAuth(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
-		cfgAuthUsername := config.Auth().Username
-		cfgAuthPassword := config.Auth().Password
-		username, password, ok := req.BasicAuth()
-
-		if !ok {
-			responder.WriteError(wr, req, errors.NewUnauthorizedError("Not Authorized", nil))
-			return
-		}
-
-		isValid := (username == cfgAuthUsername) && (password == cfgAuthPassword)
-		if !isValid {
-			responder.WriteError(wr, req, errors.NewUnauthorizedError("Wrong username/password", nil))
-			return
-		}
-		next.ServeHTTP(wr, req)
-	})
+const bearerPrefix = "Bearer "
+
+// HTTPAuthConfig selects how requests may authenticate. Tokens is nil when bearer tokens are not accepted.
+type HTTPAuthConfig struct {
+	Clients      apiclient.Service
+	Tokens       auth.TokenVerifier
+	BasicEnabled bool
+}
+
+// WithHTTPAuth authenticates requests with a bearer JWT, acting as the user it was issued to, or with the basic auth
+// credentials of an API client, acting as that client.
+func WithHTTPAuth(cfg HTTPAuthConfig) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+			var principal *auth.Principal
+			authorization := req.Header.Get("Authorization")
+
+			switch {
+			case cfg.Tokens != nil && strings.HasPrefix(authorization, bearerPrefix):
+				principal = bearerPrincipal(wr, req, cfg.Tokens, strings.TrimPrefix(authorization, bearerPrefix))
+			case cfg.BasicEnabled:
+				principal = basicPrincipal(wr, req, cfg.Clients)
+			default:
+				responder.WriteError(wr, req, errors.NewUnauthorizedError("Not Authorized", nil))
+			}
+
+			if principal != nil {
+				next.ServeHTTP(wr, req.WithContext(auth.WithPrincipal(req.Context(), *principal)))
+			}
+		})
+	}
+}
+
+// bearerPrincipal returns the principal of a bearer token, or writes an error response and returns nil.
+func bearerPrincipal(wr http.ResponseWriter, req *http.Request, tokens auth.TokenVerifier, token string) *auth.Principal {
+	principal, err := tokens.Verify(req.Context(), token)
+	if err != nil {
+		logger.WithContext(req.Context()).Infof("rejected bearer token: %s", err.Error())
+		responder.WriteError(wr, req, errors.NewUnauthorizedError("Invalid token", nil))
+		return nil
+	}
+	return &principal
+}
+
+// basicPrincipal returns the principal of the API client in the basic auth credentials, or writes an error response
+// and returns nil.
+func basicPrincipal(wr http.ResponseWriter, req *http.Request, clients apiclient.Service) *auth.Principal {
+	name, secret, ok := req.BasicAuth()
+
+	if !ok {
+		responder.WriteError(wr, req, errors.NewUnauthorizedError("Not Authorized", nil))
+		return nil
+	}
+
+	client, err := clients.Authenticate(req.Context(), name, secret)
+	if err != nil {
+		logger.WithContext(req.Context()).Errorf("error while authenticating api client %s: %s", name, err.Error())
+		responder.WriteError(wr, req, errors.NewInternalServerError(errcodes.InternalServerError, &errors.ErrDetails{}))
+		return nil
+	}
+	if client == nil {
+		responder.WriteError(wr, req, errors.NewUnauthorizedError("Wrong username/password", nil))
+		return nil
+	}
+	principal := auth.NewClientPrincipal(client.Name, client.TenantID, auth.Role(client.Role))
+	return &principal
 }
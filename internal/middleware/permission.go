@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+// WithPermission rejects requests whose principal's role does not grant permission. It must run after WithHTTPAuth.
+func WithPermission(permission auth.Permission, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		principal, ok := auth.PrincipalFromContext(req.Context())
+		if !ok {
+			responder.WriteError(wr, req, errors.NewUnauthorizedError("Not Authorized", nil))
+			return
+		}
+
+		if !principal.Role.Can(permission) {
+			responder.WriteError(wr, req, errors.NewForbiddenError("Forbidden", &errors.ErrDetails{
+				Message: "missing permission " + string(permission),
+			}))
+			return
+		}
+		next.ServeHTTP(wr, req)
+	})
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+)
+
+func TestWithPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		principal  *auth.Principal
+		permission auth.Permission
+		want       int
+	}{
+		{
+			name:       "Returns status unauthorized when there is no principal",
+			permission: auth.PermAccountsRead,
+			want:       http.StatusUnauthorized,
+		},
+		{
+			name:       "Returns ok when the role grants the permission",
+			principal:  &auth.Principal{Subject: "ops", Type: auth.PrincipalTypeUser, Role: auth.RoleViewer},
+			permission: auth.PermAccountsRead,
+			want:       http.StatusOK,
+		},
+		{
+			name:       "Returns status forbidden when the role lacks the permission",
+			principal:  &auth.Principal{Subject: "ops", Type: auth.PrincipalTypeUser, Role: auth.RoleViewer},
+			permission: auth.PermAccountsWrite,
+			want:       http.StatusForbidden,
+		},
+		{
+			name:       "Returns status forbidden when the role is unknown",
+			principal:  &auth.Principal{Subject: "ops", Type: auth.PrincipalTypeUser, Role: "root"},
+			permission: auth.PermAccountsRead,
+			want:       http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/end_point", nil)
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), *tt.principal))
+			}
+			w := httptest.NewRecorder()
+			dummyHandler := func(w http.ResponseWriter, r *http.Request) {}
+
+			WithPermission(tt.permission, http.HandlerFunc(dummyHandler)).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/shahbaz275817/prismo/constants/errcodes"
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/responder"
+	"github.com/shahbaz275817/prismo/pkg/cache"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+const (
+	rateLimitKeyPrefix      = "rate_limit"
+	rateLimitLimitHeader    = "RateLimit-Limit"
+	rateLimitRemainHeader   = "RateLimit-Remaining"
+	rateLimitResetHeader    = "RateLimit-Reset"
+	rateLimitRetryAfterName = "Retry-After"
+)
+
+// tokenBucketScript takes a token from the bucket in KEYS[1], refilled at ARGV[1] tokens per millisecond up to
+// ARGV[2] tokens, and returns whether a token was taken and the tokens left. Redis' clock is used so that every
+// server sees the same time.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate))
+return {allowed, tostring(tokens)}
+`)
+
+// RateLimit allows Burst requests at once, refilled at RequestsPerMinute.
+type RateLimit struct {
+	RequestsPerMinute float64
+	Burst             int
+}
+
+// RateLimitRule overrides the default limit for a route, such as "POST /v1/transfers", a client (an API client name
+// or a token subject), or a route when called by a client. Empty fields match everything.
+type RateLimitRule struct {
+	Route  string
+	Client string
+	Limit  RateLimit
+}
+
+type RateLimitConfig struct {
+	Default RateLimit
+	Rules   []RateLimitRule
+	// Address limits all requests from an address, whether or not they authenticate. A zero Address is no limit.
+	Address RateLimit
+	// Timeout bounds each call to redis. Requests are let through when redis does not answer in time.
+	Timeout time.Duration
+}
+
+// RateLimiter limits requests with a token bucket per client and route, kept in redis so that the limit holds across
+// servers.
+type RateLimiter struct {
+	client cache.Client
+	cfg    RateLimitConfig
+}
+
+func NewRateLimiter(client cache.Client, cfg RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+type rateLimitResult struct {
+	allowed   bool
+	remaining float64
+}
+
+// WithAddressRateLimit rejects requests with 429 once their address has used up the address limit. It must run
+// before WithHTTPAuth, so that callers without valid credentials cannot keep the server busy checking them. A nil
+// limiter, or one without an address limit, lets every request through.
+func WithAddressRateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limiter == nil || limiter.cfg.Address.Burst == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+			key := fmt.Sprintf("%s:address:%s", rateLimitKeyPrefix, remoteHost(req))
+			limiter.serve(wr, req, "address", key, limiter.cfg.Address, next)
+		})
+	}
+}
+
+// WithRateLimit rejects requests with 429 once the caller has used up its limit on route. It must run after
+// WithHTTPAuth. A nil limiter lets every request through.
+func WithRateLimit(limiter *RateLimiter, route string, next http.Handler) http.Handler {
+	if limiter == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		caller, name := rateLimitCaller(req)
+		limit := limiter.limitFor(route, name)
+		limiter.serve(wr, req, route, fmt.Sprintf("%s:%s:%s", rateLimitKeyPrefix, caller, route), limit, next)
+	})
+}
+
+// serve takes a token from the bucket at key and passes the request to next, or rejects it when the bucket is empty.
+func (l *RateLimiter) serve(wr http.ResponseWriter, req *http.Request, name, key string, limit RateLimit, next http.Handler) {
+	res, err := l.take(req.Context(), key, limit)
+	if err != nil {
+		logger.WithContext(req.Context()).Warnf("rate limit unavailable for %s, letting request through: %s", name, err.Error())
+		next.ServeHTTP(wr, req)
+		return
+	}
+
+	perSecond := limit.RequestsPerMinute / 60
+	wr.Header().Set(rateLimitLimitHeader, strconv.Itoa(limit.Burst))
+	wr.Header().Set(rateLimitRemainHeader, strconv.Itoa(int(math.Floor(res.remaining))))
+	wr.Header().Set(rateLimitResetHeader, strconv.Itoa(secondsUntil(float64(limit.Burst)-res.remaining, perSecond)))
+
+	if !res.allowed {
+		retryAfter := secondsUntil(1-res.remaining, perSecond)
+		wr.Header().Set(rateLimitRetryAfterName, strconv.Itoa(retryAfter))
+		responder.WriteError(wr, req, errors.NewTooManyRequestsError(errcodes.TooManyRequests, &errors.ErrDetails{
+			Message: fmt.Sprintf("rate limit exceeded, retry after %d seconds", retryAfter),
+		}))
+		return
+	}
+	next.ServeHTTP(wr, req)
+}
+
+// limitFor returns the limit of the most specific rule matching route and client, or the default limit.
+func (l *RateLimiter) limitFor(route, client string) RateLimit {
+	limit, best := l.cfg.Default, 0
+	for _, rule := range l.cfg.Rules {
+		if (rule.Route != "" && rule.Route != route) || (rule.Client != "" && rule.Client != client) {
+			continue
+		}
+		// a rule for the route and the client beats one for the route, which beats one for the client
+		score := 1
+		if rule.Route != "" {
+			score += 2
+		}
+		if rule.Client != "" {
+			score++
+		}
+		if score > best {
+			limit, best = rule.Limit, score
+		}
+	}
+	return limit
+}
+
+func (l *RateLimiter) take(ctx context.Context, key string, limit RateLimit) (rateLimitResult, error) {
+	if l.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.cfg.Timeout)
+		defer cancel()
+	}
+
+	perMS := limit.RequestsPerMinute / float64(time.Minute/time.Millisecond)
+	values, err := tokenBucketScript.Run(ctx, l.client, []string{key}, perMS, limit.Burst).Slice()
+	if err != nil {
+		return rateLimitResult{}, err
+	}
+	if len(values) != 2 {
+		return rateLimitResult{}, fmt.Errorf("unexpected rate limit script result %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	remaining, err := strconv.ParseFloat(fmt.Sprint(values[1]), 64)
+	if err != nil {
+		return rateLimitResult{}, err
+	}
+	return rateLimitResult{allowed: allowed == 1, remaining: remaining}, nil
+}
+
+// rateLimitCaller returns the key of the caller's buckets, made of its tenant and actor, and the name rules match
+// against, which is the API client name or the token subject. Unauthenticated callers are keyed by address.
+func rateLimitCaller(req *http.Request) (string, string) {
+	if p, ok := auth.PrincipalFromContext(req.Context()); ok {
+		return fmt.Sprintf("%d:%s", p.TenantID, p.Actor()), p.Subject
+	}
+	return remoteHost(req), ""
+}
+
+func remoteHost(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+func secondsUntil(tokens, perSecond float64) int {
+	if tokens <= 0 || perSecond <= 0 {
+		return 0
+	}
+	return int(math.Ceil(tokens / perSecond))
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+)
+
+func newTestRateLimiter(t *testing.T, cfg RateLimitConfig) (*RateLimiter, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	mr.SetTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRateLimiter(client, cfg), mr
+}
+
+func rateLimitedCall(limiter *RateLimiter, subject string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/transfers", nil)
+	req = req.WithContext(auth.WithPrincipal(req.Context(), auth.NewClientPrincipal(subject, 1, auth.RoleOperator)))
+	w := httptest.NewRecorder()
+	dummyHandler := func(w http.ResponseWriter, r *http.Request) {}
+	WithRateLimit(limiter, "POST /v1/transfers", http.HandlerFunc(dummyHandler)).ServeHTTP(w, req)
+	return w
+}
+
+func TestWithRateLimit_RejectsOnceBurstIsUsedAndRefills(t *testing.T) {
+	limiter, mr := newTestRateLimiter(t, RateLimitConfig{Default: RateLimit{RequestsPerMinute: 60, Burst: 2}})
+
+	first := rateLimitedCall(limiter, "lms_portal")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Reset"))
+
+	assert.Equal(t, http.StatusOK, rateLimitedCall(limiter, "lms_portal").Code)
+
+	limited := rateLimitedCall(limiter, "lms_portal")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "0", limited.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+
+	// other clients have their own bucket
+	assert.Equal(t, http.StatusOK, rateLimitedCall(limiter, "other").Code)
+
+	mr.SetTime(time.Date(2024, 5, 1, 12, 0, 1, 0, time.UTC))
+	assert.Equal(t, http.StatusOK, rateLimitedCall(limiter, "lms_portal").Code)
+	assert.Equal(t, http.StatusTooManyRequests, rateLimitedCall(limiter, "lms_portal").Code)
+}
+
+func TestWithRateLimit_FailsOpenWhenRedisIsUnavailable(t *testing.T) {
+	limiter, mr := newTestRateLimiter(t, RateLimitConfig{Default: RateLimit{RequestsPerMinute: 60, Burst: 1}, Timeout: 50 * time.Millisecond})
+	mr.Close()
+
+	w := rateLimitedCall(limiter, "lms_portal")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestWithRateLimit_NilLimiterLetsEverythingThrough(t *testing.T) {
+	assert.Equal(t, http.StatusOK, rateLimitedCall(nil, "lms_portal").Code)
+}
+
+func TestRateLimiter_LimitForPicksTheMostSpecificRule(t *testing.T) {
+	def := RateLimit{RequestsPerMinute: 600, Burst: 100}
+	route := RateLimit{RequestsPerMinute: 60, Burst: 10}
+	client := RateLimit{RequestsPerMinute: 1200, Burst: 200}
+	both := RateLimit{RequestsPerMinute: 120, Burst: 20}
+	limiter := NewRateLimiter(nil, RateLimitConfig{
+		Default: def,
+		Rules: []RateLimitRule{
+			{Client: "batch", Limit: client},
+			{Route: "POST /v1/transfers", Client: "batch", Limit: both},
+			{Route: "POST /v1/transfers", Limit: route},
+		},
+	})
+
+	assert.Equal(t, def, limiter.limitFor("GET /v1/accounts/{account_id}", "lms_portal"))
+	assert.Equal(t, route, limiter.limitFor("POST /v1/transfers", "lms_portal"))
+	assert.Equal(t, client, limiter.limitFor("GET /v1/accounts/{account_id}", "batch"))
+	assert.Equal(t, both, limiter.limitFor("POST /v1/transfers", "batch"))
+}
+
+func TestWithAddressRateLimit_LimitsRequestsBeforeTheyAuthenticate(t *testing.T) {
+	limiter, _ := newTestRateLimiter(t, RateLimitConfig{
+		Default: RateLimit{RequestsPerMinute: 600, Burst: 100},
+		Address: RateLimit{RequestsPerMinute: 60, Burst: 2},
+	})
+	var authenticated int
+	handler := WithAddressRateLimit(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authenticated++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	call := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/v1/accounts/1", nil)
+		req.RemoteAddr = remoteAddr
+		req.SetBasicAuth("lms_portal", "guess")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, call("10.0.0.1:1234"))
+	assert.Equal(t, http.StatusUnauthorized, call("10.0.0.1:4321"))
+	assert.Equal(t, http.StatusTooManyRequests, call("10.0.0.1:1234"))
+	assert.Equal(t, 2, authenticated)
+
+	// other addresses have their own bucket
+	assert.Equal(t, http.StatusUnauthorized, call("10.0.0.2:1234"))
+}
+
+func TestWithAddressRateLimit_WithoutAddressLimitLetsEverythingThrough(t *testing.T) {
+	limiter, _ := newTestRateLimiter(t, RateLimitConfig{Default: RateLimit{RequestsPerMinute: 60, Burst: 1}})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, l := range []*RateLimiter{nil, limiter} {
+		for i := 0; i < 3; i++ {
+			w := httptest.NewRecorder()
+			WithAddressRateLimit(l)(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/accounts/1", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+	}
+}
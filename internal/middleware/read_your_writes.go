@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/shahbaz275817/prismo/internal/repository"
+)
+
+// WithReadYourWrites sends the reads a request makes after its first write to the primary database, so responses
+// never miss the request's own changes because the read replica is behind.
+func WithReadYourWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(wr, req.WithContext(repository.WithReadYourWrites(req.Context())))
+	})
+}
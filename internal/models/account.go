@@ -1,6 +1,17 @@
 package models
 
+import "time"
+
 type Account struct {
-	AccountID      int64  `gorm:"primaryKey;autoIncrement" json:"account_id"`
-	DocumentNumber string `gorm:"type:varchar(15);not null" json:"document_number"`
+	AccountID      int64      `gorm:"primaryKey;autoIncrement" json:"account_id"`
+	TenantID       int64      `gorm:"not null" json:"-"`
+	DocumentNumber string     `gorm:"type:varchar(15);not null" audit:"redact" json:"document_number"`
+	ErasedAt       *time.Time `json:"erased_at,omitempty"`
+	Version        int64      `gorm:"not null;default:1" lock:"optimistic" json:"version"`
+}
+
+// Erased tells whether the holder's personal data was erased, after which the account takes no new transactions,
+// transfers or cards.
+func (a Account) Erased() bool {
+	return a.ErasedAt != nil
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// APIClient is a caller of the API. Clients are looked up by name before the tenant of the request is known, so the
+// table is not scoped to the tenant of the context.
+type APIClient struct {
+	ClientID                int64      `gorm:"primaryKey;autoIncrement" json:"client_id"`
+	TenantID                int64      `gorm:"not null" tenant:"unscoped" json:"tenant_id"`
+	Name                    string     `gorm:"type:varchar(100);not null" json:"name"`
+	Role                    string     `gorm:"type:varchar(20);not null" json:"role"`
+	SecretHash              string     `gorm:"type:varchar(60);not null" audit:"redact" json:"-"`
+	PreviousSecretHash      *string    `gorm:"type:varchar(60)" audit:"redact" json:"-"`
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+	Enabled                 bool       `gorm:"not null" json:"enabled"`
+	CreatedAt               time.Time  `gorm:"not null" json:"created_at"`
+	UpdatedAt               time.Time  `gorm:"not null" json:"updated_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditDelete AuditAction = "delete"
+	AuditErase  AuditAction = "erase"
+)
+
+// AuditLog records a single row written through GORM. Before and After hold only the changed columns for updates,
+// the full row otherwise.
+type AuditLog struct {
+	AuditID   int64           `gorm:"primaryKey;autoIncrement" json:"audit_id"`
+	TenantID  int64           `gorm:"not null" json:"-"`
+	Actor     string          `gorm:"type:varchar(128);not null" json:"actor"`
+	RequestID *string         `gorm:"type:varchar(64)" json:"request_id,omitempty"`
+	Entity    string          `gorm:"type:varchar(64);not null" json:"entity"`
+	EntityID  *string         `gorm:"type:varchar(64)" json:"entity_id,omitempty"`
+	Action    AuditAction     `gorm:"type:varchar(16);not null" json:"action"`
+	Before    json.RawMessage `gorm:"column:before_data;type:jsonb" json:"before,omitempty"`
+	After     json.RawMessage `gorm:"column:after_data;type:jsonb" json:"after,omitempty"`
+	CreatedAt time.Time       `gorm:"not null" json:"created_at"`
+}
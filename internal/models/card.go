@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type CardStatus string
+
+const (
+	CardInactive CardStatus = "inactive"
+	CardActive   CardStatus = "active"
+	CardBlocked  CardStatus = "blocked"
+	CardReplaced CardStatus = "replaced"
+)
+
+// Card is a card issued on an account. Token and PanHash are unique across tenants rather than per tenant: tokens are
+// random UUIDs, and tenants issue card numbers from the same BIN, so two tenants must never hold the same number.
+type Card struct {
+	CardID           int64      `gorm:"primaryKey;autoIncrement" json:"card_id"`
+	TenantID         int64      `gorm:"not null" json:"-"`
+	AccountID        int64      `gorm:"not null" json:"account_id"`
+	Token            string     `gorm:"type:varchar(36);not null" json:"card_token"`
+	EncryptedPan     string     `gorm:"not null" audit:"redact" json:"-"`
+	PanHash          string     `gorm:"type:varchar(64);not null" audit:"redact" json:"-"`
+	MaskedPan        string     `gorm:"type:varchar(19);not null" json:"masked_pan"`
+	ExpiryMonth      int        `gorm:"not null" json:"expiry_month"`
+	ExpiryYear       int        `gorm:"not null" json:"expiry_year"`
+	Status           CardStatus `gorm:"type:varchar(20);not null" json:"status"`
+	ReplacedByCardID *int64     `json:"replaced_by_card_id,omitempty"`
+	CreatedAt        time.Time  `gorm:"not null" json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"not null" json:"updated_at"`
+}
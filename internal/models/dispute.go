@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type DisputeStatus string
+
+const (
+	DisputeOpen     DisputeStatus = "open"
+	DisputeResolved DisputeStatus = "resolved"
+	DisputeRejected DisputeStatus = "rejected"
+)
+
+type Dispute struct {
+	DisputeID     int64         `gorm:"primaryKey;autoIncrement" json:"dispute_id"`
+	TenantID      int64         `gorm:"not null" json:"-"`
+	AccountID     int64         `gorm:"not null" json:"account_id"`
+	TransactionID *int64        `json:"transaction_id,omitempty"`
+	Status        DisputeStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Reason        *string       `audit:"redact" json:"reason,omitempty"`
+	CreatedAt     time.Time     `gorm:"not null" json:"created_at"`
+	ResolvedAt    *time.Time    `json:"resolved_at,omitempty"`
+}
@@ -0,0 +1,12 @@
+package models
+
+type FeeSchedule struct {
+	FeeScheduleID   int64    `gorm:"primaryKey;autoIncrement" json:"fee_schedule_id"`
+	TenantID        int64    `gorm:"not null" json:"-"`
+	OperationTypeID int64    `gorm:"column:operationtype_id;not null" json:"operationtype_id"`
+	FixedAmount     float64  `gorm:"type:decimal(10,2);not null" json:"fixed_amount"`
+	Percentage      float64  `gorm:"type:decimal(7,4);not null" json:"percentage"`
+	MinAmount       *float64 `gorm:"type:decimal(10,2)" json:"min_amount,omitempty"`
+	MaxAmount       *float64 `gorm:"type:decimal(10,2)" json:"max_amount,omitempty"`
+	IsActive        bool     `gorm:"not null" json:"is_active"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type InterestAccrual struct {
+	AccrualID          int64     `gorm:"primaryKey;autoIncrement" json:"accrual_id"`
+	TenantID           int64     `gorm:"not null" json:"-"`
+	AccountID          int64     `gorm:"not null" json:"account_id"`
+	AccrualDate        time.Time `gorm:"type:date;not null" json:"accrual_date"`
+	Balance            float64   `gorm:"type:decimal(12,2);not null" json:"balance"`
+	Apr                float64   `gorm:"type:decimal(7,4);not null" json:"apr"`
+	DayCountConvention string    `gorm:"type:varchar(10);not null" json:"day_count_convention"`
+	Amount             float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	TransactionID      *int64    `json:"transaction_id,omitempty"`
+}
+
+// OperationTotal is the sum of the absolute amounts of an account's transactions of one operation type.
+type OperationTotal struct {
+	AccountID       int64   `json:"account_id"`
+	OperationTypeID int64   `gorm:"column:operationtype_id" json:"operationtype_id"`
+	IsCredit        bool    `json:"is_credit"`
+	Amount          float64 `json:"amount"`
+}
+
+type AccountBalance struct {
+	AccountID int64   `json:"account_id"`
+	Balance   float64 `json:"balance"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type Merchant struct {
+	MerchantID string    `gorm:"primaryKey;type:varchar(64)" json:"merchant_id"`
+	TenantID   int64     `gorm:"not null" json:"-"`
+	Name       *string   `gorm:"type:varchar(255)" json:"name,omitempty"`
+	MCC        *string   `gorm:"column:mcc;type:char(4)" json:"mcc,omitempty"`
+	City       *string   `gorm:"type:varchar(64)" json:"city,omitempty"`
+	Country    *string   `gorm:"type:char(2)" json:"country,omitempty"`
+	CreatedAt  time.Time `gorm:"not null" json:"created_at"`
+}
@@ -1,8 +1,22 @@
 package models
 
+// Descriptions of the shared operation types the server posts transactions of itself. Their ids depend on the order
+// migrations seeded them in, so they are looked up by description.
+const (
+	FeeOperationType         = "Fee"
+	InterestOperationType    = "Interest"
+	TransferOutOperationType = "Transfer Out"
+	TransferInOperationType  = "Transfer In"
+)
+
 type OperationsType struct {
 	OperationTypeID int64  `gorm:"column:operationtype_id;primaryKey;autoIncrement" json:"operationtype_id"`
+	TenantID        *int64 `tenant:"shared" json:"-"`
 	Description     string `gorm:"type:varchar(50);not null" json:"description"`
+	// IsCredit tells whether transactions of the type add to the account's balance, e.g. payments, rather than take
+	// from it, e.g. purchases and fees.
+	IsCredit bool  `gorm:"not null;default:false" json:"is_credit"`
+	Version  int64 `gorm:"not null;default:1" lock:"optimistic" json:"version"`
 }
 
 func (OperationsType) TableName() string {
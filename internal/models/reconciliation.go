@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type ReconciliationStatus string
+
+const (
+	ReconciliationMatched           ReconciliationStatus = "matched"
+	ReconciliationAmountMismatch    ReconciliationStatus = "amount_mismatch"
+	ReconciliationMissingInternally ReconciliationStatus = "missing_internally"
+	ReconciliationMissingExternally ReconciliationStatus = "missing_externally"
+)
+
+type ReconciliationRun struct {
+	RunID        int64     `gorm:"primaryKey;autoIncrement" json:"run_id"`
+	TenantID     int64     `gorm:"not null" json:"-"`
+	FileName     string    `gorm:"type:varchar(255);not null" json:"file_name"`
+	MatchKey     string    `gorm:"type:varchar(50);not null" json:"match_key"`
+	TotalRecords int       `gorm:"not null" json:"total_records"`
+	CreatedAt    time.Time `gorm:"not null" json:"created_at"`
+}
+
+type ReconciliationResult struct {
+	ResultID          int64                `gorm:"primaryKey;autoIncrement" json:"result_id"`
+	TenantID          int64                `gorm:"not null" json:"-"`
+	RunID             int64                `gorm:"not null" json:"run_id"`
+	Status            ReconciliationStatus `gorm:"type:varchar(30);not null" json:"status"`
+	TransactionID     *int64               `json:"transaction_id,omitempty"`
+	AccountID         *int64               `json:"account_id,omitempty"`
+	ExternalReference *string              `gorm:"type:varchar(64)" json:"external_reference,omitempty"`
+	ExternalAmount    *float64             `gorm:"type:decimal(10,2)" json:"external_amount,omitempty"`
+	InternalAmount    *float64             `gorm:"type:decimal(10,2)" json:"internal_amount,omitempty"`
+	ExternalEventDate *time.Time           `gorm:"type:timestamp" json:"external_event_date,omitempty"`
+	CreatedAt         time.Time            `gorm:"not null" json:"created_at"`
+}
+
+type ReconciliationStatusSummary struct {
+	Status         ReconciliationStatus `json:"status"`
+	Count          int64                `json:"count"`
+	ExternalAmount float64              `json:"external_amount"`
+	InternalAmount float64              `json:"internal_amount"`
+}
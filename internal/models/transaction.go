@@ -6,11 +6,22 @@ import (
 
 type Transaction struct {
 	TransactionID   int64     `gorm:"primaryKey;autoIncrement" json:"transaction_id"`
+	TenantID        int64     `gorm:"not null" json:"-"`
 	AccountID       int64     `gorm:"not null" json:"account_id"`
 	OperationTypeID int64     `gorm:"column:operationtype_id;not null" json:"operationtype_id"`
 	Amount          float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
 	EventDate       time.Time `gorm:"column:eventdate;type:timestamp;not null" json:"event_date"`
 
+	ExternalReference   *string `gorm:"type:varchar(64)" json:"external_reference,omitempty"`
+	ParentTransactionID *int64  `json:"parent_transaction_id,omitempty"`
+	CardID              *int64  `json:"card_id,omitempty"`
+
+	MerchantID      *string `gorm:"type:varchar(64)" json:"merchant_id,omitempty"`
+	MerchantName    *string `gorm:"type:varchar(255)" json:"merchant_name,omitempty"`
+	MCC             *string `gorm:"column:mcc;type:char(4)" json:"mcc,omitempty"`
+	MerchantCity    *string `gorm:"type:varchar(64)" json:"merchant_city,omitempty"`
+	MerchantCountry *string `gorm:"type:char(2)" json:"merchant_country,omitempty"`
+
 	Account        Account        `gorm:"foreignKey:AccountID;references:AccountID"`
 	OperationsType OperationsType `gorm:"foreignKey:OperationTypeID;references:OperationTypeID"`
 }
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Transfer struct {
+	TransferID           int64     `gorm:"primaryKey;autoIncrement" json:"transfer_id"`
+	TenantID             int64     `gorm:"not null" json:"-"`
+	ClientReference      string    `gorm:"type:varchar(64);not null" json:"client_reference"`
+	SourceAccountID      int64     `gorm:"not null" json:"source_account_id"`
+	DestinationAccountID int64     `gorm:"not null" json:"destination_account_id"`
+	Amount               float64   `gorm:"type:decimal(10,2);not null" json:"amount"`
+	DebitTransactionID   int64     `gorm:"not null" json:"debit_transaction_id"`
+	CreditTransactionID  int64     `gorm:"not null" json:"credit_transaction_id"`
+	CreatedAt            time.Time `gorm:"not null" json:"created_at"`
+}
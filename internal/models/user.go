@@ -3,11 +3,12 @@ package models
 import "time"
 
 type User struct {
-	ID        *int64
-	HubID     int64
-	Name      string
-	Email     string
-	IsActive  bool
+	ID *int64 `gorm:"primaryKey;autoIncrement"`
+	// HubID is the hub the user belongs to, which is its tenant.
+	HubID     int64  `gorm:"column:tenant_id;not null"`
+	Name      string `gorm:"type:varchar(255);not null" audit:"redact"`
+	Email     string `gorm:"type:varchar(255);not null" audit:"redact"`
+	IsActive  bool   `gorm:"not null"`
 	CreatedAt time.Time
 	CreatedBy *int64
 	UpdatedAt time.Time
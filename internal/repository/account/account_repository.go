@@ -2,8 +2,10 @@ package account
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/shahbaz275817/prismo/internal/models"
 	"github.com/shahbaz275817/prismo/internal/repository"
@@ -11,61 +13,52 @@ import (
 )
 
 type Repository interface {
-	Get(ctx context.Context, query *models.Account) (*models.Account, error)
-	Save(ctx context.Context, query *models.Account) error
-	Update(ctx context.Context, query *models.Account, update *models.Account) error
+	repository.CRUD[models.Account]
+	// GetForShare locks the account matching query against updates, such as its erasure, until the surrounding
+	// transaction ends, so it must run in Transact.
+	GetForShare(ctx context.Context, query *models.Account) (*models.Account, error)
+	// Erase replaces the personal data of the account with pseudonyms and marks it erased.
+	Erase(ctx context.Context, account *models.Account, documentNumber string, erasedAt time.Time) error
 }
 
 type accountRepository struct {
+	repository.CRUD[models.Account]
 	dB repository.Accessor
 }
 
 func NewAccountRepository(accessor repository.Accessor) Repository {
 	return &accountRepository{
-		dB: accessor,
+		CRUD: repository.NewCRUD[models.Account](accessor, nil),
+		dB:   accessor,
 	}
 }
 
-func (repo *accountRepository) Get(ctx context.Context, query *models.Account) (*models.Account, error) {
-	var Account models.Account
+func (repo *accountRepository) GetForShare(ctx context.Context, query *models.Account) (*models.Account, error) {
+	var account models.Account
 
 	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
-		return repository.GetTx(ctx).Debug().First(&Account, query).Error
+		return repository.GetTx(ctx).Clauses(clause.Locking{Strength: "SHARE"}).First(&account, query).Error
 	})
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, errors.NewUnknownError(err.Error())
+		return nil, repository.MapError(err)
 	}
-	return &Account, nil
+	return &account, nil
 }
 
-func (repo *accountRepository) Save(ctx context.Context, model *models.Account) error {
+func (repo *accountRepository) Erase(ctx context.Context, account *models.Account, documentNumber string, erasedAt time.Time) error {
 	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
-		return repository.GetTx(ctx).Create(model).Error
-	})
-	return err
-}
-
-func (repo *accountRepository) Update(ctx context.Context, model *models.Account, update *models.Account) error {
-
-	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
-		return repository.GetTx(ctx).Model(&model).Updates(update).Error
+		return repository.GetTx(ctx).Model(account).Updates(map[string]interface{}{
+			"document_number": documentNumber,
+			"erased_at":       erasedAt,
+		}).Error
 	})
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil
-	}
-
 	if err != nil {
-		return nil
+		return repository.MapError(err)
 	}
-
-	return err
-}
-
-func (repo *accountRepository) Transact(ctx context.Context, f func(ctx context.Context) error) error {
-	return repo.dB.Transact(ctx, f)
+	return nil
 }
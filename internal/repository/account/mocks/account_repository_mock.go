@@ -7,6 +7,10 @@ import (
 
 	models "github.com/shahbaz275817/prismo/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/shahbaz275817/prismo/internal/repository"
+
+	time "time"
 )
 
 // MockAccountRepository is an autogenerated mock type for the Repository type
@@ -14,6 +18,42 @@ type MockAccountRepository struct {
 	mock.Mock
 }
 
+// Delete provides a mock function with given fields: ctx, model
+func (_m *MockAccountRepository) Delete(ctx context.Context, model *models.Account) error {
+	ret := _m.Called(ctx, model)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account) error); ok {
+		r0 = rf(ctx, model)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Erase provides a mock function with given fields: ctx, _a1, documentNumber, erasedAt
+func (_m *MockAccountRepository) Erase(ctx context.Context, _a1 *models.Account, documentNumber string, erasedAt time.Time) error {
+	ret := _m.Called(ctx, _a1, documentNumber, erasedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Erase")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account, string, time.Time) error); ok {
+		r0 = rf(ctx, _a1, documentNumber, erasedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, query
 func (_m *MockAccountRepository) Get(ctx context.Context, query *models.Account) (*models.Account, error) {
 	ret := _m.Called(ctx, query)
@@ -44,17 +84,102 @@ func (_m *MockAccountRepository) Get(ctx context.Context, query *models.Account)
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: ctx, query
-func (_m *MockAccountRepository) Save(ctx context.Context, query *models.Account) error {
+// GetForShare provides a mock function with given fields: ctx, query
+func (_m *MockAccountRepository) GetForShare(ctx context.Context, query *models.Account) (*models.Account, error) {
 	ret := _m.Called(ctx, query)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetForShare")
+	}
+
+	var r0 *models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account) (*models.Account, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account) *models.Account); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Account) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, query, request
+func (_m *MockAccountRepository) List(ctx context.Context, query *models.Account, request repository.FilterRequest) ([]models.Account, repository.Page, error) {
+	ret := _m.Called(ctx, query, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []models.Account
+	var r1 repository.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account, repository.FilterRequest) ([]models.Account, repository.Page, error)); ok {
+		return rf(ctx, query, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account, repository.FilterRequest) []models.Account); ok {
+		r0 = rf(ctx, query, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Account, repository.FilterRequest) repository.Page); ok {
+		r1 = rf(ctx, query, request)
+	} else {
+		r1 = ret.Get(1).(repository.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.Account, repository.FilterRequest) error); ok {
+		r2 = rf(ctx, query, request)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Save provides a mock function with given fields: ctx, model
+func (_m *MockAccountRepository) Save(ctx context.Context, model *models.Account) error {
+	ret := _m.Called(ctx, model)
+
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, *models.Account) error); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, model)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transact provides a mock function with given fields: ctx, f
+func (_m *MockAccountRepository) Transact(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -62,17 +187,17 @@ func (_m *MockAccountRepository) Save(ctx context.Context, query *models.Account
 	return r0
 }
 
-// Update provides a mock function with given fields: ctx, query, update
-func (_m *MockAccountRepository) Update(ctx context.Context, query *models.Account, update *models.Account) error {
-	ret := _m.Called(ctx, query, update)
+// Update provides a mock function with given fields: ctx, model, update
+func (_m *MockAccountRepository) Update(ctx context.Context, model *models.Account, update interface{}) error {
+	ret := _m.Called(ctx, model, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Account, *models.Account) error); ok {
-		r0 = rf(ctx, query, update)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account, interface{}) error); ok {
+		r0 = rf(ctx, model, update)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,301 @@
+package anonymize
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+const defaultBatchSize = 500
+
+// Scrubber replaces the value of a column holding personal data.
+type Scrubber func(value interface{}) (interface{}, error)
+
+// Table describes how a table is copied. Key is the primary key, used both to read the source in batches and as the
+// conflict target on the copy, so re-running the copy updates rows instead of failing. Identity names the identity
+// column, if any, whose sequence is moved past the copied ids.
+type Table struct {
+	Name     string
+	Key      []string
+	Identity string
+	// Deferred columns reference rows of the same table that may not be copied yet; they are written once every
+	// row of the table exists.
+	Deferred []string
+	Scrub    map[string]Scrubber
+}
+
+// Tables lists every table of the schema in foreign key order. Audit_Logs is left out: it is not needed outside
+// production and records who did what. Api_Clients is left out so production secrets do not work on the copy.
+func Tables() []Table {
+	return []Table{
+		identityTable("operationstypes", "operationtype_id"),
+		withScrub(identityTable("accounts", "account_id"), map[string]Scrubber{
+			"document_number": Pseudonym(15),
+		}),
+		withScrub(withDeferred(identityTable("cards", "card_id"), "replaced_by_card_id"), map[string]Scrubber{
+			"encrypted_pan": Constant(""),
+			"pan_hash":      Pseudonym(64),
+		}),
+		{Name: "merchants", Key: []string{"tenant_id", "merchant_id"}},
+		withDeferred(identityTable("transactions", "transaction_id"), "parent_transaction_id"),
+		identityTable("fee_schedules", "fee_schedule_id"),
+		identityTable("interest_accruals", "accrual_id"),
+		identityTable("reconciliation_runs", "run_id"),
+		identityTable("reconciliation_results", "result_id"),
+		identityTable("transfers", "transfer_id"),
+		withScrub(identityTable("disputes", "dispute_id"), map[string]Scrubber{
+			"reason": Constant(nil),
+		}),
+		withScrub(identityTable("users", "id"), map[string]Scrubber{
+			"name":  Pseudonym(12),
+			"email": PseudonymEmail(12),
+		}),
+	}
+}
+
+func identityTable(name, key string) Table {
+	return Table{Name: name, Key: []string{key}, Identity: key}
+}
+
+func withDeferred(t Table, columns ...string) Table {
+	t.Deferred = columns
+	return t
+}
+
+func withScrub(t Table, scrub map[string]Scrubber) Table {
+	t.Scrub = scrub
+	return t
+}
+
+// Pseudonym replaces non null values with random pseudonyms of the given length.
+func Pseudonym(length int) Scrubber {
+	return func(value interface{}) (interface{}, error) {
+		if value == nil {
+			return nil, nil
+		}
+		return utils.NewPseudonym(length)
+	}
+}
+
+// PseudonymEmail replaces non null values with random addresses on a reserved domain, keeping them unique.
+func PseudonymEmail(length int) Scrubber {
+	pseudonym := Pseudonym(length)
+	return func(value interface{}) (interface{}, error) {
+		local, err := pseudonym(value)
+		if err != nil || local == nil {
+			return local, err
+		}
+		return fmt.Sprintf("%s@example.invalid", local), nil
+	}
+}
+
+// Constant replaces every value with v.
+func Constant(v interface{}) Scrubber {
+	return func(interface{}) (interface{}, error) {
+		return v, nil
+	}
+}
+
+type Copier struct {
+	source    *gorm.DB
+	target    *gorm.DB
+	batchSize int
+}
+
+func NewCopier(source, target *gorm.DB) *Copier {
+	return &Copier{
+		source:    source,
+		target:    target,
+		batchSize: defaultBatchSize,
+	}
+}
+
+// Open connects to the database the anonymized copy is written to.
+func Open(dsn string) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: gormLogger.Default.LogMode(gormLogger.Silent)})
+}
+
+// Copy copies tables from source to target in a single target transaction, scrubbing personal data on the way, and
+// returns the number of rows copied per table. Every table is read from the same snapshot of source, so rows written
+// while the copy runs cannot leave references to rows that were not copied.
+func (c *Copier) Copy(ctx context.Context, tables []Table) (map[string]int64, error) {
+	copied := make(map[string]int64, len(tables))
+
+	err := c.source.WithContext(ctx).Transaction(func(source *gorm.DB) error {
+		return c.target.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, table := range tables {
+				n, err := c.copyTable(ctx, source, tx, table)
+				if err != nil {
+					return fmt.Errorf("copying %s: %w", table.Name, err)
+				}
+				copied[table.Name] = n
+				logger.WithContext(ctx).Infof("Anonymize: copied %d rows of %s", n, table.Name)
+			}
+			return nil
+		})
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+func (c *Copier) copyTable(ctx context.Context, source, tx *gorm.DB, table Table) (int64, error) {
+	var total int64
+	var last []interface{}
+	var deferred []map[string]interface{}
+
+	key := strings.Join(table.Key, ", ")
+	for {
+		query := source.Table(table.Name).Order(key).Limit(c.batchSize)
+		if last != nil {
+			query = query.Where(fmt.Sprintf("(%s) > (%s)", key, placeholders(len(last))), last...)
+		}
+
+		var rows []map[string]interface{}
+		if err := query.Find(&rows).Error; err != nil {
+			return total, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			if err := scrubRow(table, row); err != nil {
+				return total, err
+			}
+			if update := deferredColumns(table, row); update != nil {
+				deferred = append(deferred, update)
+			}
+		}
+
+		sql, args := upsertSQL(table, rows)
+		if err := tx.Exec(sql, args...).Error; err != nil {
+			return total, err
+		}
+
+		total += int64(len(rows))
+		last = keyValues(table, rows[len(rows)-1])
+		if len(rows) < c.batchSize {
+			break
+		}
+	}
+
+	for _, update := range deferred {
+		query := tx.Table(table.Name)
+		for _, column := range table.Key {
+			query = query.Where(fmt.Sprintf("%s = ?", column), update[column])
+			delete(update, column)
+		}
+		if err := query.Updates(update).Error; err != nil {
+			return total, err
+		}
+	}
+
+	if table.Identity != "" {
+		// Rows were inserted with their source ids, so the identity has to continue after the highest one
+		resync := fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', '%s'), COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)",
+			table.Name, table.Identity, table.Identity, table.Name)
+		if err := tx.Exec(resync).Error; err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// deferredColumns clears the deferred columns of row and returns the update that restores them, keyed by the row's
+// primary key, or nil when they are all null.
+func deferredColumns(table Table, row map[string]interface{}) map[string]interface{} {
+	var update map[string]interface{}
+	for _, column := range table.Deferred {
+		if row[column] != nil {
+			if update == nil {
+				update = map[string]interface{}{}
+				for _, key := range table.Key {
+					update[key] = row[key]
+				}
+			}
+			update[column] = row[column]
+		}
+		row[column] = nil
+	}
+	return update
+}
+
+func keyValues(table Table, row map[string]interface{}) []interface{} {
+	values := make([]interface{}, 0, len(table.Key))
+	for _, column := range table.Key {
+		values = append(values, row[column])
+	}
+	return values
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+func scrubRow(table Table, row map[string]interface{}) error {
+	for column, scrub := range table.Scrub {
+		if _, ok := row[column]; !ok {
+			continue
+		}
+		value, err := scrub(row[column])
+		if err != nil {
+			return err
+		}
+		row[column] = value
+	}
+	return nil
+}
+
+// upsertSQL builds a multi row insert that keeps the source ids and overwrites rows already present in the target,
+// such as the operation types seeded by the migrations.
+func upsertSQL(table Table, rows []map[string]interface{}) (string, []interface{}) {
+	columns := make([]string, 0, len(rows[0]))
+	for column := range rows[0] {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	row := "(" + placeholders(len(columns)) + ")"
+	values := make([]string, 0, len(rows))
+	args := make([]interface{}, 0, len(rows)*len(columns))
+	for _, r := range rows {
+		values = append(values, row)
+		for _, column := range columns {
+			args = append(args, r[column])
+		}
+	}
+
+	isKey := make(map[string]bool, len(table.Key))
+	for _, column := range table.Key {
+		isKey[column] = true
+	}
+	updates := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if !isKey[column] {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+		}
+	}
+
+	overriding := ""
+	if table.Identity != "" {
+		overriding = " OVERRIDING SYSTEM VALUE"
+	}
+	conflict := "DO NOTHING"
+	if len(updates) > 0 {
+		conflict = "DO UPDATE SET " + strings.Join(updates, ", ")
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s)%s VALUES %s ON CONFLICT (%s) %s",
+		table.Name, strings.Join(columns, ", "), overriding, strings.Join(values, ", "), strings.Join(table.Key, ", "), conflict), args
+}
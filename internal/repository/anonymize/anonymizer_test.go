@@ -0,0 +1,132 @@
+package anonymize
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/utils"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	return db, mock
+}
+
+func pseudonymArg(length int) sqlmock.Argument {
+	return pseudonymMatcher(length)
+}
+
+type pseudonymMatcher int
+
+func (m pseudonymMatcher) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	return ok && len(s) == int(m) && strings.HasPrefix(s, utils.PseudonymPrefix)
+}
+
+func TestCopier_CopyScrubsPersonalData(t *testing.T) {
+	source, sourceMock := newMockDB(t)
+	target, targetMock := newMockDB(t)
+
+	sourceMock.ExpectBegin()
+	sourceMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" ORDER BY account_id LIMIT $1`)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "document_number", "erased_at"}).
+			AddRow(int64(1), "12345678900", nil).
+			AddRow(int64(2), "98765432100", nil))
+	sourceMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "accounts" WHERE (account_id) > ($1) ORDER BY account_id LIMIT $2`)).
+		WithArgs(int64(2), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "document_number", "erased_at"}))
+
+	targetMock.ExpectBegin()
+	targetMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO accounts (account_id, document_number, erased_at) OVERRIDING SYSTEM VALUE VALUES ($1,$2,$3), ($4,$5,$6) ON CONFLICT (account_id) DO UPDATE SET document_number = EXCLUDED.document_number, erased_at = EXCLUDED.erased_at`)).
+		WithArgs(int64(1), pseudonymArg(15), nil, int64(2), pseudonymArg(15), nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	targetMock.ExpectExec(regexp.QuoteMeta(`SELECT setval(pg_get_serial_sequence('accounts', 'account_id'), COALESCE((SELECT MAX(account_id) FROM accounts), 0) + 1, false)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	targetMock.ExpectCommit()
+	sourceMock.ExpectCommit()
+
+	copier := NewCopier(source, target)
+	copier.batchSize = 2
+	copied, err := copier.Copy(context.Background(), []Table{
+		withScrub(identityTable("accounts", "account_id"), map[string]Scrubber{"document_number": Pseudonym(15)}),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"accounts": 2}, copied)
+	assert.NoError(t, sourceMock.ExpectationsWereMet())
+	assert.NoError(t, targetMock.ExpectationsWereMet())
+}
+
+func TestCopier_CopyWritesSelfReferencesLast(t *testing.T) {
+	source, sourceMock := newMockDB(t)
+	target, targetMock := newMockDB(t)
+
+	sourceMock.ExpectBegin()
+	sourceMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "cards" ORDER BY card_id LIMIT $1`)).
+		WithArgs(defaultBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"card_id", "replaced_by_card_id"}).
+			AddRow(int64(1), int64(2)).
+			AddRow(int64(2), nil))
+
+	targetMock.ExpectBegin()
+	targetMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO cards (card_id, replaced_by_card_id) OVERRIDING SYSTEM VALUE VALUES ($1,$2), ($3,$4) ON CONFLICT (card_id) DO UPDATE SET replaced_by_card_id = EXCLUDED.replaced_by_card_id`)).
+		WithArgs(int64(1), nil, int64(2), nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	targetMock.ExpectExec(regexp.QuoteMeta(`UPDATE "cards" SET "replaced_by_card_id"=$1 WHERE card_id = $2`)).
+		WithArgs(int64(2), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	targetMock.ExpectExec(regexp.QuoteMeta(`SELECT setval(`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	targetMock.ExpectCommit()
+	sourceMock.ExpectCommit()
+
+	_, err := NewCopier(source, target).Copy(context.Background(), []Table{
+		withDeferred(identityTable("cards", "card_id"), "replaced_by_card_id"),
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, sourceMock.ExpectationsWereMet())
+	assert.NoError(t, targetMock.ExpectationsWereMet())
+}
+
+func TestCopier_CopyPagesOnCompositeKeys(t *testing.T) {
+	source, sourceMock := newMockDB(t)
+	target, targetMock := newMockDB(t)
+
+	sourceMock.ExpectBegin()
+	sourceMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "merchants" ORDER BY tenant_id, merchant_id LIMIT $1`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"tenant_id", "merchant_id"}).AddRow(int64(1), "m1"))
+	sourceMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "merchants" WHERE (tenant_id, merchant_id) > ($1,$2) ORDER BY tenant_id, merchant_id LIMIT $3`)).
+		WithArgs(int64(1), "m1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"tenant_id", "merchant_id"}))
+
+	targetMock.ExpectBegin()
+	targetMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO merchants (merchant_id, tenant_id) VALUES ($1,$2) ON CONFLICT (tenant_id, merchant_id) DO NOTHING`)).
+		WithArgs("m1", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	targetMock.ExpectCommit()
+	sourceMock.ExpectCommit()
+
+	copier := NewCopier(source, target)
+	copier.batchSize = 1
+	_, err := copier.Copy(context.Background(), []Table{{Name: "merchants", Key: []string{"tenant_id", "merchant_id"}}})
+
+	assert.NoError(t, err)
+	assert.NoError(t, sourceMock.ExpectationsWereMet())
+	assert.NoError(t, targetMock.ExpectationsWereMet())
+}
@@ -0,0 +1,65 @@
+package apiclient
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+type Repository interface {
+	Get(ctx context.Context, query *models.APIClient) (*models.APIClient, error)
+	Save(ctx context.Context, client *models.APIClient) error
+	Update(ctx context.Context, client *models.APIClient, update map[string]interface{}) error
+}
+
+type apiClientRepository struct {
+	dB repository.Accessor
+}
+
+func NewAPIClientRepository(accessor repository.Accessor) Repository {
+	return &apiClientRepository{
+		dB: accessor,
+	}
+}
+
+func (repo *apiClientRepository) Get(ctx context.Context, query *models.APIClient) (*models.APIClient, error) {
+	var client models.APIClient
+
+	err := repo.dB.Read(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).First(&client, query).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, repository.MapError(err)
+	}
+	return &client, nil
+}
+
+func (repo *apiClientRepository) Save(ctx context.Context, client *models.APIClient) error {
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Create(client).Error
+	})
+
+	if err != nil {
+		return repository.MapError(err)
+	}
+	return nil
+}
+
+func (repo *apiClientRepository) Update(ctx context.Context, client *models.APIClient, update map[string]interface{}) error {
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Model(client).Updates(update).Error
+	})
+
+	if err != nil {
+		return repository.MapError(err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAPIClientRepository is an autogenerated mock type for the Repository type
+type MockAPIClientRepository struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, query
+func (_m *MockAPIClientRepository) Get(ctx context.Context, query *models.APIClient) (*models.APIClient, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.APIClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.APIClient) (*models.APIClient, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.APIClient) *models.APIClient); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.APIClient) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, client
+func (_m *MockAPIClientRepository) Save(ctx context.Context, client *models.APIClient) error {
+	ret := _m.Called(ctx, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.APIClient) error); ok {
+		r0 = rf(ctx, client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, client, update
+func (_m *MockAPIClientRepository) Update(ctx context.Context, client *models.APIClient, update map[string]interface{}) error {
+	ret := _m.Called(ctx, client, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.APIClient, map[string]interface{}) error); ok {
+		r0 = rf(ctx, client, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockAPIClientRepository creates a new instance of MockAPIClientRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIClientRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIClientRepository {
+	mock := &MockAPIClientRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
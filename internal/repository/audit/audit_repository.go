@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+)
+
+// scrubAccountQuery blanks every value recorded for an account and its cards, keeping the column names so the trail
+// still shows who changed what and when.
+const scrubAccountQuery = `UPDATE audit_logs SET
+	before_data = (SELECT jsonb_object_agg(k, to_jsonb(?::text)) FROM jsonb_object_keys(before_data) AS k),
+	after_data = (SELECT jsonb_object_agg(k, to_jsonb(?::text)) FROM jsonb_object_keys(after_data) AS k)
+WHERE (entity = 'accounts' AND entity_id = ?)
+	OR (entity = 'cards' AND entity_id IN (SELECT card_id::text FROM cards WHERE account_id = ?))`
+
+// erasedValue replaces the values scrubbed from audit logs.
+const erasedValue = "[ERASED]"
+
+// Repository reads audit logs; they are written by the audit callbacks registered on the accessor. Entries are never
+// changed, except by ScrubAccount.
+type Repository interface {
+	GetPage(ctx context.Context, query *models.AuditLog, request repository.FilterRequest) ([]models.AuditLog, repository.Page, error)
+	// ScrubAccount blanks the before and after values of the audit logs of an account and its cards, the only change
+	// the audit_logs trigger allows.
+	ScrubAccount(ctx context.Context, accountID int64) error
+}
+
+type auditRepository struct {
+	dB repository.Accessor
+}
+
+func NewAuditRepository(accessor repository.Accessor) Repository {
+	return &auditRepository{
+		dB: accessor,
+	}
+}
+
+func (repo *auditRepository) GetPage(ctx context.Context, query *models.AuditLog, request repository.FilterRequest) ([]models.AuditLog, repository.Page, error) {
+	var logs []models.AuditLog
+	var page repository.Page
+
+	err := repo.dB.Read(ctx, func(ctx context.Context) error {
+		db := repository.GetTx(ctx).Model(&models.AuditLog{}).
+			Scopes(request.CreatedAtRange()).
+			Where(query)
+
+		var err error
+		page, err = repository.Paginate(db, request, &logs, func(log models.AuditLog) repository.Cursor {
+			return repository.Cursor{Value: log.CreatedAt, ID: log.AuditID}
+		})
+		return err
+	})
+
+	if err != nil {
+		return nil, page, repository.MapError(err)
+	}
+	return logs, page, nil
+}
+
+func (repo *auditRepository) ScrubAccount(ctx context.Context, accountID int64) error {
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		tx := repository.GetTx(ctx)
+		// local to the transaction, so nothing else gets to change audit logs
+		if err := tx.Exec("SELECT set_config('prismo.audit_scrub', 'on', true)").Error; err != nil {
+			return err
+		}
+		return tx.Exec(scrubAccountQuery, erasedValue, erasedValue, strconv.FormatInt(accountID, 10), accountID).Error
+	})
+
+	if err != nil {
+		return repository.MapError(err)
+	}
+	return nil
+}
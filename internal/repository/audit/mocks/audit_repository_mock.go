@@ -0,0 +1,86 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/shahbaz275817/prismo/internal/repository"
+)
+
+// MockAuditRepository is an autogenerated mock type for the Repository type
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+// GetPage provides a mock function with given fields: ctx, query, request
+func (_m *MockAuditRepository) GetPage(ctx context.Context, query *models.AuditLog, request repository.FilterRequest) ([]models.AuditLog, repository.Page, error) {
+	ret := _m.Called(ctx, query, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 []models.AuditLog
+	var r1 repository.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditLog, repository.FilterRequest) ([]models.AuditLog, repository.Page, error)); ok {
+		return rf(ctx, query, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditLog, repository.FilterRequest) []models.AuditLog); ok {
+		r0 = rf(ctx, query, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.AuditLog, repository.FilterRequest) repository.Page); ok {
+		r1 = rf(ctx, query, request)
+	} else {
+		r1 = ret.Get(1).(repository.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.AuditLog, repository.FilterRequest) error); ok {
+		r2 = rf(ctx, query, request)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ScrubAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockAuditRepository) ScrubAccount(ctx context.Context, accountID int64) error {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScrubAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockAuditRepository creates a new instance of MockAuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditRepository {
+	mock := &MockAuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	logconst "github.com/shahbaz275817/prismo/constants/fields"
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/models"
+)
+
+const (
+	auditTable         = "audit_logs"
+	auditBeforeRowsKey = "audit:before_rows"
+	unknownActor       = "system:unknown"
+	auditBatchSize     = 500
+	auditRedactTag     = "redact"
+	redactedValue      = "[REDACTED]"
+)
+
+type auditRow map[string]interface{}
+
+type auditActionKey struct{}
+
+// WithAuditAction records every write made with ctx under action instead of create, update or delete, so that
+// operations such as an erasure can be told apart in the audit trail.
+func WithAuditAction(ctx context.Context, action models.AuditAction) context.Context {
+	return context.WithValue(ctx, auditActionKey{}, action)
+}
+
+// RegisterAuditCallbacks records every insert, update and delete made through db in audit_logs, inside the same
+// database transaction as the write itself. Repositories are covered without any change on their side.
+func RegisterAuditCallbacks(db *gorm.DB) error {
+	callbacks := []func() error{
+		func() error {
+			return db.Callback().Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").Register("audit:after_create", auditAfterCreate)
+		},
+		func() error {
+			return db.Callback().Update().After("gorm:begin_transaction").Before("gorm:update").Register("audit:before_update", auditLoadBefore)
+		},
+		func() error {
+			return db.Callback().Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").Register("audit:after_update", auditAfterUpdate)
+		},
+		func() error {
+			return db.Callback().Delete().After("gorm:begin_transaction").Before("gorm:delete").Register("audit:before_delete", auditLoadBefore)
+		},
+		func() error {
+			return db.Callback().Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").Register("audit:after_delete", auditAfterDelete)
+		},
+	}
+
+	for _, register := range callbacks {
+		if err := register(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func auditable(db *gorm.DB) bool {
+	stmt := db.Statement
+	return db.Error == nil && !stmt.DryRun && stmt.Schema != nil && stmt.Table != "" && stmt.Table != auditTable
+}
+
+func auditAfterCreate(db *gorm.DB) {
+	if !auditable(db) || db.RowsAffected == 0 {
+		return
+	}
+
+	rows := modelRows(db.Statement)
+	entries := make([]models.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		entries = appendAuditLog(db, entries, models.AuditCreate, row, nil, row)
+	}
+	saveAuditLogs(db, entries)
+}
+
+func auditLoadBefore(db *gorm.DB) {
+	if !auditable(db) {
+		return
+	}
+
+	rows, err := loadAffectedRows(db)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	db.InstanceSet(auditBeforeRowsKey, rows)
+}
+
+func auditAfterUpdate(db *gorm.DB) {
+	if !auditable(db) || db.RowsAffected == 0 {
+		return
+	}
+
+	before := instanceRows(db)
+	pk := db.Statement.Schema.PrioritizedPrimaryField
+	if len(before) == 0 || pk == nil {
+		return
+	}
+
+	ids := make([]interface{}, 0, len(before))
+	for _, row := range before {
+		ids = append(ids, row[pk.DBName])
+	}
+
+	var after []map[string]interface{}
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Table(db.Statement.Table).
+		Where(clause.IN{Column: clause.Column{Name: pk.DBName}, Values: ids}).
+		Find(&after).Error
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+
+	afterByID := make(map[string]auditRow, len(after))
+	for _, row := range after {
+		afterByID[fmt.Sprint(row[pk.DBName])] = row
+	}
+
+	entries := make([]models.AuditLog, 0, len(before))
+	for _, row := range before {
+		changedFrom, changedTo := diffRows(row, afterByID[fmt.Sprint(row[pk.DBName])])
+		if len(changedTo) == 0 {
+			continue
+		}
+		entries = appendAuditLog(db, entries, models.AuditUpdate, row, changedFrom, changedTo)
+	}
+	saveAuditLogs(db, entries)
+}
+
+func auditAfterDelete(db *gorm.DB) {
+	if !auditable(db) || db.RowsAffected == 0 {
+		return
+	}
+
+	rows := instanceRows(db)
+	entries := make([]models.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		entries = appendAuditLog(db, entries, models.AuditDelete, row, row, nil)
+	}
+	saveAuditLogs(db, entries)
+}
+
+// loadAffectedRows reads the rows an update or delete is about to touch, using the statement's conditions and the
+// primary key of its model.
+func loadAffectedRows(db *gorm.DB) ([]auditRow, error) {
+	stmt := db.Statement
+	query := db.Session(&gorm.Session{NewDB: true}).Table(stmt.Table)
+
+	conditions := 0
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			query = query.Clauses(where)
+			conditions++
+		}
+	}
+
+	if stmt.ReflectValue.Kind() == reflect.Struct {
+		for _, field := range stmt.Schema.PrimaryFields {
+			if value, zero := field.ValueOf(stmt.Context, stmt.ReflectValue); !zero {
+				query = query.Where(clause.Eq{Column: clause.Column{Name: field.DBName}, Value: value})
+				conditions++
+			}
+		}
+	}
+
+	if conditions == 0 {
+		return nil, nil
+	}
+
+	var found []map[string]interface{}
+	if err := query.Find(&found).Error; err != nil {
+		return nil, err
+	}
+
+	rows := make([]auditRow, 0, len(found))
+	for _, row := range found {
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func instanceRows(db *gorm.DB) []auditRow {
+	v, ok := db.InstanceGet(auditBeforeRowsKey)
+	if !ok {
+		return nil
+	}
+	rows, _ := v.([]auditRow)
+	return rows
+}
+
+func modelRows(stmt *gorm.Statement) []auditRow {
+	rv := reflect.Indirect(stmt.ReflectValue)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		rows := make([]auditRow, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			rows = append(rows, structRow(stmt.Context, stmt.Schema, reflect.Indirect(rv.Index(i))))
+		}
+		return rows
+	case reflect.Struct:
+		return []auditRow{structRow(stmt.Context, stmt.Schema, rv)}
+	default:
+		return nil
+	}
+}
+
+func structRow(ctx context.Context, s *schema.Schema, rv reflect.Value) auditRow {
+	row := auditRow{}
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		value, _ := field.ValueOf(ctx, rv)
+		row[field.DBName] = value
+	}
+	return row
+}
+
+// diffRows returns the columns whose values differ, as they were before and after.
+func diffRows(before, after auditRow) (auditRow, auditRow) {
+	changedFrom, changedTo := auditRow{}, auditRow{}
+	for column, value := range after {
+		if !reflect.DeepEqual(before[column], value) {
+			changedFrom[column] = before[column]
+			changedTo[column] = value
+		}
+	}
+	return changedFrom, changedTo
+}
+
+func appendAuditLog(db *gorm.DB, entries []models.AuditLog, action models.AuditAction, row auditRow, before auditRow, after auditRow) []models.AuditLog {
+	stmt := db.Statement
+
+	entry := models.AuditLog{
+		Actor:     actorFromContext(stmt.Context),
+		RequestID: requestIDFromContext(stmt.Context),
+		Entity:    stmt.Table,
+		Action:    actionFromContext(stmt.Context, action),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if pk := stmt.Schema.PrioritizedPrimaryField; pk != nil && row[pk.DBName] != nil {
+		id := fmt.Sprint(row[pk.DBName])
+		entry.EntityID = &id
+	}
+
+	var err error
+	if entry.Before, err = marshalAuditRow(redactRow(stmt.Schema, before)); err != nil {
+		_ = db.AddError(err)
+		return entries
+	}
+	if entry.After, err = marshalAuditRow(redactRow(stmt.Schema, after)); err != nil {
+		_ = db.AddError(err)
+		return entries
+	}
+	return append(entries, entry)
+}
+
+func saveAuditLogs(db *gorm.DB, entries []models.AuditLog) {
+	if db.Error != nil || len(entries) == 0 {
+		return
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).CreateInBatches(&entries, auditBatchSize).Error; err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// redactRow masks the columns whose model field is tagged audit:"redact", so personal data never reaches the
+// append only audit trail. Null values are kept to show whether the column was set.
+func redactRow(s *schema.Schema, row auditRow) auditRow {
+	if row == nil {
+		return nil
+	}
+
+	redacted := make(auditRow, len(row))
+	for column, value := range row {
+		redacted[column] = value
+		if field := s.LookUpField(column); field != nil && field.Tag.Get("audit") == auditRedactTag && !isNilValue(value) {
+			redacted[column] = redactedValue
+		}
+	}
+	return redacted
+}
+
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+func marshalAuditRow(row auditRow) (json.RawMessage, error) {
+	if row == nil {
+		return nil, nil
+	}
+	return json.Marshal(row)
+}
+
+func actorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return unknownActor
+	}
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		return p.Actor()
+	}
+	return unknownActor
+}
+
+func actionFromContext(ctx context.Context, action models.AuditAction) models.AuditAction {
+	if ctx == nil {
+		return action
+	}
+	if override, ok := ctx.Value(auditActionKey{}).(models.AuditAction); ok && override != "" {
+		return override
+	}
+	return action
+}
+
+func requestIDFromContext(ctx context.Context) *string {
+	if ctx == nil {
+		return nil
+	}
+	if rid, ok := ctx.Value(logconst.RequestIDKey).(string); ok && rid != "" {
+		return &rid
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+
+	logconst "github.com/shahbaz275817/prismo/constants/fields"
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/models"
+)
+
+type auditedRow struct {
+	RowID  int64 `gorm:"primaryKey"`
+	Name   string
+	Status string
+}
+
+type redactedRow struct {
+	RowID    int64  `gorm:"primaryKey"`
+	Document string `audit:"redact"`
+	Status   string
+}
+
+func auditContext() context.Context {
+	ctx := auth.WithPrincipal(context.Background(), auth.NewUserPrincipal("ops", 1, auth.RoleAdmin))
+	return context.WithValue(ctx, logconst.RequestIDKey, "req-1")
+}
+
+func TestAuditCallbacks_Create(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterAuditCallbacks(db))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audited_rows" ("name","status") VALUES ($1,$2) RETURNING "row_id"`)).
+		WithArgs("a", "new").
+		WillReturnRows(sqlmock.NewRows([]string{"row_id"}).AddRow(5))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs"`)).
+		WithArgs(int64(0), "user:ops", "req-1", "audited_rows", "5", "create", []byte(`{"name":"a","row_id":5,"status":"new"}`), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"audit_id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := db.WithContext(auditContext()).Create(&auditedRow{Name: "a", Status: "new"}).Error
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditCallbacks_UpdateRecordsOnlyChangedColumns(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterAuditCallbacks(db))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "audited_rows" WHERE "row_id" = $1`)).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "name", "status"}).AddRow(5, "a", "new"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "audited_rows" SET "status"=$1 WHERE "row_id" = $2`)).
+		WithArgs("active", int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "audited_rows" WHERE "row_id" = $1`)).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "name", "status"}).AddRow(5, "a", "active"))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs"`)).
+		WithArgs(int64(0), "system:unknown", nil, "audited_rows", "5", "update", []byte(`{"status":"new"}`), []byte(`{"status":"active"}`), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"audit_id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := db.Model(&auditedRow{RowID: 5}).Updates(&auditedRow{Status: "active"}).Error
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditCallbacks_Delete(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterAuditCallbacks(db))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "audited_rows" WHERE "row_id" = $1`)).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "name", "status"}).AddRow(5, "a", "new"))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "audited_rows" WHERE "audited_rows"."row_id" = $1`)).
+		WithArgs(int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs"`)).
+		WithArgs(int64(0), "user:ops", "req-1", "audited_rows", "5", "delete", []byte(`{"name":"a","row_id":5,"status":"new"}`), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"audit_id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := db.WithContext(auditContext()).Delete(&auditedRow{RowID: 5}).Error
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditCallbacks_RedactsTaggedColumnsAndUsesActionFromContext(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterAuditCallbacks(db))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "redacted_rows" WHERE "row_id" = $1`)).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "document", "status"}).AddRow(5, "12345678900", "active"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "redacted_rows" SET "document"=$1 WHERE "row_id" = $2`)).
+		WithArgs("X0a1b2c3d4e5f6", int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "redacted_rows" WHERE "row_id" = $1`)).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "document", "status"}).AddRow(5, "X0a1b2c3d4e5f6", "active"))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs"`)).
+		WithArgs(int64(0), "user:ops", "req-1", "redacted_rows", "5", "erase", []byte(`{"document":"[REDACTED]"}`), []byte(`{"document":"[REDACTED]"}`), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"audit_id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	ctx := WithAuditAction(auditContext(), models.AuditErase)
+	err := db.WithContext(ctx).Model(&redactedRow{RowID: 5}).Updates(&redactedRow{Document: "X0a1b2c3d4e5f6"}).Error
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedactRow_MasksPersonalDataOfModels(t *testing.T) {
+	tests := []struct {
+		model    interface{}
+		row      auditRow
+		redacted []string
+	}{
+		{model: &models.Account{}, row: auditRow{"account_id": 1, "document_number": "12345678900"}, redacted: []string{"document_number"}},
+		{model: &models.Card{}, row: auditRow{"card_id": 1, "encrypted_pan": "c2VjcmV0", "pan_hash": "ab12", "masked_pan": "400000******1234"}, redacted: []string{"encrypted_pan", "pan_hash"}},
+		{model: &models.User{}, row: auditRow{"id": 1, "name": "Jane", "email": "jane@example.com"}, redacted: []string{"name", "email"}},
+	}
+
+	for _, tt := range tests {
+		s, err := schema.Parse(tt.model, &sync.Map{}, schema.NamingStrategy{})
+		assert.NoError(t, err)
+
+		redacted := redactRow(s, tt.row)
+
+		for column, value := range tt.row {
+			want := value
+			for _, c := range tt.redacted {
+				if c == column {
+					want = redactedValue
+				}
+			}
+			assert.Equal(t, want, redacted[column], "%s.%s", s.Table, column)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package card
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+type Repository interface {
+	Get(ctx context.Context, query *models.Card) (*models.Card, error)
+	// GetForUpdate locks the card matching query until the surrounding transaction ends, so it must run in Transact.
+	GetForUpdate(ctx context.Context, query *models.Card) (*models.Card, error)
+	// PanHashTaken tells whether any tenant holds a card with the number hashed to panHash. Card numbers are unique
+	// across tenants, as tenants issue them from the same BIN, so the lookup is not scoped to the caller's tenant.
+	PanHashTaken(ctx context.Context, panHash string) (bool, error)
+	Save(ctx context.Context, card *models.Card) error
+	Update(ctx context.Context, card *models.Card, update map[string]interface{}) error
+	// EraseByAccount drops the encrypted card numbers of an account and blocks its cards that are still usable.
+	EraseByAccount(ctx context.Context, accountID int64) error
+	Transact(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type cardRepository struct {
+	dB repository.Accessor
+}
+
+func NewCardRepository(accessor repository.Accessor) Repository {
+	return &cardRepository{
+		dB: accessor,
+	}
+}
+
+func (repo *cardRepository) Get(ctx context.Context, query *models.Card) (*models.Card, error) {
+	var card models.Card
+
+	err := repo.dB.Read(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).First(&card, query).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, repository.MapError(err)
+	}
+	return &card, nil
+}
+
+func (repo *cardRepository) GetForUpdate(ctx context.Context, query *models.Card) (*models.Card, error) {
+	var card models.Card
+
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&card, query).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, repository.MapError(err)
+	}
+	return &card, nil
+}
+
+func (repo *cardRepository) PanHashTaken(ctx context.Context, panHash string) (bool, error) {
+	var taken bool
+
+	// raw SQL carries no model, so the tenant callbacks leave it unscoped
+	err := repo.dB.Read(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Raw("SELECT EXISTS (SELECT 1 FROM cards WHERE pan_hash = ?)", panHash).Scan(&taken).Error
+	})
+
+	if err != nil {
+		return false, repository.MapError(err)
+	}
+	return taken, nil
+}
+
+func (repo *cardRepository) Save(ctx context.Context, card *models.Card) error {
+	return repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Create(card).Error
+	})
+}
+
+func (repo *cardRepository) Update(ctx context.Context, card *models.Card, update map[string]interface{}) error {
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Model(card).Updates(update).Error
+	})
+
+	if err != nil {
+		return repository.MapError(err)
+	}
+	return nil
+}
+
+func (repo *cardRepository) EraseByAccount(ctx context.Context, accountID int64) error {
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Model(&models.Card{}).
+			Where("account_id = ?", accountID).
+			Updates(map[string]interface{}{
+				"encrypted_pan": "",
+				// Pan_Hash is unique, so it is replaced by a random value rather than cleared
+				"pan_hash":   gorm.Expr("md5(random()::text || card_id::text)"),
+				"status":     gorm.Expr("CASE WHEN status = ? THEN status ELSE ? END", models.CardReplaced, models.CardBlocked),
+				"updated_at": time.Now().UTC(),
+			}).Error
+	})
+
+	if err != nil {
+		return repository.MapError(err)
+	}
+	return nil
+}
+
+func (repo *cardRepository) Transact(ctx context.Context, f func(ctx context.Context) error) error {
+	return repo.dB.Transact(ctx, f)
+}
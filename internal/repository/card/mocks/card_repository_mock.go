@@ -0,0 +1,189 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCardRepository is an autogenerated mock type for the Repository type
+type MockCardRepository struct {
+	mock.Mock
+}
+
+// EraseByAccount provides a mock function with given fields: ctx, accountID
+func (_m *MockCardRepository) EraseByAccount(ctx context.Context, accountID int64) error {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EraseByAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, query
+func (_m *MockCardRepository) Get(ctx context.Context, query *models.Card) (*models.Card, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.Card
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Card) (*models.Card, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Card) *models.Card); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Card)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Card) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForUpdate provides a mock function with given fields: ctx, query
+func (_m *MockCardRepository) GetForUpdate(ctx context.Context, query *models.Card) (*models.Card, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUpdate")
+	}
+
+	var r0 *models.Card
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Card) (*models.Card, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Card) *models.Card); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Card)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Card) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PanHashTaken provides a mock function with given fields: ctx, panHash
+func (_m *MockCardRepository) PanHashTaken(ctx context.Context, panHash string) (bool, error) {
+	ret := _m.Called(ctx, panHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PanHashTaken")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, panHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, panHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, panHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, _a1
+func (_m *MockCardRepository) Save(ctx context.Context, _a1 *models.Card) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Card) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transact provides a mock function with given fields: ctx, f
+func (_m *MockCardRepository) Transact(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, _a1, update
+func (_m *MockCardRepository) Update(ctx context.Context, _a1 *models.Card, update map[string]interface{}) error {
+	ret := _m.Called(ctx, _a1, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Card, map[string]interface{}) error); ok {
+		r0 = rf(ctx, _a1, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockCardRepository creates a new instance of MockCardRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCardRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCardRepository {
+	mock := &MockCardRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
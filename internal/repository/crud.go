@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+// CRUD stores entities of type T. Entity repositories embed it and only add their domain specific queries.
+type CRUD[T any] interface {
+	// Get returns the first entity matching the non zero fields of query, or nil when there is none.
+	Get(ctx context.Context, query *T) (*T, error)
+	// List returns the page of entities matching the non zero fields of query and the filters of request.
+	List(ctx context.Context, query *T, request FilterRequest) ([]T, Page, error)
+	Save(ctx context.Context, model *T) error
+	// Update sets the non zero fields of update, a *T, or every column of update, a map of column names, on model.
+	Update(ctx context.Context, model *T, update interface{}) error
+	Delete(ctx context.Context, model *T) error
+	Transact(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type crud[T any] struct {
+	dB       Accessor
+	name     string
+	cursorOf func(T) Cursor
+}
+
+// NewCRUD returns the CRUD repository of T. cursorOf returns the keyset position of an entity, and may be nil when
+// List is never asked for keyset pages.
+func NewCRUD[T any](accessor Accessor, cursorOf func(T) Cursor) CRUD[T] {
+	return &crud[T]{
+		dB:       accessor,
+		name:     strings.ToLower(reflect.TypeOf(new(T)).Elem().Name()),
+		cursorOf: cursorOf,
+	}
+}
+
+func (repo *crud[T]) Get(ctx context.Context, query *T) (*T, error) {
+	var entity T
+
+	err := repo.dB.Read(repo.operation(ctx, "get"), func(ctx context.Context) error {
+		return GetTx(ctx).First(&entity, query).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, MapError(err)
+	}
+	return &entity, nil
+}
+
+func (repo *crud[T]) List(ctx context.Context, query *T, request FilterRequest) ([]T, Page, error) {
+	var entities []T
+	var page Page
+
+	if request.Keyset != nil && repo.cursorOf == nil {
+		return nil, page, errors.NewUnknownError("keyset pagination is not supported for " + repo.name)
+	}
+
+	err := repo.dB.Read(repo.operation(ctx, "list"), func(ctx context.Context) error {
+		db := GetTx(ctx).Model(new(T)).
+			Scopes(request.CreatedAtRange(), request.InFilter()).
+			Where(query)
+
+		var err error
+		page, err = Paginate(db, request, &entities, repo.cursorOf)
+		return err
+	})
+
+	if err != nil {
+		return nil, page, MapError(err)
+	}
+	return entities, page, nil
+}
+
+func (repo *crud[T]) Save(ctx context.Context, model *T) error {
+	err := repo.dB.Transact(repo.operation(ctx, "save"), func(ctx context.Context) error {
+		return GetTx(ctx).Create(model).Error
+	})
+	return MapError(err)
+}
+
+func (repo *crud[T]) Update(ctx context.Context, model *T, update interface{}) error {
+	err := repo.dB.Transact(repo.operation(ctx, "update"), func(ctx context.Context) error {
+		return GetTx(ctx).Model(model).Updates(update).Error
+	})
+	return MapError(err)
+}
+
+func (repo *crud[T]) Delete(ctx context.Context, model *T) error {
+	err := repo.dB.Transact(repo.operation(ctx, "delete"), func(ctx context.Context) error {
+		return GetTx(ctx).Delete(model).Error
+	})
+	return MapError(err)
+}
+
+func (repo *crud[T]) Transact(ctx context.Context, f func(ctx context.Context) error) error {
+	return repo.dB.Transact(ctx, f)
+}
+
+// operation names the metrics of the accessor call, as the generic methods cannot be told apart on the stack.
+func (repo *crud[T]) operation(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, operationKey{}, repo.name+"."+method)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+type crudRow struct {
+	RowID int64 `gorm:"primaryKey"`
+	Name  string
+}
+
+func TestCRUD_GetReturnsNilWhenNotFound(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewCRUD[crudRow](NewAccessorFromDB(db, 1000), nil)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "crud_rows" WHERE "crud_rows"."row_id" = $1 ORDER BY "crud_rows"."row_id" LIMIT $2`)).
+		WithArgs(int64(4), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "name"}))
+	mock.ExpectRollback()
+
+	row, err := repo.Get(context.Background(), &crudRow{RowID: 4})
+
+	assert.NoError(t, err)
+	assert.Nil(t, row)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCRUD_UpdateReturnsErrors(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewCRUD[crudRow](NewAccessorFromDB(db, 1000), nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "crud_rows" SET "name"=$1 WHERE "row_id" = $2`)).
+		WithArgs("renamed", int64(4)).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	err := repo.Update(context.Background(), &crudRow{RowID: 4}, &crudRow{Name: "renamed"})
+
+	assert.ErrorAs(t, err, &errors.UnknownError{})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCRUD_Delete(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewCRUD[crudRow](NewAccessorFromDB(db, 1000), nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "crud_rows" WHERE "crud_rows"."row_id" = $1`)).
+		WithArgs(int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.Delete(context.Background(), &crudRow{RowID: 4}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCRUD_ListFiltersAndPages(t *testing.T) {
+	db, mock := newMockDB(t)
+	repo := NewCRUD[crudRow](NewAccessorFromDB(db, 1000), nil)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "crud_rows" WHERE name IN ($1,$2)`)).
+		WithArgs("a", "b").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "crud_rows" WHERE name IN ($1,$2) ORDER BY row_id LIMIT $3 OFFSET $4`)).
+		WithArgs("a", "b", 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "name"}).AddRow(3, "b"))
+	mock.ExpectCommit()
+
+	rows, page, err := repo.List(context.Background(), &crudRow{}, FilterRequest{
+		SortBy: "row_id",
+		Limit:  2,
+		Offset: 2,
+		In:     map[string][]string{"name": {"a", "b"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []crudRow{{RowID: 3, Name: "b"}}, rows)
+	assert.Equal(t, int64(3), *page.TotalCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCRUD_ListKeysetNeedsCursor(t *testing.T) {
+	db, _ := newMockDB(t)
+	repo := NewCRUD[crudRow](NewAccessorFromDB(db, 1000), nil)
+
+	_, _, err := repo.List(context.Background(), &crudRow{}, FilterRequest{
+		Keyset: &KeysetPagination{Column: "row_id", IDColumn: "row_id"},
+	})
+
+	assert.Error(t, err)
+}
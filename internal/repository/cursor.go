@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const cursorTimeType = "time"
+
+// Cursor is the position of the last row of a page: the value of the sort column and the row ID breaking ties.
+type Cursor struct {
+	Column string
+	Value  interface{}
+	ID     int64
+}
+
+type cursorPayload struct {
+	Column    string          `json:"c"`
+	Value     json.RawMessage `json:"v"`
+	ValueType string          `json:"t,omitempty"`
+	ID        int64           `json:"id"`
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor returns an opaque, URL safe token for the cursor.
+func EncodeCursor(c Cursor) (string, error) {
+	payload := cursorPayload{Column: c.Column, ID: c.ID}
+
+	value := c.Value
+	if t, ok := value.(time.Time); ok {
+		value = t.UTC().Format(time.RFC3339Nano)
+		payload.ValueType = cursorTimeType
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	payload.Value = raw
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor parses a token produced by EncodeCursor and checks that it was issued for the given sort column.
+func DecodeCursor(token string, column string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(b, &payload); err != nil || payload.Column != column || len(payload.Value) == 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	c := &Cursor{Column: payload.Column, ID: payload.ID}
+	if payload.ValueType == cursorTimeType {
+		var s string
+		if err := json.Unmarshal(payload.Value, &s); err != nil {
+			return nil, ErrInvalidCursor
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		c.Value = t
+		return c, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(payload.Value))
+	dec.UseNumber()
+	if err := dec.Decode(&c.Value); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if n, ok := c.Value.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			c.Value = i
+		} else if f, err := n.Float64(); err == nil {
+			c.Value = f
+		}
+	}
+	return c, nil
+}
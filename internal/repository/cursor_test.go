@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "time", value: time.Date(2024, 3, 1, 10, 30, 0, 123456789, time.UTC)},
+		{name: "integer", value: int64(42)},
+		{name: "float", value: 12.5},
+		{name: "string", value: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := EncodeCursor(Cursor{Column: "eventdate", Value: tt.value, ID: 7})
+			assert.NoError(t, err)
+
+			c, err := DecodeCursor(token, "eventdate")
+			assert.NoError(t, err)
+			assert.Equal(t, &Cursor{Column: "eventdate", Value: tt.value, ID: 7}, c)
+		})
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	token, err := EncodeCursor(Cursor{Column: "eventdate", Value: int64(1), ID: 1})
+	assert.NoError(t, err)
+
+	_, err = DecodeCursor(token, "created_at")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = DecodeCursor("not a cursor", "eventdate")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -3,8 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"log"
-	"os"
+	defaultErrors "errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
@@ -24,10 +28,38 @@ const (
 	txTimeoutErrorCode          = "57014"
 	dbMetricKey                 = "database"
 	dbMetricPublishDuration     = 10 * time.Second
+	slowQueryThreshold          = 500 * time.Millisecond
 )
 
 type key string
 
+type operationKey struct{}
+
+type afterCommitKey struct{}
+
+// afterCommitHooks are the functions queued by AfterCommit on the outermost transaction.
+type afterCommitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+func (h *afterCommitHooks) add(fn func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, fn)
+}
+
+func (h *afterCommitHooks) run() {
+	h.mu.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+}
+
 type NewRelicDetail struct {
 	Operation string
 	Target    string
@@ -37,6 +69,9 @@ type Accessor interface {
 	Close() error
 	Ping() error
 	Transact(context.Context, func(ctx context.Context) error) error
+	// Read runs a read only transaction, or joins the transaction already in ctx. Accessors built with
+	// NewReplicaAccessor run it on the read replica when they can.
+	Read(context.Context, func(ctx context.Context) error) error
 	TransactWithTimeout(context.Context, time.Duration, func(ctx context.Context) error, *sql.TxOptions) error
 	TransactWithoutTx(ctx context.Context, fn func(ctx context.Context, db *gorm.DB) error) error
 }
@@ -44,6 +79,7 @@ type Accessor interface {
 type accessor struct {
 	Db          *gorm.DB
 	txTimeoutMS int
+	reporter    *reporting.Reporter
 }
 
 func (acc accessor) Ping() error {
@@ -65,19 +101,12 @@ func (acc accessor) Close() error {
 	return sqlDbInstance.Close()
 }
 
-func NewAccessor(dbConfig config.DBConfig) (Accessor, error) {
+// NewAccessor opens a connection pool for dbConfig. Every repository operation run through it and the pool's stats
+// are reported through reporter, which may be nil.
+func NewAccessor(dbConfig config.DBConfig, reporter *reporting.Reporter) (Accessor, error) {
 	logger.Infof("Creating dB conn pool: %s %s", dbConfig.Host(), dbConfig.Name())
 
-	newLogger := gormLogger.New(
-		log.New(os.Stdout, "\r\n", log.LstdFlags), // io writer
-		gormLogger.Config{
-			SlowThreshold:             500 * time.Millisecond, // Slow SQL threshold
-			LogLevel:                  gormLogger.Info,        // Log level
-			IgnoreRecordNotFoundError: true,                   // Ignore ErrRecordNotFound error for logger
-			ParameterizedQueries:      false,                  // Don't include params in the SQL log
-			Colorful:                  false,                  // Disable color
-		},
-	)
+	newLogger := newGormLogger(slowQueryThreshold, reporter).LogMode(gormLogger.Info)
 
 	db, err := gorm.Open(postgres.Open(dbConfig.GetConnectionString()), &gorm.Config{
 		NowFunc: func() time.Time {
@@ -90,6 +119,18 @@ func NewAccessor(dbConfig config.DBConfig) (Accessor, error) {
 		logger.WithContext(context.Background()).Error("Failed to load Database")
 		return nil, err
 	}
+	if err = RegisterTenantCallbacks(db); err != nil {
+		logger.WithContext(context.Background()).Errorf("Failed to register tenant callbacks: %s", err)
+		return nil, err
+	}
+	if err = RegisterVersionCallbacks(db); err != nil {
+		logger.WithContext(context.Background()).Errorf("Failed to register version callbacks: %s", err)
+		return nil, err
+	}
+	if err = RegisterAuditCallbacks(db); err != nil {
+		logger.WithContext(context.Background()).Errorf("Failed to register audit callbacks: %s", err)
+		return nil, err
+	}
 	sqlDbInstance, err := db.DB()
 	if err = sqlDbInstance.Ping(); err != nil {
 		logger.WithContext(context.Background()).Errorf("ping to the database host failed: %s", err)
@@ -101,14 +142,38 @@ func NewAccessor(dbConfig config.DBConfig) (Accessor, error) {
 	sqlDbInstance.SetMaxOpenConns(dbConfig.MaxPoolSize())
 
 	logger.Infof("Created dB conn pool")
+	reportPoolStats(sqlDbInstance, dbConfig.Pool(), reporter)
 
-	return accessor{db, dbConfig.TransactionTimoutInMS()}, nil
+	return accessor{db, dbConfig.TransactionTimoutInMS(), reporter}, nil
+}
+
+// NewAccessorFromDB wraps an already opened connection, e.g. one backed by sqlmock in tests. Callbacks are not
+// registered on db.
+func NewAccessorFromDB(db *gorm.DB, txTimeoutMS int) Accessor {
+	return accessor{Db: db, txTimeoutMS: txTimeoutMS}
 }
 
 func contextWithDBTx(ctx context.Context, tx *gorm.DB) context.Context {
 	return context.WithValue(ctx, txKey, tx)
 }
 
+// InTransaction reports whether ctx carries a transaction, whose reads go to the primary.
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey).(*gorm.DB)
+	return ok
+}
+
+// AfterCommit runs fn once the transaction of ctx commits, and drops it when the transaction rolls back. Outside a
+// transaction fn runs right away. It lets writers act on what others can see only after the commit, e.g. drop caches.
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	if !ok || !InTransaction(ctx) {
+		fn()
+		return
+	}
+	hooks.add(fn)
+}
+
 func GetTx(ctx context.Context) *gorm.DB {
 	tx, ok := ctx.Value(txKey).(*gorm.DB)
 	if !ok {
@@ -119,29 +184,51 @@ func GetTx(ctx context.Context) *gorm.DB {
 }
 
 func (acc accessor) TransactWithoutTx(ctx context.Context, fn func(ctx context.Context, db *gorm.DB) error) error {
+	entry := acc.reportOperation(ctx)
+	defer entry.Publish()
 
-	return fn(ctx, acc.Db)
+	start := time.Now()
+	err := fn(ctx, acc.Db)
+	reportDBMetric(err, time.Since(start), entry)
+	return err
 }
 
 func (acc accessor) Transact(ctx context.Context, txFunc func(context.Context) error) (err error) {
 	return acc.TransactWithTimeout(ctx, time.Duration(acc.txTimeoutMS*int(time.Millisecond)), txFunc, &sql.TxOptions{})
 }
 
+func (acc accessor) Read(ctx context.Context, readFunc func(context.Context) error) error {
+	return acc.TransactWithTimeout(ctx, time.Duration(acc.txTimeoutMS*int(time.Millisecond)), readFunc, &sql.TxOptions{ReadOnly: true})
+}
+
 func (acc accessor) TransactWithTimeout(ctx context.Context, timeout time.Duration, txFunc func(context.Context) error, txOpts *sql.TxOptions) (err error) {
+	entry := acc.reportOperation(ctx)
+	defer entry.Publish()
+
+	start := time.Now()
+	defer func() { reportDBMetric(err, time.Since(start), entry) }()
 
 	isOwner := false
+	var hooks *afterCommitHooks
 
 	tx, txExists := ctx.Value(txKey).(*gorm.DB)
 
 	if !txExists {
 		isOwner = true
+		hooks = &afterCommitHooks{}
+		ctx = context.WithValue(ctx, afterCommitKey{}, hooks)
 
-		ctx, cancelFunc := context.WithTimeout(ctx, timeout)
+		// the deadline is kept in ctx, so that nested blocks deriving their statements from it keep it too
+		var cancelFunc context.CancelFunc
+		ctx, cancelFunc = context.WithTimeout(ctx, timeout)
 		defer cancelFunc()
 		tx = acc.Db.WithContext(ctx).Begin(txOpts)
 		if tx.Error != nil {
 			return tx.Error
 		}
+	} else {
+		// nested blocks keep the outer transaction but see their own context values, e.g. for auditing
+		tx = tx.WithContext(ctx)
 	}
 
 	defer func() {
@@ -163,7 +250,10 @@ func (acc accessor) TransactWithTimeout(ctx context.Context, timeout time.Durati
 			return
 		}
 		if isOwner { // commit only if outermost transaction
-			tx.Commit()
+			if err = tx.Commit().Error; err != nil {
+				return
+			}
+			hooks.run()
 		}
 	}()
 
@@ -174,8 +264,53 @@ func (acc accessor) TransactWithTimeout(ctx context.Context, timeout time.Durati
 	return err
 }
 
-func reportDBMetric(err error, entry *reporting.ReporterEntry) {
+// reportOperation starts the metrics of the repository method that called into the accessor, e.g.
+// database.account.get for account.Repository's Get.
+func (acc accessor) reportOperation(ctx context.Context) *reporting.ReporterEntry {
+	if acc.reporter == nil {
+		return nil
+	}
+	name, ok := ctx.Value(operationKey{}).(string)
+	if !ok {
+		name = operationName()
+	}
+	return acc.reporter.Report(fmt.Sprintf("%s.%s", dbMetricKey, name))
+}
+
+// operationName names the first function up the stack outside this package, which is the repository method (or the
+// service, when it transacts directly) that runs the operation.
+func operationName() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, repositoryPackage+".") {
+			return metricName(frame.Function)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+var repositoryPackage = reflect.TypeOf(accessor{}).PkgPath()
+
+// metricName turns a function name like github.com/x/internal/repository/account.(*accountRepository).Get into
+// account.get.
+func metricName(function string) string {
+	function = function[strings.LastIndex(function, "/")+1:]
+	parts := strings.Split(function, ".")
+	if len(parts) < 2 {
+		return strings.ToLower(function)
+	}
+	return strings.ToLower(parts[0] + "." + parts[len(parts)-1])
+}
+
+func reportDBMetric(err error, latency time.Duration, entry *reporting.ReporterEntry) {
+	entry.Timing("latency", latency)
+
 	if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+		entry.Success()
 		return
 	}
 
@@ -188,7 +323,7 @@ func reportDBMetric(err error, entry *reporting.ReporterEntry) {
 }
 
 func checkDBTimeoutError(err error) bool {
-	if err == context.DeadlineExceeded {
+	if errors.Is(err, context.DeadlineExceeded) {
 		return true
 	}
 
@@ -196,5 +331,29 @@ func checkDBTimeoutError(err error) bool {
 		return true
 	}
 
-	return false
+	var pgErr *pgconn.PgError
+	return defaultErrors.As(err, &pgErr) && pgErr.Code == txTimeoutErrorCode
+}
+
+// reportPoolStats periodically reports the connection pool stats of db as database.pool.<pool> gauges.
+func reportPoolStats(db *sql.DB, pool string, reporter *reporting.Reporter) {
+	if reporter == nil {
+		return
+	}
+
+	metricName := fmt.Sprintf("%s.pool.%s", dbMetricKey, pool)
+	reporter.RegisterPeriodicMetrics(func() {
+		stats := db.Stats()
+		entry := reporter.Report(metricName)
+		entry.SetGauge("max_open_connections", stats.MaxOpenConnections)
+		entry.SetGauge("open_connections", stats.OpenConnections)
+		entry.SetGauge("in_use", stats.InUse)
+		entry.SetGauge("idle", stats.Idle)
+		entry.SetGauge("wait_count", stats.WaitCount)
+		entry.SetGauge("wait_duration_ms", stats.WaitDuration.Milliseconds())
+		entry.SetGauge("max_idle_closed", stats.MaxIdleClosed)
+		entry.SetGauge("max_idle_time_closed", stats.MaxIdleTimeClosed)
+		entry.SetGauge("max_lifetime_closed", stats.MaxLifetimeClosed)
+		entry.Publish()
+	}, dbMetricPublishDuration)
 }
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/reporting"
+)
+
+type recordingMetricReporter struct {
+	reporting.MetricReporter
+	mu      sync.Mutex
+	metrics []string
+}
+
+func (r *recordingMetricReporter) Incr(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, key)
+}
+
+func (r *recordingMetricReporter) Timing(key string, _ interface{}) {
+	r.Incr(key)
+}
+
+func (r *recordingMetricReporter) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.metrics...)
+}
+
+func TestReportDBMetric(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		counter string
+	}{
+		{"success", nil, "success"},
+		{"not found", gorm.ErrRecordNotFound, "success"},
+		{"deadline", errors.WithStack(context.DeadlineExceeded), "timeout"},
+		{"statement timeout", &pgconn.PgError{Code: txTimeoutErrorCode}, "timeout"},
+		{"failure", errors.New("connection reset"), "failure"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := &recordingMetricReporter{}
+			entry := (&reporting.Reporter{MetricReporter: mr}).Report("database.account.get")
+
+			reportDBMetric(tt.err, 5*time.Millisecond, entry)
+			entry.Publish()
+
+			assert.Eventually(t, func() bool { return len(mr.recorded()) == 2 }, time.Second, time.Millisecond)
+			assert.ElementsMatch(t, []string{
+				"counters.database.account.get." + tt.counter + ".count",
+				"timers.database.account.get.latency",
+			}, mr.recorded())
+		})
+	}
+}
+
+func TestMetricName(t *testing.T) {
+	assert.Equal(t, "account.get", metricName("github.com/shahbaz275817/prismo/internal/repository/account.(*accountRepository).Get"))
+	assert.Equal(t, "erasure.erase", metricName("github.com/shahbaz275817/prismo/internal/services/erasure.(*erasureService).Erase"))
+	assert.Equal(t, "main.runinterestaccrual", metricName("main.runInterestAccrual"))
+}
+
+func TestAfterCommit(t *testing.T) {
+	fail := errors.New("failed")
+	tests := []struct {
+		name    string
+		expect  func(mock sqlmock.Sqlmock)
+		err     error
+		wantRan bool
+	}{
+		{"runs after the commit", func(mock sqlmock.Sqlmock) { mock.ExpectBegin(); mock.ExpectCommit() }, nil, true},
+		{"drops on rollback", func(mock sqlmock.Sqlmock) { mock.ExpectBegin(); mock.ExpectRollback() }, fail, false},
+		{"drops when the commit fails", func(mock sqlmock.Sqlmock) { mock.ExpectBegin(); mock.ExpectCommit().WillReturnError(fail) }, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tt.expect(mock)
+			acc := NewAccessorFromDB(db, 1000)
+			ran := false
+
+			err := acc.Transact(context.Background(), func(ctx context.Context) error {
+				// nested blocks queue on the outermost transaction
+				return acc.Transact(ctx, func(ctx context.Context) error {
+					AfterCommit(ctx, func() { ran = true })
+					assert.False(t, ran)
+					return tt.err
+				})
+			})
+
+			assert.Equal(t, tt.wantRan, ran)
+			assert.Equal(t, tt.wantRan, err == nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAfterCommit_RunsRightAwayOutsideTransaction(t *testing.T) {
+	ran := false
+	AfterCommit(context.Background(), func() { ran = true })
+	assert.True(t, ran)
+}
+
+func TestTransactWithTimeout_NestedBlocksKeepTheDeadline(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	acc := NewAccessorFromDB(db, 1000)
+
+	err := acc.Transact(context.Background(), func(ctx context.Context) error {
+		return acc.Transact(ctx, func(ctx context.Context) error {
+			_, ok := GetTx(ctx).Statement.Context.Deadline()
+			assert.True(t, ok)
+			return nil
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
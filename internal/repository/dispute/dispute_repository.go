@@ -0,0 +1,88 @@
+package dispute
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+type Repository interface {
+	// GetForUpdate locks the dispute matching query until the surrounding transaction ends, so it must run in Transact.
+	GetForUpdate(ctx context.Context, query *models.Dispute) (*models.Dispute, error)
+	Save(ctx context.Context, dispute *models.Dispute) error
+	Update(ctx context.Context, dispute *models.Dispute, update map[string]interface{}) error
+	HasOpenDisputes(ctx context.Context, accountID int64) (bool, error)
+	Transact(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type disputeRepository struct {
+	dB repository.Accessor
+}
+
+func NewDisputeRepository(accessor repository.Accessor) Repository {
+	return &disputeRepository{
+		dB: accessor,
+	}
+}
+
+func (repo *disputeRepository) GetForUpdate(ctx context.Context, query *models.Dispute) (*models.Dispute, error) {
+	var dispute models.Dispute
+
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&dispute, query).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, repository.MapError(err)
+	}
+	return &dispute, nil
+}
+
+func (repo *disputeRepository) Save(ctx context.Context, dispute *models.Dispute) error {
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Create(dispute).Error
+	})
+
+	if err != nil {
+		return repository.MapError(err)
+	}
+	return nil
+}
+
+func (repo *disputeRepository) Update(ctx context.Context, dispute *models.Dispute, update map[string]interface{}) error {
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Model(dispute).Updates(update).Error
+	})
+
+	if err != nil {
+		return repository.MapError(err)
+	}
+	return nil
+}
+
+func (repo *disputeRepository) HasOpenDisputes(ctx context.Context, accountID int64) (bool, error) {
+	var count int64
+
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Model(&models.Dispute{}).
+			Where("account_id = ? AND status = ?", accountID, models.DisputeOpen).
+			Count(&count).Error
+	})
+
+	if err != nil {
+		return false, repository.MapError(err)
+	}
+	return count > 0, nil
+}
+
+func (repo *disputeRepository) Transact(ctx context.Context, f func(ctx context.Context) error) error {
+	return repo.dB.Transact(ctx, f)
+}
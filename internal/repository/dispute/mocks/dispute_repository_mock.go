@@ -0,0 +1,142 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+)
+
+// MockDisputeRepository is an autogenerated mock type for the Repository type
+type MockDisputeRepository struct {
+	mock.Mock
+}
+
+// GetForUpdate provides a mock function with given fields: ctx, query
+func (_m *MockDisputeRepository) GetForUpdate(ctx context.Context, query *models.Dispute) (*models.Dispute, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUpdate")
+	}
+
+	var r0 *models.Dispute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Dispute) (*models.Dispute, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Dispute) *models.Dispute); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Dispute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Dispute) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasOpenDisputes provides a mock function with given fields: ctx, accountID
+func (_m *MockDisputeRepository) HasOpenDisputes(ctx context.Context, accountID int64) (bool, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasOpenDisputes")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, _a1
+func (_m *MockDisputeRepository) Save(ctx context.Context, _a1 *models.Dispute) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Dispute) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transact provides a mock function with given fields: ctx, f
+func (_m *MockDisputeRepository) Transact(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, _a1, update
+func (_m *MockDisputeRepository) Update(ctx context.Context, _a1 *models.Dispute, update map[string]interface{}) error {
+	ret := _m.Called(ctx, _a1, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Dispute, map[string]interface{}) error); ok {
+		r0 = rf(ctx, _a1, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockDisputeRepository creates a new instance of MockDisputeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDisputeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDisputeRepository {
+	mock := &MockDisputeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	defaultErrors "errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+// SQLSTATE codes of the errors callers can act on, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	notNullViolation     = "23502"
+	foreignKeyViolation  = "23503"
+	uniqueViolation      = "23505"
+	checkViolation       = "23514"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// MapError turns a storage error into the pkg/errors type the responder renders with the matching status, so that
+// client mistakes are not reported as 500s and no SQL reaches the caller. Errors that already are domain errors are
+// returned unchanged.
+func MapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var domainErr errors.GenericError
+	if defaultErrors.As(err, &domainErr) {
+		return domainErr
+	}
+
+	if checkDBTimeoutError(err) {
+		return errors.NewTimeoutError("database_timeout", &errors.ErrDetails{
+			Message: "the database did not answer in time",
+		})
+	}
+
+	var pgErr *pgconn.PgError
+	if defaultErrors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolation:
+			return errors.NewConflictError("duplicate_record", &errors.ErrDetails{
+				Message: "a record with the same values already exists",
+			})
+		case foreignKeyViolation:
+			return errors.NewStatusUnprocessableEntity("invalid_reference", &errors.ErrDetails{
+				Message: "the record refers to a record that does not exist",
+			})
+		case checkViolation, notNullViolation:
+			return errors.NewValidationError("constraint_violation", &errors.ErrDetails{
+				Message: "the record has missing or invalid values",
+			})
+		case serializationFailure, deadlockDetected:
+			return errors.NewRetryableError("concurrent_update", &errors.ErrDetails{
+				Message: "the record was changed concurrently, try again",
+			})
+		}
+	}
+
+	logger.Errorf("Database error: %s", err)
+	return errors.NewUnknownErrorWithDetails("database_error", &errors.ErrDetails{Message: "database error"})
+}
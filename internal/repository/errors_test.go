@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want interface{}
+	}{
+		{"unique violation", &pgconn.PgError{Code: uniqueViolation}, &errors.ConflictError{}},
+		{"foreign key violation", &pgconn.PgError{Code: foreignKeyViolation}, &errors.UnprocessableEntityError{}},
+		{"check violation", &pgconn.PgError{Code: checkViolation}, &errors.ValidationError{}},
+		{"serialization failure", errors.WithStack(&pgconn.PgError{Code: serializationFailure}), &errors.RetryableError{}},
+		{"statement timeout", &pgconn.PgError{Code: txTimeoutErrorCode}, &errors.TimeoutError{}},
+		{"deadline", context.DeadlineExceeded, &errors.TimeoutError{}},
+		{"domain error", errors.WithStack(errors.NewNotFoundError("not_found", nil)), &errors.NotFoundError{}},
+		{"other", &pgconn.PgError{Code: "42P01", Message: `relation "cards" does not exist`}, &errors.UnknownError{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MapError(tt.err)
+
+			assert.ErrorAs(t, err, tt.want)
+			assert.NotContains(t, err.Error(), "relation")
+		})
+	}
+
+	assert.NoError(t, MapError(nil))
+}
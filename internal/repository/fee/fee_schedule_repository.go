@@ -0,0 +1,41 @@
+package fee
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+type Repository interface {
+	Get(ctx context.Context, query *models.FeeSchedule) (*models.FeeSchedule, error)
+}
+
+type feeScheduleRepository struct {
+	dB repository.Accessor
+}
+
+func NewFeeScheduleRepository(accessor repository.Accessor) Repository {
+	return &feeScheduleRepository{
+		dB: accessor,
+	}
+}
+
+func (repo *feeScheduleRepository) Get(ctx context.Context, query *models.FeeSchedule) (*models.FeeSchedule, error) {
+	var schedule models.FeeSchedule
+
+	err := repo.dB.Read(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).First(&schedule, query).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, repository.MapError(err)
+	}
+	return &schedule, nil
+}
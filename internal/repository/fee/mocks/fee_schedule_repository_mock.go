@@ -0,0 +1,60 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+)
+
+// MockFeeScheduleRepository is an autogenerated mock type for the Repository type
+type MockFeeScheduleRepository struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, query
+func (_m *MockFeeScheduleRepository) Get(ctx context.Context, query *models.FeeSchedule) (*models.FeeSchedule, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.FeeSchedule) (*models.FeeSchedule, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.FeeSchedule) *models.FeeSchedule); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FeeSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.FeeSchedule) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockFeeScheduleRepository creates a new instance of MockFeeScheduleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFeeScheduleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFeeScheduleRepository {
+	mock := &MockFeeScheduleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
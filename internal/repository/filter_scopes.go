@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"fmt"
+	"sort"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,6 +17,12 @@ type FilterRequest struct {
 	StartedFrom *time.Time
 	StartedTo   *time.Time
 	RawQuery    string
+	// In restricts each column to the listed values. Keys are column names and must never come from user input.
+	In map[string][]string
+	// Keyset replaces Offset and SortBy with cursor based pagination.
+	Keyset *KeysetPagination
+	// SkipCount avoids counting every matching row when the caller does not need a total.
+	SkipCount bool
 }
 
 func (fr FilterRequest) CreatedAtRange() func(db *gorm.DB) *gorm.DB {
@@ -28,6 +36,9 @@ func (fr FilterRequest) CreatedAtRange() func(db *gorm.DB) *gorm.DB {
 
 func (fr FilterRequest) Pagination() func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
+		if fr.Keyset != nil {
+			return fr.Keyset.scope(fr.Limit)(db)
+		}
 		if fr.Limit > 0 {
 			return db.Limit(fr.Limit).Offset(fr.Offset)
 		}
@@ -37,9 +48,24 @@ func (fr FilterRequest) Pagination() func(db *gorm.DB) *gorm.DB {
 
 func (fr FilterRequest) Sort() func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		if fr.SortBy != "" {
+		if fr.SortBy != "" && fr.Keyset == nil {
 			return db.Order(fr.SortBy)
 		}
 		return db
 	}
 }
+
+func (fr FilterRequest) InFilter() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		columns := make([]string, 0, len(fr.In))
+		for column := range fr.In {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		for _, column := range columns {
+			db = db.Where(fmt.Sprintf("%s IN ?", column), fr.In[column])
+		}
+		return db
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+	"github.com/shahbaz275817/prismo/pkg/reporting"
+)
+
+// logrusGormLogger sends GORM's logs to our logger: failed queries as errors, slow ones as warnings and the rest at
+// debug level.
+type logrusGormLogger struct {
+	level         gormLogger.LogLevel
+	slowThreshold time.Duration
+	reporter      *reporting.Reporter
+}
+
+func newGormLogger(slowThreshold time.Duration, reporter *reporting.Reporter) gormLogger.Interface {
+	return logrusGormLogger{level: gormLogger.Warn, slowThreshold: slowThreshold, reporter: reporter}
+}
+
+func (l logrusGormLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	l.level = level
+	return l
+}
+
+func (l logrusGormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormLogger.Info {
+		logger.WithContext(ctx).Infof(msg, data...)
+	}
+}
+
+func (l logrusGormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormLogger.Warn {
+		logger.WithContext(ctx).Warnf(msg, data...)
+	}
+}
+
+func (l logrusGormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormLogger.Error {
+		logger.WithContext(ctx).Errorf(msg, data...)
+	}
+}
+
+func (l logrusGormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormLogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormLogger.Error:
+		sql, rows := fc()
+		logger.WithContext(ctx).Errorf("Query failed after %s [rows:%d] %s: %s", elapsed, rows, sql, err)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormLogger.Warn:
+		sql, rows := fc()
+		logger.WithContext(ctx).Warnf("Slow query took %s, over %s [rows:%d] %s", elapsed, l.slowThreshold, rows, sql)
+
+		entry := l.reporter.Report(dbMetricKey)
+		entry.Incr("slow_query")
+		entry.Publish()
+	case l.level >= gormLogger.Info:
+		sql, rows := fc()
+		logger.WithContext(ctx).Debugf("Query took %s [rows:%d] %s", elapsed, rows, sql)
+	}
+}
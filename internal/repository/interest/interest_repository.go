@@ -0,0 +1,83 @@
+package interest
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+)
+
+type Repository interface {
+	// GetOperationTotals returns the totals of the transactions before asOf of every account, by operation type and
+	// ordered by account.
+	GetOperationTotals(ctx context.Context, asOf time.Time) ([]models.OperationTotal, error)
+	// SaveAccrual inserts the accrual and reports false if one already exists for the account and date.
+	SaveAccrual(ctx context.Context, accrual *models.InterestAccrual) (bool, error)
+	SetAccrualTransaction(ctx context.Context, accrual *models.InterestAccrual, transactionID int64) error
+	Transact(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type interestRepository struct {
+	dB repository.Accessor
+}
+
+func NewInterestRepository(accessor repository.Accessor) Repository {
+	return &interestRepository{
+		dB: accessor,
+	}
+}
+
+func (repo *interestRepository) GetOperationTotals(ctx context.Context, asOf time.Time) ([]models.OperationTotal, error) {
+	var totals []models.OperationTotal
+
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Model(&models.Transaction{}).
+			Select("transactions.account_id, transactions.operationtype_id, operationstypes.is_credit, "+
+				"sum(transactions.amount) as amount").
+			Joins("JOIN operationstypes ON operationstypes.operationtype_id = transactions.operationtype_id").
+			Where("transactions.eventdate < ?", asOf).
+			Group("transactions.account_id, transactions.operationtype_id, operationstypes.is_credit").
+			Order("transactions.account_id, transactions.operationtype_id").
+			Scan(&totals).Error
+	})
+
+	if err != nil {
+		return nil, repository.MapError(err)
+	}
+	return totals, nil
+}
+
+func (repo *interestRepository) SaveAccrual(ctx context.Context, accrual *models.InterestAccrual) (bool, error) {
+	var inserted bool
+
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		res := repository.GetTx(ctx).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(accrual)
+		inserted = res.RowsAffected > 0
+		return res.Error
+	})
+
+	if err != nil {
+		return false, repository.MapError(err)
+	}
+	return inserted, nil
+}
+
+func (repo *interestRepository) SetAccrualTransaction(ctx context.Context, accrual *models.InterestAccrual, transactionID int64) error {
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Model(accrual).Update("transaction_id", transactionID).Error
+	})
+
+	if err != nil {
+		return repository.MapError(err)
+	}
+	return nil
+}
+
+func (repo *interestRepository) Transact(ctx context.Context, f func(ctx context.Context) error) error {
+	return repo.dB.Transact(ctx, f)
+}
@@ -0,0 +1,126 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+
+	time "time"
+)
+
+// MockInterestRepository is an autogenerated mock type for the Repository type
+type MockInterestRepository struct {
+	mock.Mock
+}
+
+// GetOperationTotals provides a mock function with given fields: ctx, asOf
+func (_m *MockInterestRepository) GetOperationTotals(ctx context.Context, asOf time.Time) ([]models.OperationTotal, error) {
+	ret := _m.Called(ctx, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationTotals")
+	}
+
+	var r0 []models.OperationTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.OperationTotal, error)); ok {
+		return rf(ctx, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.OperationTotal); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OperationTotal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveAccrual provides a mock function with given fields: ctx, accrual
+func (_m *MockInterestRepository) SaveAccrual(ctx context.Context, accrual *models.InterestAccrual) (bool, error) {
+	ret := _m.Called(ctx, accrual)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAccrual")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.InterestAccrual) (bool, error)); ok {
+		return rf(ctx, accrual)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.InterestAccrual) bool); ok {
+		r0 = rf(ctx, accrual)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.InterestAccrual) error); ok {
+		r1 = rf(ctx, accrual)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetAccrualTransaction provides a mock function with given fields: ctx, accrual, transactionID
+func (_m *MockInterestRepository) SetAccrualTransaction(ctx context.Context, accrual *models.InterestAccrual, transactionID int64) error {
+	ret := _m.Called(ctx, accrual, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAccrualTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.InterestAccrual, int64) error); ok {
+		r0 = rf(ctx, accrual, transactionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transact provides a mock function with given fields: ctx, f
+func (_m *MockInterestRepository) Transact(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockInterestRepository creates a new instance of MockInterestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInterestRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInterestRepository {
+	mock := &MockInterestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,66 @@
+package merchant
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+type Repository interface {
+	Get(ctx context.Context, query *models.Merchant) (*models.Merchant, error)
+	// SaveIfAbsent inserts the merchant and reports false if it was already registered.
+	SaveIfAbsent(ctx context.Context, merchant *models.Merchant) (bool, error)
+	Transact(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type merchantRepository struct {
+	dB repository.Accessor
+}
+
+func NewMerchantRepository(accessor repository.Accessor) Repository {
+	return &merchantRepository{
+		dB: accessor,
+	}
+}
+
+func (repo *merchantRepository) Get(ctx context.Context, query *models.Merchant) (*models.Merchant, error) {
+	var merchant models.Merchant
+
+	err := repo.dB.Read(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).First(&merchant, query).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, repository.MapError(err)
+	}
+	return &merchant, nil
+}
+
+func (repo *merchantRepository) SaveIfAbsent(ctx context.Context, merchant *models.Merchant) (bool, error) {
+	var inserted bool
+
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		res := repository.GetTx(ctx).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(merchant)
+		inserted = res.RowsAffected > 0
+		return res.Error
+	})
+
+	if err != nil {
+		return false, repository.MapError(err)
+	}
+	return inserted, nil
+}
+
+func (repo *merchantRepository) Transact(ctx context.Context, f func(ctx context.Context) error) error {
+	return repo.dB.Transact(ctx, f)
+}
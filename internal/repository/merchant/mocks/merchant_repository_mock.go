@@ -0,0 +1,106 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+)
+
+// MockMerchantRepository is an autogenerated mock type for the Repository type
+type MockMerchantRepository struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, query
+func (_m *MockMerchantRepository) Get(ctx context.Context, query *models.Merchant) (*models.Merchant, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.Merchant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Merchant) (*models.Merchant, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Merchant) *models.Merchant); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Merchant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Merchant) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveIfAbsent provides a mock function with given fields: ctx, _a1
+func (_m *MockMerchantRepository) SaveIfAbsent(ctx context.Context, _a1 *models.Merchant) (bool, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveIfAbsent")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Merchant) (bool, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Merchant) bool); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Merchant) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transact provides a mock function with given fields: ctx, f
+func (_m *MockMerchantRepository) Transact(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockMerchantRepository creates a new instance of MockMerchantRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMerchantRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMerchantRepository {
+	mock := &MockMerchantRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,168 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	repository "github.com/shahbaz275817/prismo/internal/repository"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCRUD is an autogenerated mock type for the CRUD type
+type MockCRUD[T interface{}] struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, model
+func (_m *MockCRUD[T]) Delete(ctx context.Context, model *T) error {
+	ret := _m.Called(ctx, model)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *T) error); ok {
+		r0 = rf(ctx, model)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, query
+func (_m *MockCRUD[T]) Get(ctx context.Context, query *T) (*T, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *T
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *T) (*T, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *T) *T); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *T) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, query, request
+func (_m *MockCRUD[T]) List(ctx context.Context, query *T, request repository.FilterRequest) ([]T, repository.Page, error) {
+	ret := _m.Called(ctx, query, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []T
+	var r1 repository.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *T, repository.FilterRequest) ([]T, repository.Page, error)); ok {
+		return rf(ctx, query, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *T, repository.FilterRequest) []T); ok {
+		r0 = rf(ctx, query, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *T, repository.FilterRequest) repository.Page); ok {
+		r1 = rf(ctx, query, request)
+	} else {
+		r1 = ret.Get(1).(repository.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *T, repository.FilterRequest) error); ok {
+		r2 = rf(ctx, query, request)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Save provides a mock function with given fields: ctx, model
+func (_m *MockCRUD[T]) Save(ctx context.Context, model *T) error {
+	ret := _m.Called(ctx, model)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *T) error); ok {
+		r0 = rf(ctx, model)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transact provides a mock function with given fields: ctx, f
+func (_m *MockCRUD[T]) Transact(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, model, update
+func (_m *MockCRUD[T]) Update(ctx context.Context, model *T, update interface{}) error {
+	ret := _m.Called(ctx, model, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *T, interface{}) error); ok {
+		r0 = rf(ctx, model, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockCRUD creates a new instance of MockCRUD. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCRUD[T interface{}](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCRUD[T] {
+	mock := &MockCRUD[T]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
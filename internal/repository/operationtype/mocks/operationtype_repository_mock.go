@@ -7,15 +7,35 @@ import (
 
 	models "github.com/shahbaz275817/prismo/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/shahbaz275817/prismo/internal/repository"
 )
 
-// MockOperationtypeRepository is an autogenerated mock type for the Repository type
-type MockOperationtypeRepository struct {
+// MockOperationTypeRepository is an autogenerated mock type for the Repository type
+type MockOperationTypeRepository struct {
 	mock.Mock
 }
 
+// Delete provides a mock function with given fields: ctx, model
+func (_m *MockOperationTypeRepository) Delete(ctx context.Context, model *models.OperationsType) error {
+	ret := _m.Called(ctx, model)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OperationsType) error); ok {
+		r0 = rf(ctx, model)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, query
-func (_m *MockOperationtypeRepository) Get(ctx context.Context, query *models.OperationsType) (*models.OperationsType, error) {
+func (_m *MockOperationTypeRepository) Get(ctx context.Context, query *models.OperationsType) (*models.OperationsType, error) {
 	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
@@ -44,9 +64,76 @@ func (_m *MockOperationtypeRepository) Get(ctx context.Context, query *models.Op
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: ctx, query
-func (_m *MockOperationtypeRepository) Save(ctx context.Context, query *models.OperationsType) error {
-	ret := _m.Called(ctx, query)
+// GetShared provides a mock function with given fields: ctx, description
+func (_m *MockOperationTypeRepository) GetShared(ctx context.Context, description string) (*models.OperationsType, error) {
+	ret := _m.Called(ctx, description)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShared")
+	}
+
+	var r0 *models.OperationsType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.OperationsType, error)); ok {
+		return rf(ctx, description)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.OperationsType); ok {
+		r0 = rf(ctx, description)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OperationsType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, description)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, query, request
+func (_m *MockOperationTypeRepository) List(ctx context.Context, query *models.OperationsType, request repository.FilterRequest) ([]models.OperationsType, repository.Page, error) {
+	ret := _m.Called(ctx, query, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []models.OperationsType
+	var r1 repository.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OperationsType, repository.FilterRequest) ([]models.OperationsType, repository.Page, error)); ok {
+		return rf(ctx, query, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OperationsType, repository.FilterRequest) []models.OperationsType); ok {
+		r0 = rf(ctx, query, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OperationsType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.OperationsType, repository.FilterRequest) repository.Page); ok {
+		r1 = rf(ctx, query, request)
+	} else {
+		r1 = ret.Get(1).(repository.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.OperationsType, repository.FilterRequest) error); ok {
+		r2 = rf(ctx, query, request)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Save provides a mock function with given fields: ctx, model
+func (_m *MockOperationTypeRepository) Save(ctx context.Context, model *models.OperationsType) error {
+	ret := _m.Called(ctx, model)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
@@ -54,7 +141,25 @@ func (_m *MockOperationtypeRepository) Save(ctx context.Context, query *models.O
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, *models.OperationsType) error); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, model)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transact provides a mock function with given fields: ctx, f
+func (_m *MockOperationTypeRepository) Transact(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -62,17 +167,17 @@ func (_m *MockOperationtypeRepository) Save(ctx context.Context, query *models.O
 	return r0
 }
 
-// Update provides a mock function with given fields: ctx, query, update
-func (_m *MockOperationtypeRepository) Update(ctx context.Context, query *models.OperationsType, update *models.OperationsType) error {
-	ret := _m.Called(ctx, query, update)
+// Update provides a mock function with given fields: ctx, model, update
+func (_m *MockOperationTypeRepository) Update(ctx context.Context, model *models.OperationsType, update interface{}) error {
+	ret := _m.Called(ctx, model, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.OperationsType, *models.OperationsType) error); ok {
-		r0 = rf(ctx, query, update)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OperationsType, interface{}) error); ok {
+		r0 = rf(ctx, model, update)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -80,13 +185,13 @@ func (_m *MockOperationtypeRepository) Update(ctx context.Context, query *models
 	return r0
 }
 
-// NewMockOperationtypeRepository creates a new instance of MockOperationtypeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockOperationTypeRepository creates a new instance of MockOperationTypeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockOperationtypeRepository(t interface {
+func NewMockOperationTypeRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockOperationtypeRepository {
-	mock := &MockOperationtypeRepository{}
+}) *MockOperationTypeRepository {
+	mock := &MockOperationTypeRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
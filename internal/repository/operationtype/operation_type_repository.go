@@ -3,69 +3,45 @@ package operationtype
 import (
 	"context"
 
-	"gorm.io/gorm"
-
 	"github.com/shahbaz275817/prismo/internal/models"
 	"github.com/shahbaz275817/prismo/internal/repository"
-	"github.com/shahbaz275817/prismo/pkg/errors"
 )
 
+// sharedOperationTypeQuery is raw SQL so it is not scoped to a tenant: shared operation types are looked up at
+// startup, before any request carries one.
+const sharedOperationTypeQuery = `SELECT * FROM operationstypes WHERE tenant_id IS NULL AND description = ? ORDER BY operationtype_id LIMIT 1`
+
 type Repository interface {
-	Get(ctx context.Context, query *models.OperationsType) (*models.OperationsType, error)
-	Save(ctx context.Context, query *models.OperationsType) error
-	Update(ctx context.Context, query *models.OperationsType, update *models.OperationsType) error
+	repository.CRUD[models.OperationsType]
+	// GetShared returns the operation type shared by every tenant with description, or nil when there is none. ctx
+	// needs no tenant.
+	GetShared(ctx context.Context, description string) (*models.OperationsType, error)
 }
 
 type operationTypeRepository struct {
+	repository.CRUD[models.OperationsType]
 	dB repository.Accessor
 }
 
 func NewOperationTypeRepository(accessor repository.Accessor) Repository {
 	return &operationTypeRepository{
-		dB: accessor,
+		CRUD: repository.NewCRUD[models.OperationsType](accessor, nil),
+		dB:   accessor,
 	}
 }
 
-func (repo *operationTypeRepository) Get(ctx context.Context, query *models.OperationsType) (*models.OperationsType, error) {
-	var OperationType models.OperationsType
+func (repo *operationTypeRepository) GetShared(ctx context.Context, description string) (*models.OperationsType, error) {
+	var operationTypes []models.OperationsType
 
-	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
-		return repository.GetTx(ctx).Debug().First(&OperationType, query).Error
+	err := repo.dB.Read(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Raw(sharedOperationTypeQuery, description).Scan(&operationTypes).Error
 	})
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, nil
-	}
 	if err != nil {
-		return nil, errors.NewUnknownError(err.Error())
+		return nil, repository.MapError(err)
 	}
-	return &OperationType, nil
-}
-
-func (repo *operationTypeRepository) Save(ctx context.Context, model *models.OperationsType) error {
-	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
-		return repository.GetTx(ctx).Create(model).Error
-	})
-	return err
-}
-
-func (repo *operationTypeRepository) Update(ctx context.Context, model *models.OperationsType, update *models.OperationsType) error {
-
-	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
-		return repository.GetTx(ctx).Model(&model).Updates(update).Error
-	})
-
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil
-	}
-
-	if err != nil {
-		return nil
+	if len(operationTypes) == 0 {
+		return nil, nil
 	}
-
-	return err
-}
-
-func (repo *operationTypeRepository) Transact(ctx context.Context, f func(ctx context.Context) error) error {
-	return repo.dB.Transact(ctx, f)
+	return &operationTypes[0], nil
 }
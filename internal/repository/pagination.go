@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// KeysetPagination pages through rows ordered by Column and then IDColumn, starting after the After cursor. Unlike
+// LIMIT/OFFSET its cost does not grow with the page number.
+type KeysetPagination struct {
+	Column     string
+	IDColumn   string
+	Descending bool
+	After      *Cursor
+}
+
+// Page describes the position of a result set. NextCursor is empty on the last page and TotalCount is nil when the
+// count was skipped.
+type Page struct {
+	NextCursor string
+	TotalCount *int64
+}
+
+func (kp KeysetPagination) scope(limit int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		direction, comparator := "ASC", ">"
+		if kp.Descending {
+			direction, comparator = "DESC", "<"
+		}
+
+		if kp.After != nil {
+			db = db.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", kp.Column, kp.IDColumn, comparator), kp.After.Value, kp.After.ID)
+		}
+		db = db.Order(fmt.Sprintf("%s %s, %s %s", kp.Column, direction, kp.IDColumn, direction))
+		if limit > 0 {
+			// one extra row tells whether there is a next page
+			db = db.Limit(limit + 1)
+		}
+		return db
+	}
+}
+
+// Paginate counts the rows matched by db, unless the request skips the count, and loads the requested page into dest.
+// db must already carry the request's filters. For keyset requests, cursorOf returns the cursor position of a row.
+func Paginate[T any](db *gorm.DB, request FilterRequest, dest *[]T, cursorOf func(T) Cursor) (Page, error) {
+	var page Page
+
+	db = db.Session(&gorm.Session{})
+	if !request.SkipCount {
+		var count int64
+		if err := db.Count(&count).Error; err != nil {
+			return page, err
+		}
+		page.TotalCount = &count
+	}
+
+	if err := db.Scopes(request.Sort(), request.Pagination()).Find(dest).Error; err != nil {
+		return page, err
+	}
+
+	if request.Keyset == nil || request.Limit <= 0 || len(*dest) <= request.Limit {
+		return page, nil
+	}
+
+	*dest = (*dest)[:request.Limit]
+	last := cursorOf((*dest)[request.Limit-1])
+	last.Column = request.Keyset.Column
+
+	next, err := EncodeCursor(last)
+	if err != nil {
+		return page, err
+	}
+	page.NextCursor = next
+	return page, nil
+}
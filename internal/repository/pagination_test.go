@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type paginatedRow struct {
+	RowID     int64
+	CreatedAt time.Time
+}
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	return db, mock
+}
+
+func rowCursor(r paginatedRow) Cursor {
+	return Cursor{Value: r.CreatedAt, ID: r.RowID}
+}
+
+func TestPaginate_Keyset(t *testing.T) {
+	db, mock := newMockDB(t)
+	after := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	first := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "paginated_rows" WHERE (created_at, row_id) < ($1, $2) ORDER BY created_at DESC, row_id DESC LIMIT $3`)).
+		WithArgs(after, int64(9), 3).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "created_at"}).
+			AddRow(8, first).
+			AddRow(7, first).
+			AddRow(6, first))
+
+	var rows []paginatedRow
+	page, err := Paginate(db.Model(&paginatedRow{}), FilterRequest{
+		Limit:     2,
+		SkipCount: true,
+		Keyset: &KeysetPagination{
+			Column:     "created_at",
+			IDColumn:   "row_id",
+			Descending: true,
+			After:      &Cursor{Column: "created_at", Value: after, ID: 9},
+		},
+	}, &rows, rowCursor)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Len(t, rows, 2)
+	assert.Nil(t, page.TotalCount)
+
+	next, err := DecodeCursor(page.NextCursor, "created_at")
+	assert.NoError(t, err)
+	assert.Equal(t, &Cursor{Column: "created_at", Value: first, ID: 7}, next)
+}
+
+func TestPaginate_LastPageWithCount(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "paginated_rows"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "paginated_rows" ORDER BY created_at ASC, row_id ASC LIMIT $1`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "created_at"}).AddRow(1, time.Now()))
+
+	var rows []paginatedRow
+	page, err := Paginate(db.Model(&paginatedRow{}), FilterRequest{
+		Limit:  2,
+		Keyset: &KeysetPagination{Column: "created_at", IDColumn: "row_id"},
+	}, &rows, rowCursor)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Len(t, rows, 1)
+	assert.Equal(t, int64(1), *page.TotalCount)
+	assert.Empty(t, page.NextCursor)
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+type primaryReadsKey struct{}
+
+// replicaLagQuery returns how many seconds the replica is behind. A replica that replayed everything it received is
+// not behind even if the primary has been idle for a while. Run on a primary it returns 0.
+const replicaLagQuery = `SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END`
+
+type ReplicaConfig struct {
+	// MaxLag is how far behind the primary the replica may be before reads go back to the primary.
+	MaxLag time.Duration
+	// CheckInterval is how often the replica's health and lag are checked.
+	CheckInterval time.Duration
+}
+
+// primaryReads records whether reads made with a context must go to the primary.
+type primaryReads struct {
+	forced  bool
+	written atomic.Bool
+}
+
+// WithPrimaryReads sends every read made with the returned context to the primary.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, &primaryReads{forced: true})
+}
+
+// WithReadYourWrites sends reads made with the returned context to the primary once it has been used to write, so
+// a request sees its own writes even while the replica catches up.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(primaryReadsKey{}).(*primaryReads); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, primaryReadsKey{}, &primaryReads{})
+}
+
+func readsFromPrimary(ctx context.Context) bool {
+	p, ok := ctx.Value(primaryReadsKey{}).(*primaryReads)
+	return ok && (p.forced || p.written.Load())
+}
+
+func markWritten(ctx context.Context) {
+	if p, ok := ctx.Value(primaryReadsKey{}).(*primaryReads); ok {
+		p.written.Store(true)
+	}
+}
+
+type replicaAccessor struct {
+	Accessor
+	replica Accessor
+	cfg     ReplicaConfig
+	healthy atomic.Bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewReplicaAccessor returns an accessor that writes to primary and reads from replica, unless the read is part of a
+// transaction, the context asks for primary reads, or the replica is down or lagging more than cfg.MaxLag.
+func NewReplicaAccessor(primary, replica Accessor, cfg ReplicaConfig) Accessor {
+	acc := &replicaAccessor{
+		Accessor: primary,
+		replica:  replica,
+		cfg:      cfg,
+		stop:     make(chan struct{}),
+	}
+	acc.checkReplica()
+	go acc.watchReplica()
+	return acc
+}
+
+func (acc *replicaAccessor) Read(ctx context.Context, readFunc func(context.Context) error) error {
+	if InTransaction(ctx) || readsFromPrimary(ctx) || !acc.healthy.Load() {
+		return acc.Accessor.Read(ctx, readFunc)
+	}
+	return acc.replica.Read(ctx, readFunc)
+}
+
+func (acc *replicaAccessor) Transact(ctx context.Context, txFunc func(context.Context) error) error {
+	markWritten(ctx)
+	return acc.Accessor.Transact(ctx, txFunc)
+}
+
+func (acc *replicaAccessor) TransactWithTimeout(ctx context.Context, timeout time.Duration, txFunc func(context.Context) error, txOpts *sql.TxOptions) error {
+	markWritten(ctx)
+	return acc.Accessor.TransactWithTimeout(ctx, timeout, txFunc, txOpts)
+}
+
+func (acc *replicaAccessor) Close() error {
+	acc.stopOnce.Do(func() { close(acc.stop) })
+	if err := acc.replica.Close(); err != nil {
+		logger.Errorf("Failed to close read replica: %s", err)
+	}
+	return acc.Accessor.Close()
+}
+
+func (acc *replicaAccessor) watchReplica() {
+	ticker := time.NewTicker(acc.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-acc.stop:
+			return
+		case <-ticker.C:
+			acc.checkReplica()
+		}
+	}
+}
+
+func (acc *replicaAccessor) checkReplica() {
+	ctx, cancel := context.WithTimeout(context.Background(), acc.cfg.CheckInterval)
+	defer cancel()
+
+	var lagSeconds float64
+	err := acc.replica.TransactWithoutTx(ctx, func(ctx context.Context, db *gorm.DB) error {
+		return db.WithContext(ctx).Raw(replicaLagQuery).Scan(&lagSeconds).Error
+	})
+	lag := time.Duration(lagSeconds * float64(time.Second))
+
+	healthy := err == nil && lag <= acc.cfg.MaxLag
+	if was := acc.healthy.Swap(healthy); was != healthy {
+		if healthy {
+			logger.Infof("Read replica is healthy, lag %s", lag)
+		} else if err != nil {
+			logger.Warnf("Read replica is unavailable, reading from primary: %s", err)
+		} else {
+			logger.Warnf("Read replica lags %s behind, reading from primary", lag)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingAccessor records the reads it serves and runs them without opening a transaction.
+type countingAccessor struct {
+	Accessor
+	reads int
+}
+
+func (acc *countingAccessor) Read(ctx context.Context, readFunc func(context.Context) error) error {
+	acc.reads++
+	return readFunc(ctx)
+}
+
+func (acc *countingAccessor) Transact(ctx context.Context, txFunc func(context.Context) error) error {
+	return txFunc(ctx)
+}
+
+func newTestReplicaAccessor(t *testing.T, lag float64, lagErr error) (*replicaAccessor, *countingAccessor, *countingAccessor) {
+	primaryDB, _ := newMockDB(t)
+	replicaDB, mock := newMockDB(t)
+
+	query := mock.ExpectQuery(`pg_last_wal_replay_lsn`)
+	if lagErr != nil {
+		query.WillReturnError(lagErr)
+	} else {
+		query.WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(lag))
+	}
+
+	primary := &countingAccessor{Accessor: NewAccessorFromDB(primaryDB, 1000)}
+	replica := &countingAccessor{Accessor: NewAccessorFromDB(replicaDB, 1000)}
+	acc := NewReplicaAccessor(primary, replica, ReplicaConfig{MaxLag: 2 * time.Second, CheckInterval: time.Hour}).(*replicaAccessor)
+	t.Cleanup(func() { acc.stopOnce.Do(func() { close(acc.stop) }) })
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+	return acc, primary, replica
+}
+
+func noopRead(context.Context) error { return nil }
+
+func TestReplicaAccessor_ReadsFromHealthyReplica(t *testing.T) {
+	acc, primary, replica := newTestReplicaAccessor(t, 0.5, nil)
+
+	assert.NoError(t, acc.Read(context.Background(), noopRead))
+
+	assert.Equal(t, 0, primary.reads)
+	assert.Equal(t, 1, replica.reads)
+}
+
+func TestReplicaAccessor_ReadsFromPrimaryWhenReplicaLags(t *testing.T) {
+	acc, primary, replica := newTestReplicaAccessor(t, 5, nil)
+
+	assert.NoError(t, acc.Read(context.Background(), noopRead))
+
+	assert.Equal(t, 1, primary.reads)
+	assert.Equal(t, 0, replica.reads)
+}
+
+func TestReplicaAccessor_ReadsFromPrimaryWhenReplicaIsDown(t *testing.T) {
+	acc, primary, replica := newTestReplicaAccessor(t, 0, errors.New("connection refused"))
+
+	assert.NoError(t, acc.Read(context.Background(), noopRead))
+
+	assert.Equal(t, 1, primary.reads)
+	assert.Equal(t, 0, replica.reads)
+}
+
+func TestReplicaAccessor_WithPrimaryReads(t *testing.T) {
+	acc, primary, replica := newTestReplicaAccessor(t, 0, nil)
+
+	assert.NoError(t, acc.Read(WithPrimaryReads(context.Background()), noopRead))
+
+	assert.Equal(t, 1, primary.reads)
+	assert.Equal(t, 0, replica.reads)
+}
+
+func TestReplicaAccessor_WithReadYourWrites(t *testing.T) {
+	acc, primary, replica := newTestReplicaAccessor(t, 0, nil)
+	ctx := WithReadYourWrites(context.Background())
+
+	assert.NoError(t, acc.Read(ctx, noopRead))
+	assert.NoError(t, acc.Transact(ctx, noopRead))
+	assert.NoError(t, acc.Read(ctx, noopRead))
+
+	assert.Equal(t, 1, primary.reads)
+	assert.Equal(t, 1, replica.reads)
+}
+
+func TestReplicaAccessor_ReadsInTransactionUsePrimary(t *testing.T) {
+	acc, primary, replica := newTestReplicaAccessor(t, 0, nil)
+	primaryDB, _ := newMockDB(t)
+
+	assert.NoError(t, acc.Read(contextWithDBTx(context.Background(), primaryDB), noopRead))
+
+	assert.Equal(t, 1, primary.reads)
+	assert.Equal(t, 0, replica.reads)
+}
+
+func TestReplicaAccessor_FallsBackToPrimaryOnceReplicaLagsOrFails(t *testing.T) {
+	primaryDB, _ := newMockDB(t)
+	replicaDB, mock := newMockDB(t)
+	mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(0))
+	mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(5))
+	mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(0.5))
+	mock.ExpectQuery(`pg_last_wal_replay_lsn`).WillReturnError(errors.New("connection refused"))
+
+	primary := &countingAccessor{Accessor: NewAccessorFromDB(primaryDB, 1000)}
+	replica := &countingAccessor{Accessor: NewAccessorFromDB(replicaDB, 1000)}
+	acc := NewReplicaAccessor(primary, replica, ReplicaConfig{MaxLag: 2 * time.Second, CheckInterval: time.Hour}).(*replicaAccessor)
+	t.Cleanup(func() { acc.stopOnce.Do(func() { close(acc.stop) }) })
+
+	reads := func() (int, int) {
+		assert.NoError(t, acc.Read(context.Background(), noopRead))
+		return primary.reads, replica.reads
+	}
+
+	p, r := reads()
+	assert.Equal(t, []int{0, 1}, []int{p, r}, "healthy replica")
+
+	acc.checkReplica()
+	p, r = reads()
+	assert.Equal(t, []int{1, 1}, []int{p, r}, "lagging replica")
+
+	acc.checkReplica()
+	p, r = reads()
+	assert.Equal(t, []int{1, 2}, []int{p, r}, "replica caught up")
+
+	acc.checkReplica()
+	p, r = reads()
+	assert.Equal(t, []int{2, 2}, []int{p, r}, "replica down")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
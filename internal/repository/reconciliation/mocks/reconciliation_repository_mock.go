@@ -0,0 +1,143 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReconciliationRepository is an autogenerated mock type for the Repository type
+type MockReconciliationRepository struct {
+	mock.Mock
+}
+
+// GetRun provides a mock function with given fields: ctx, query
+func (_m *MockReconciliationRepository) GetRun(ctx context.Context, query *models.ReconciliationRun) (*models.ReconciliationRun, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRun")
+	}
+
+	var r0 *models.ReconciliationRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ReconciliationRun) (*models.ReconciliationRun, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ReconciliationRun) *models.ReconciliationRun); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ReconciliationRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ReconciliationRun) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSummary provides a mock function with given fields: ctx, runID
+func (_m *MockReconciliationRepository) GetSummary(ctx context.Context, runID int64) ([]models.ReconciliationStatusSummary, error) {
+	ret := _m.Called(ctx, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSummary")
+	}
+
+	var r0 []models.ReconciliationStatusSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]models.ReconciliationStatusSummary, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []models.ReconciliationStatusSummary); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReconciliationStatusSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveResults provides a mock function with given fields: ctx, results
+func (_m *MockReconciliationRepository) SaveResults(ctx context.Context, results []models.ReconciliationResult) error {
+	ret := _m.Called(ctx, results)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveResults")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ReconciliationResult) error); ok {
+		r0 = rf(ctx, results)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveRun provides a mock function with given fields: ctx, run
+func (_m *MockReconciliationRepository) SaveRun(ctx context.Context, run *models.ReconciliationRun) error {
+	ret := _m.Called(ctx, run)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ReconciliationRun) error); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transact provides a mock function with given fields: ctx, f
+func (_m *MockReconciliationRepository) Transact(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockReconciliationRepository creates a new instance of MockReconciliationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReconciliationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReconciliationRepository {
+	mock := &MockReconciliationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
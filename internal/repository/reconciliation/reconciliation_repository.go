@@ -0,0 +1,85 @@
+package reconciliation
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const resultsBatchSize = 500
+
+type Repository interface {
+	GetRun(ctx context.Context, query *models.ReconciliationRun) (*models.ReconciliationRun, error)
+	SaveRun(ctx context.Context, run *models.ReconciliationRun) error
+	SaveResults(ctx context.Context, results []models.ReconciliationResult) error
+	GetSummary(ctx context.Context, runID int64) ([]models.ReconciliationStatusSummary, error)
+	Transact(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type reconciliationRepository struct {
+	dB repository.Accessor
+}
+
+func NewReconciliationRepository(accessor repository.Accessor) Repository {
+	return &reconciliationRepository{
+		dB: accessor,
+	}
+}
+
+func (repo *reconciliationRepository) GetRun(ctx context.Context, query *models.ReconciliationRun) (*models.ReconciliationRun, error) {
+	var run models.ReconciliationRun
+
+	err := repo.dB.Read(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).First(&run, query).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, repository.MapError(err)
+	}
+	return &run, nil
+}
+
+func (repo *reconciliationRepository) SaveRun(ctx context.Context, run *models.ReconciliationRun) error {
+	return repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Create(run).Error
+	})
+}
+
+func (repo *reconciliationRepository) SaveResults(ctx context.Context, results []models.ReconciliationResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	return repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).CreateInBatches(results, resultsBatchSize).Error
+	})
+}
+
+func (repo *reconciliationRepository) GetSummary(ctx context.Context, runID int64) ([]models.ReconciliationStatusSummary, error) {
+	var summary []models.ReconciliationStatusSummary
+
+	err := repo.dB.Read(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Model(&models.ReconciliationResult{}).
+			Select("status, count(*) as count, coalesce(sum(external_amount), 0) as external_amount, coalesce(sum(internal_amount), 0) as internal_amount").
+			Where("run_id = ?", runID).
+			Group("status").
+			Order("status").
+			Scan(&summary).Error
+	})
+
+	if err != nil {
+		return nil, repository.MapError(err)
+	}
+	return summary, nil
+}
+
+func (repo *reconciliationRepository) Transact(ctx context.Context, f func(ctx context.Context) error) error {
+	return repo.dB.Transact(ctx, f)
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	contextWrapper "github.com/shahbaz275817/prismo/pkg/context"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const (
+	tenantColumn    = "tenant_id"
+	tenantTag       = "tenant"
+	tenantSharedTag = "shared"
+	// tenantUnscopedTag marks a tenant column that is recorded but never filtered on or stamped from the context.
+	tenantUnscopedTag = "unscoped"
+)
+
+var (
+	ErrTenantNotSet     = errors.New("tenant is not set in context")
+	ErrCrossTenantWrite = errors.New("model belongs to another tenant")
+)
+
+// RegisterTenantCallbacks scopes every query, update and delete on a model with a tenant_id column to the tenant in
+// the statement context, and stamps that tenant on created rows. Statements on such models fail when the context
+// carries no tenant. Rows of models whose tenant field is tagged tenant:"shared" are visible to every tenant when
+// their tenant_id is null. Models whose tenant field is tagged tenant:"unscoped" are left alone.
+//
+// It must be registered before RegisterAuditCallbacks so the audit trail loads rows through the tenant filter.
+func RegisterTenantCallbacks(db *gorm.DB) error {
+	callbacks := []func() error{
+		func() error {
+			return db.Callback().Create().Before("gorm:create").Register("tenant:create", tenantAssign)
+		},
+		func() error {
+			return db.Callback().Query().Before("gorm:query").Register("tenant:query", tenantFilter)
+		},
+		func() error {
+			return db.Callback().Row().Before("gorm:row").Register("tenant:row", tenantFilter)
+		},
+		func() error {
+			return db.Callback().Update().After("gorm:begin_transaction").Before("gorm:update").Register("tenant:update", tenantFilterWrite)
+		},
+		func() error {
+			return db.Callback().Delete().After("gorm:begin_transaction").Before("gorm:delete").Register("tenant:delete", tenantFilterWrite)
+		},
+	}
+
+	for _, register := range callbacks {
+		if err := register(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tenantField(db *gorm.DB) *schema.Field {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil {
+		return nil
+	}
+	field := stmt.Schema.LookUpField(tenantColumn)
+	if field == nil || field.Tag.Get(tenantTag) == tenantUnscopedTag {
+		return nil
+	}
+	return field
+}
+
+func tenantFilter(db *gorm.DB) {
+	field := tenantField(db)
+	if field == nil {
+		return
+	}
+
+	tenantID, ok := contextWrapper.TenantID(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrTenantNotSet)
+		return
+	}
+
+	column := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+	var condition clause.Expression = clause.Eq{Column: column, Value: tenantID}
+	if field.Tag.Get(tenantTag) == tenantSharedTag {
+		condition = clause.Or(condition, clause.Eq{Column: column, Value: nil})
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{condition}})
+}
+
+// tenantFilterWrite leaves updates and deletes without any condition alone, so GORM still rejects them as global
+// writes instead of applying them to the whole tenant.
+func tenantFilterWrite(db *gorm.DB) {
+	if tenantField(db) == nil || (!hasWhereClause(db.Statement) && !hasPrimaryKeyValue(db.Statement)) {
+		return
+	}
+	tenantFilter(db)
+}
+
+func tenantAssign(db *gorm.DB) {
+	field := tenantField(db)
+	if field == nil {
+		return
+	}
+
+	tenantID, ok := contextWrapper.TenantID(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrTenantNotSet)
+		return
+	}
+
+	assign := func(rv reflect.Value) {
+		value, zero := field.ValueOf(db.Statement.Context, rv)
+		if zero {
+			if err := field.Set(db.Statement.Context, rv, tenantID); err != nil {
+				_ = db.AddError(err)
+			}
+			return
+		}
+		if !sameTenant(value, tenantID) {
+			_ = db.AddError(ErrCrossTenantWrite)
+		}
+	}
+
+	rv := reflect.Indirect(db.Statement.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			assign(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		assign(rv)
+	}
+}
+
+func hasWhereClause(stmt *gorm.Statement) bool {
+	c, ok := stmt.Clauses["WHERE"]
+	if !ok {
+		return false
+	}
+	where, ok := c.Expression.(clause.Where)
+	return ok && len(where.Exprs) > 0
+}
+
+func hasPrimaryKeyValue(stmt *gorm.Statement) bool {
+	rv := reflect.Indirect(stmt.ReflectValue)
+	if rv.Kind() != reflect.Struct {
+		return false
+	}
+	for _, field := range stmt.Schema.PrimaryFields {
+		if _, zero := field.ValueOf(stmt.Context, rv); !zero {
+			return true
+		}
+	}
+	return false
+}
+
+func sameTenant(value interface{}, tenantID int64) bool {
+	switch v := value.(type) {
+	case int64:
+		return v == tenantID
+	case *int64:
+		return v != nil && *v == tenantID
+	default:
+		return false
+	}
+}
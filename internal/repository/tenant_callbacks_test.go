@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	contextWrapper "github.com/shahbaz275817/prismo/pkg/context"
+)
+
+type tenantRow struct {
+	RowID    int64 `gorm:"primaryKey"`
+	TenantID int64
+	Name     string
+}
+
+type sharedTenantRow struct {
+	RowID    int64  `gorm:"primaryKey"`
+	TenantID *int64 `tenant:"shared"`
+}
+
+type unscopedTenantRow struct {
+	RowID    int64 `gorm:"primaryKey"`
+	TenantID int64 `tenant:"unscoped"`
+}
+
+func tenantContext(tenantID int64) context.Context {
+	return contextWrapper.WithTenantID(context.Background(), tenantID)
+}
+
+func TestTenantCallbacks_QueryIsScopedToTenant(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterTenantCallbacks(db))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tenant_rows" WHERE "tenant_rows"."row_id" = $1 AND "tenant_rows"."tenant_id" = $2 ORDER BY "tenant_rows"."row_id" LIMIT $3`)).
+		WithArgs(int64(5), int64(2), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "tenant_id", "name"}))
+
+	var row tenantRow
+	err := db.WithContext(tenantContext(2)).First(&row, &tenantRow{RowID: 5}).Error
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantCallbacks_SharedRowsAreVisibleToEveryTenant(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterTenantCallbacks(db))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "shared_tenant_rows" WHERE ("shared_tenant_rows"."tenant_id" = $1 OR "shared_tenant_rows"."tenant_id" IS NULL)`)).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "tenant_id"}).AddRow(1, nil))
+
+	var rows []sharedTenantRow
+	err := db.WithContext(tenantContext(2)).Find(&rows).Error
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantCallbacks_CreateStampsTenant(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterTenantCallbacks(db))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tenant_rows" ("tenant_id","name") VALUES ($1,$2) RETURNING "row_id"`)).
+		WithArgs(int64(2), "a").
+		WillReturnRows(sqlmock.NewRows([]string{"row_id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	row := tenantRow{Name: "a"}
+	err := db.WithContext(tenantContext(2)).Create(&row).Error
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), row.TenantID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantCallbacks_RejectsWritesForAnotherTenant(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterTenantCallbacks(db))
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	err := db.WithContext(tenantContext(2)).Create(&tenantRow{TenantID: 3, Name: "a"}).Error
+
+	assert.ErrorIs(t, err, ErrCrossTenantWrite)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantCallbacks_RequireTenant(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterTenantCallbacks(db))
+
+	var rows []tenantRow
+	err := db.WithContext(context.Background()).Find(&rows).Error
+
+	assert.ErrorIs(t, err, ErrTenantNotSet)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantCallbacks_UnscopedModelsIgnoreTenant(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterTenantCallbacks(db))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "unscoped_tenant_rows"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "tenant_id"}).AddRow(1, 3))
+
+	var rows []unscopedTenantRow
+	err := db.WithContext(context.Background()).Find(&rows).Error
+
+	assert.NoError(t, err)
+	assert.Equal(t, []unscopedTenantRow{{RowID: 1, TenantID: 3}}, rows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantCallbacks_UpdateIsScopedBeforeAuditLoadsRows(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterTenantCallbacks(db))
+	assert.NoError(t, RegisterAuditCallbacks(db))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tenant_rows" WHERE "tenant_rows"."tenant_id" = $1 AND "row_id" = $2`)).
+		WithArgs(int64(2), int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"row_id", "tenant_id", "name"}))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tenant_rows" SET "name"=$1 WHERE "tenant_rows"."tenant_id" = $2 AND "row_id" = $3`)).
+		WithArgs("b", int64(2), int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := db.WithContext(tenantContext(2)).Model(&tenantRow{RowID: 5}).Updates(map[string]interface{}{"name": "b"}).Error
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	repository "github.com/shahbaz275817/prismo/internal/repository"
+
+	time "time"
 )
 
 // MockTransactionRepository is an autogenerated mock type for the Repository type
@@ -16,6 +18,24 @@ type MockTransactionRepository struct {
 	mock.Mock
 }
 
+// Delete provides a mock function with given fields: ctx, model
+func (_m *MockTransactionRepository) Delete(ctx context.Context, model *models.Transaction) error {
+	ret := _m.Called(ctx, model)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction) error); ok {
+		r0 = rf(ctx, model)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, query
 func (_m *MockTransactionRepository) Get(ctx context.Context, query *models.Transaction) (*models.Transaction, error) {
 	ret := _m.Called(ctx, query)
@@ -46,18 +66,48 @@ func (_m *MockTransactionRepository) Get(ctx context.Context, query *models.Tran
 	return r0, r1
 }
 
-// GetAllWithCount provides a mock function with given fields: ctx, query, request
-func (_m *MockTransactionRepository) GetAllWithCount(ctx context.Context, query *models.Transaction, request repository.FilterRequest) ([]models.Transaction, int64, error) {
+// GetAllByEventDateRange provides a mock function with given fields: ctx, from, to
+func (_m *MockTransactionRepository) GetAllByEventDateRange(ctx context.Context, from time.Time, to time.Time) ([]models.Transaction, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllByEventDateRange")
+	}
+
+	var r0 []models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]models.Transaction, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []models.Transaction); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, query, request
+func (_m *MockTransactionRepository) List(ctx context.Context, query *models.Transaction, request repository.FilterRequest) ([]models.Transaction, repository.Page, error) {
 	ret := _m.Called(ctx, query, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllWithCount")
+		panic("no return value specified for List")
 	}
 
 	var r0 []models.Transaction
-	var r1 int64
+	var r1 repository.Page
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction, repository.FilterRequest) ([]models.Transaction, int64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction, repository.FilterRequest) ([]models.Transaction, repository.Page, error)); ok {
 		return rf(ctx, query, request)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction, repository.FilterRequest) []models.Transaction); ok {
@@ -68,10 +118,10 @@ func (_m *MockTransactionRepository) GetAllWithCount(ctx context.Context, query
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *models.Transaction, repository.FilterRequest) int64); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Transaction, repository.FilterRequest) repository.Page); ok {
 		r1 = rf(ctx, query, request)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(repository.Page)
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, *models.Transaction, repository.FilterRequest) error); ok {
@@ -83,9 +133,9 @@ func (_m *MockTransactionRepository) GetAllWithCount(ctx context.Context, query
 	return r0, r1, r2
 }
 
-// Save provides a mock function with given fields: ctx, query
-func (_m *MockTransactionRepository) Save(ctx context.Context, query *models.Transaction) error {
-	ret := _m.Called(ctx, query)
+// Save provides a mock function with given fields: ctx, model
+func (_m *MockTransactionRepository) Save(ctx context.Context, model *models.Transaction) error {
+	ret := _m.Called(ctx, model)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
@@ -93,7 +143,7 @@ func (_m *MockTransactionRepository) Save(ctx context.Context, query *models.Tra
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction) error); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, model)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -119,17 +169,17 @@ func (_m *MockTransactionRepository) Transact(ctx context.Context, f func(contex
 	return r0
 }
 
-// Update provides a mock function with given fields: ctx, query, update
-func (_m *MockTransactionRepository) Update(ctx context.Context, query *models.Transaction, update *models.Transaction) error {
-	ret := _m.Called(ctx, query, update)
+// Update provides a mock function with given fields: ctx, model, update
+func (_m *MockTransactionRepository) Update(ctx context.Context, model *models.Transaction, update interface{}) error {
+	ret := _m.Called(ctx, model, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction, *models.Transaction) error); ok {
-		r0 = rf(ctx, query, update)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction, interface{}) error); ok {
+		r0 = rf(ctx, model, update)
 	} else {
 		r0 = ret.Error(0)
 	}
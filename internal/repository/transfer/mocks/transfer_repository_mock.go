@@ -0,0 +1,95 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTransferRepository is an autogenerated mock type for the Repository type
+type MockTransferRepository struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, query
+func (_m *MockTransferRepository) Get(ctx context.Context, query *models.Transfer) (*models.Transfer, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Transfer) (*models.Transfer, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Transfer) *models.Transfer); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Transfer) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, _a1
+func (_m *MockTransferRepository) Save(ctx context.Context, _a1 *models.Transfer) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Transfer) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Transact provides a mock function with given fields: ctx, f
+func (_m *MockTransferRepository) Transact(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockTransferRepository creates a new instance of MockTransferRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransferRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransferRepository {
+	mock := &MockTransferRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
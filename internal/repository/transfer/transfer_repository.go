@@ -0,0 +1,58 @@
+package transfer
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+type Repository interface {
+	Get(ctx context.Context, query *models.Transfer) (*models.Transfer, error)
+	Save(ctx context.Context, transfer *models.Transfer) error
+	Transact(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type transferRepository struct {
+	dB repository.Accessor
+}
+
+func NewTransferRepository(accessor repository.Accessor) Repository {
+	return &transferRepository{
+		dB: accessor,
+	}
+}
+
+func (repo *transferRepository) Get(ctx context.Context, query *models.Transfer) (*models.Transfer, error) {
+	var transfer models.Transfer
+
+	err := repo.dB.Read(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).First(&transfer, query).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, repository.MapError(err)
+	}
+	return &transfer, nil
+}
+
+func (repo *transferRepository) Save(ctx context.Context, transfer *models.Transfer) error {
+	err := repo.dB.Transact(ctx, func(ctx context.Context) error {
+		return repository.GetTx(ctx).Create(transfer).Error
+	})
+
+	if err != nil {
+		return repository.MapError(err)
+	}
+	return nil
+}
+
+func (repo *transferRepository) Transact(ctx context.Context, f func(ctx context.Context) error) error {
+	return repo.dB.Transact(ctx, f)
+}
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.14.1. DO NOT EDIT.
+// Code generated by mockery v2.38.0. DO NOT EDIT.
 
 package mocks
 
@@ -7,18 +7,46 @@ import (
 
 	models "github.com/shahbaz275817/prismo/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/shahbaz275817/prismo/internal/repository"
 )
 
-// MockUserRepository is an autogenerated mock type for the MockUserRepository type
+// MockUserRepository is an autogenerated mock type for the Repository type
 type MockUserRepository struct {
 	mock.Mock
 }
 
+// Delete provides a mock function with given fields: ctx, model
+func (_m *MockUserRepository) Delete(ctx context.Context, model *models.User) error {
+	ret := _m.Called(ctx, model)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, model)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, query
 func (_m *MockUserRepository) Get(ctx context.Context, query *models.User) (*models.User, error) {
 	ret := _m.Called(ctx, query)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
 	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) (*models.User, error)); ok {
+		return rf(ctx, query)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *models.User) *models.User); ok {
 		r0 = rf(ctx, query)
 	} else {
@@ -27,7 +55,6 @@ func (_m *MockUserRepository) Get(ctx context.Context, query *models.User) (*mod
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *models.User) error); ok {
 		r1 = rf(ctx, query)
 	} else {
@@ -37,36 +64,54 @@ func (_m *MockUserRepository) Get(ctx context.Context, query *models.User) (*mod
 	return r0, r1
 }
 
-// GetWithHubs provides a mock function with given fields: ctx, query
-func (_m *MockUserRepository) GetWithHubs(ctx context.Context, query *models.User) (*models.User, error) {
-	ret := _m.Called(ctx, query)
+// List provides a mock function with given fields: ctx, query, request
+func (_m *MockUserRepository) List(ctx context.Context, query *models.User, request repository.FilterRequest) ([]models.User, repository.Page, error) {
+	ret := _m.Called(ctx, query, request)
 
-	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(context.Context, *models.User) *models.User); ok {
-		r0 = rf(ctx, query)
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []models.User
+	var r1 repository.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, repository.FilterRequest) ([]models.User, repository.Page, error)); ok {
+		return rf(ctx, query, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, repository.FilterRequest) []models.User); ok {
+		r0 = rf(ctx, query, request)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.User)
+			r0 = ret.Get(0).([]models.User)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *models.User) error); ok {
-		r1 = rf(ctx, query)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, repository.FilterRequest) repository.Page); ok {
+		r1 = rf(ctx, query, request)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(repository.Page)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, *models.User, repository.FilterRequest) error); ok {
+		r2 = rf(ctx, query, request)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Save provides a mock function with given fields: ctx, query
-func (_m *MockUserRepository) Save(ctx context.Context, query *models.User) error {
-	ret := _m.Called(ctx, query)
+// Save provides a mock function with given fields: ctx, model
+func (_m *MockUserRepository) Save(ctx context.Context, model *models.User) error {
+	ret := _m.Called(ctx, model)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
-		r0 = rf(ctx, query)
+		r0 = rf(ctx, model)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -74,13 +119,17 @@ func (_m *MockUserRepository) Save(ctx context.Context, query *models.User) erro
 	return r0
 }
 
-// Update provides a mock function with given fields: ctx, query, update
-func (_m *MockUserRepository) Update(ctx context.Context, query *models.User, update map[string]interface{}) error {
-	ret := _m.Called(ctx, query, update)
+// Transact provides a mock function with given fields: ctx, f
+func (_m *MockUserRepository) Transact(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transact")
+	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.User, map[string]interface{}) error); ok {
-		r0 = rf(ctx, query, update)
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -88,13 +137,30 @@ func (_m *MockUserRepository) Update(ctx context.Context, query *models.User, up
 	return r0
 }
 
-type mockConstructorTestingTNewMockUserRepository interface {
-	mock.TestingT
-	Cleanup(func())
+// Update provides a mock function with given fields: ctx, model, update
+func (_m *MockUserRepository) Update(ctx context.Context, model *models.User, update interface{}) error {
+	ret := _m.Called(ctx, model, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, interface{}) error); ok {
+		r0 = rf(ctx, model, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // NewMockUserRepository creates a new instance of MockUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewMockUserRepository(t mockConstructorTestingTNewMockUserRepository) *MockUserRepository {
+// The first argument is typically a *testing.T value.
+func NewMockUserRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserRepository {
 	mock := &MockUserRepository{}
 	mock.Mock.Test(t)
 
@@ -21,7 +21,7 @@ type UserRepositoryTestSuit struct {
 
 func (suite *UserRepositoryTestSuit) SetupSuite() {
 	config.Load()
-	dbAccessor, err := repository.NewAccessor(config.DB())
+	dbAccessor, err := repository.NewAccessor(config.DB(), nil)
 	if err != nil {
 		panic(any("error creating db accessor in consumer repository"))
 	}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const (
+	versionTag        = "lock"
+	versionOptimistic = "optimistic"
+	versionCheckedKey = "version:checked"
+)
+
+// ErrVersionConflict is returned by updates made with a version the row no longer has.
+var ErrVersionConflict = errors.NewConflictError("version_conflict", &errors.ErrDetails{
+	Message: "the record was changed by someone else, reload it and try again",
+})
+
+// RegisterVersionCallbacks adds optimistic concurrency control to models with a field tagged lock:"optimistic".
+// Every update of such a model increments its version. Updates made through Model(&m) with a non zero version only
+// apply while the row still has that version, fail with ErrVersionConflict otherwise, and leave m with the new
+// version.
+//
+// It must be registered before RegisterAuditCallbacks so rejected updates are not audited.
+func RegisterVersionCallbacks(db *gorm.DB) error {
+	callbacks := []func() error{
+		func() error {
+			return db.Callback().Update().Before("gorm:update").Register("version:update", versionUpdate)
+		},
+		func() error {
+			return db.Callback().Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").Register("version:after_update", versionAfterUpdate)
+		},
+	}
+
+	for _, register := range callbacks {
+		if err := register(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func versionField(db *gorm.DB) *schema.Field {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil {
+		return nil
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.Tag.Get(versionTag) == versionOptimistic {
+			return field
+		}
+	}
+	return nil
+}
+
+func versionUpdate(db *gorm.DB) {
+	field := versionField(db)
+	if field == nil {
+		return
+	}
+	stmt := db.Statement
+	if _, ok := stmt.Clauses["SET"]; ok {
+		return
+	}
+
+	if rv := reflect.Indirect(stmt.ReflectValue); rv.Kind() == reflect.Struct {
+		if expected, zero := field.ValueOf(stmt.Context, rv); !zero {
+			column := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+			stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.Eq{Column: column, Value: expected}}})
+			db.InstanceSet(versionCheckedKey, true)
+		}
+	}
+
+	// the version is set by the increment below, whatever the caller passed
+	assignments := callbacks.ConvertToAssignments(stmt)
+	set := make(clause.Set, 0, len(assignments)+1)
+	for _, assignment := range assignments {
+		if assignment.Column.Name != field.DBName {
+			set = append(set, assignment)
+		}
+	}
+	if len(set) == 0 {
+		return
+	}
+	set = append(set, clause.Assignment{
+		Column: clause.Column{Name: field.DBName},
+		Value:  gorm.Expr("? + 1", clause.Column{Name: field.DBName}),
+	})
+	stmt.AddClause(set)
+}
+
+func versionAfterUpdate(db *gorm.DB) {
+	field := versionField(db)
+	if field == nil {
+		return
+	}
+	if checked, _ := db.InstanceGet(versionCheckedKey); checked != true {
+		return
+	}
+	if db.RowsAffected == 0 {
+		_ = db.AddError(ErrVersionConflict)
+		return
+	}
+
+	rv := reflect.Indirect(db.Statement.ReflectValue)
+	version, _ := field.ValueOf(db.Statement.Context, rv)
+	if v, ok := version.(int64); ok {
+		_ = db.AddError(field.Set(db.Statement.Context, rv, v+1))
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type versionedRow struct {
+	RowID   int64 `gorm:"primaryKey"`
+	Name    string
+	Version int64 `lock:"optimistic"`
+}
+
+func TestVersionCallbacks_UpdateChecksAndIncrementsVersion(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterVersionCallbacks(db))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "versioned_rows" SET "name"=$1,"version"="version" + 1 WHERE "versioned_rows"."version" = $2 AND "row_id" = $3`)).
+		WithArgs("renamed", int64(3), int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	row := versionedRow{RowID: 5, Version: 3}
+	err := db.Model(&row).Updates(map[string]interface{}{"name": "renamed"}).Error
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), row.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVersionCallbacks_StaleVersionConflicts(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterVersionCallbacks(db))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "versioned_rows" SET "name"=$1,"version"="version" + 1 WHERE "versioned_rows"."version" = $2 AND "row_id" = $3`)).
+		WithArgs("renamed", int64(2), int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	row := versionedRow{RowID: 5, Version: 2}
+	err := db.Model(&row).Updates(&versionedRow{Name: "renamed"}).Error
+
+	assert.Equal(t, ErrVersionConflict, err)
+	assert.Equal(t, int64(2), row.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVersionCallbacks_UpdateWithoutVersionOnlyIncrements(t *testing.T) {
+	db, mock := newMockDB(t)
+	assert.NoError(t, RegisterVersionCallbacks(db))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "versioned_rows" SET "name"=$1,"version"="version" + 1 WHERE "row_id" = $2`)).
+		WithArgs("renamed", int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := db.Model(&versionedRow{RowID: 5}).Updates(&versionedRow{Name: "renamed", Version: 9}).Error
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,8 +3,10 @@ package responder
 import (
 	"context"
 	"encoding/json"
+	defaultErrors "errors"
 	"net/http"
 
+	"github.com/shahbaz275817/prismo/constants/errcodes"
 	contextWrapper "github.com/shahbaz275817/prismo/pkg/context"
 	"github.com/shahbaz275817/prismo/pkg/errors"
 	"github.com/shahbaz275817/prismo/pkg/logger"
@@ -51,6 +53,17 @@ func WriteError(w http.ResponseWriter, r *http.Request, err error) {
 	}
 }
 
+// WriteServiceError writes the error a service returned: domain errors of pkg/errors with their own status, such as the
+// conflicts and invalid references repository.MapError reports, and any other error as a 500 that does not expose it.
+func WriteServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr errors.GenericError
+	var unknownErr errors.UnknownError
+	if !defaultErrors.As(err, &domainErr) || defaultErrors.As(err, &unknownErr) {
+		err = errors.NewInternalServerError(errcodes.InternalServerError, &errors.ErrDetails{})
+	}
+	WriteError(w, r, err)
+}
+
 func WriteErrorWithData(w http.ResponseWriter, r *http.Request, err error, data interface{}) {
 	w.Header().Add("Content-Type", "application/json; utf8")
 
@@ -111,6 +124,13 @@ func WriteAnyResponse(ctx context.Context, w http.ResponseWriter, res interface{
 
 func handleError(r *http.Request, err error, data interface{}, language string) (int, *Response) {
 	l := logger.WithRequest(r)
+
+	// errors returned through nested transactions carry a stack, render the domain error they wrap
+	var domainErr errors.GenericError
+	if defaultErrors.As(err, &domainErr) {
+		err = domainErr
+	}
+
 	switch errorType := err.(type) {
 	case errors.BadRequestError:
 		l.Warnf("%v", err)
@@ -130,6 +150,21 @@ func handleError(r *http.Request, err error, data interface{}, language string)
 	case *errors.ValidationError:
 		l.Warnf("%v", err)
 		return http.StatusUnprocessableEntity, newErrorResponse(errorType, "Unprocessable Entity", data, language)
+	case errors.ValidationError:
+		l.Warnf("%v", err)
+		return http.StatusUnprocessableEntity, newErrorResponse(errorType, "Unprocessable Entity", data, language)
+	case errors.ConflictError:
+		l.Warnf("%v", err)
+		return http.StatusConflict, newErrorResponse(errorType, "Conflict", data, language)
+	case errors.PreconditionFailedError:
+		l.Warnf("%v", err)
+		return http.StatusPreconditionFailed, newErrorResponse(errorType, "Precondition Failed", data, language)
+	case errors.RetryableError:
+		l.Warnf("%v", err)
+		return http.StatusServiceUnavailable, newErrorResponse(errorType, "Service Unavailable", data, language)
+	case errors.TimeoutError:
+		l.Warnf("%v", err)
+		return http.StatusGatewayTimeout, newErrorResponse(errorType, "Gateway Timeout", data, language)
 	case errors.TooManyRequestsError:
 		l.Warnf("%v", err)
 		return http.StatusTooManyRequests, newErrorResponse(errorType, "Too Many Requests", data, language)
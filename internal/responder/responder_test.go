@@ -237,6 +237,50 @@ func TestWriteError(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Return conflict if the error is of type conflict",
+			args: args{
+				w:   httptest.NewRecorder(),
+				r:   req,
+				err: errors.NewConflictError("duplicate_record", &errors.ErrDetails{Message: "already exists"}),
+			},
+			want: wantRes{
+				statusCode: http.StatusConflict,
+				res: &Response{
+					Success: false,
+					Data:    nil,
+					Errors: []ErrorItem{
+						{
+							Message:      "already exists",
+							Code:         "duplicate_record",
+							MessageTitle: "Conflict",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Return the status of a domain error wrapped with a stack",
+			args: args{
+				w:   httptest.NewRecorder(),
+				r:   req,
+				err: errors.WithStack(errors.NewRetryableError("concurrent_update", nil)),
+			},
+			want: wantRes{
+				statusCode: http.StatusServiceUnavailable,
+				res: &Response{
+					Success: false,
+					Data:    nil,
+					Errors: []ErrorItem{
+						{
+							Message:      "concurrent_update",
+							Code:         "concurrent_update",
+							MessageTitle: "Service Unavailable",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -254,6 +298,35 @@ func TestWriteError(t *testing.T) {
 	}
 }
 
+func TestWriteServiceError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+		code       string
+	}{
+		{"passes conflicts through", errors.NewConflictError("duplicate_record", &errors.ErrDetails{}), http.StatusConflict, "duplicate_record"},
+		{"passes invalid references through", errors.NewStatusUnprocessableEntity("invalid_reference", &errors.ErrDetails{}), http.StatusUnprocessableEntity, "invalid_reference"},
+		{"passes validation errors through", errors.NewValidationError("constraint_violation", &errors.ErrDetails{}), http.StatusUnprocessableEntity, "constraint_violation"},
+		{"passes retryable errors through", errors.NewRetryableError("concurrent_update", &errors.ErrDetails{}), http.StatusServiceUnavailable, "concurrent_update"},
+		{"passes wrapped domain errors through", errors.WithStack(errors.NewNotFoundError("account_not_found", &errors.ErrDetails{})), http.StatusNotFound, "account_not_found"},
+		{"hides unknown database errors", errors.NewUnknownErrorWithDetails("database_error", &errors.ErrDetails{Message: "database error"}), http.StatusInternalServerError, "INTERNAL_SERVER_ERROR"},
+		{"hides other errors", errors.New("pq: relation does not exist"), http.StatusInternalServerError, "INTERNAL_SERVER_ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			WriteServiceError(w, httptest.NewRequest(http.MethodGet, "/test", nil), tt.err)
+
+			var res Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, tt.code, res.Errors[0].Code)
+		})
+	}
+}
+
 func TestWriteResponse(t *testing.T) {
 	type args struct {
 		w            *httptest.ResponseRecorder
@@ -10,7 +10,10 @@ import (
 
 type Service interface {
 	Get(ctx context.Context, query *models.Account) (Account *models.Account, err error)
-	Create(ctx context.Context, trx models.Account) error
+	// GetForShare locks the account against updates, such as its erasure, until the surrounding transaction ends, so
+	// it must run in one. Writes that depend on the state of the account read it with GetForShare.
+	GetForShare(ctx context.Context, query *models.Account) (*models.Account, error)
+	Create(ctx context.Context, acc *models.Account) error
 	Update(ctx context.Context, Account *models.Account, update *models.Account) error
 }
 
@@ -28,9 +31,14 @@ func (service *accountService) Get(ctx context.Context, query *models.Account) (
 	return service.repo.Get(ctx, query)
 }
 
-func (service *accountService) Create(ctx context.Context, acc models.Account) error {
+func (service *accountService) GetForShare(ctx context.Context, query *models.Account) (*models.Account, error) {
+	return service.repo.GetForShare(ctx, query)
+}
+
+// Create saves acc, setting its AccountID.
+func (service *accountService) Create(ctx context.Context, acc *models.Account) error {
 
-	err := service.repo.Save(ctx, &acc)
+	err := service.repo.Save(ctx, acc)
 	if err != nil {
 		logger.WithContext(ctx).Errorf("Error while saving Account Error: %s", err.Error())
 		return err
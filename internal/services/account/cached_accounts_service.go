@@ -0,0 +1,115 @@
+package account
+
+import (
+	"context"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
+	contextWrapper "github.com/shahbaz275817/prismo/pkg/context"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+// Invalidator drops cached accounts, for writers that change accounts without going through the Service.
+type Invalidator interface {
+	Invalidate(ctx context.Context, accountID int64)
+}
+
+// CachedService is a Service serving lookups of accounts by id from a cache.
+type CachedService interface {
+	Service
+	Invalidator
+}
+
+type cachedAccountService struct {
+	Service
+	cache inmemory.InMemCache
+}
+
+// NewCachedAccountService serves lookups of accounts by id from cache, which must be built with CacheLoader for
+// service. Other lookups and writes go to service, and creates and updates drop the account from the cache once
+// committed.
+func NewCachedAccountService(service Service, cache inmemory.InMemCache) CachedService {
+	return &cachedAccountService{
+		Service: service,
+		cache:   cache,
+	}
+}
+
+// CacheLoader loads accounts missing from the cache built for NewCachedAccountService. Accounts that do not exist are
+// reported as inmemory.ErrNotFound, so they are cached for the cache's negative TTL.
+func CacheLoader(service Service) inmemory.LoaderFunc {
+	return func(key interface{}) (interface{}, error) {
+		tenantID, id, err := inmemory.ParseTenantKey(key)
+		if err != nil {
+			return nil, err
+		}
+		// loads follow invalidations, so they read the primary to not cache what the replica has not seen yet
+		ctx := repository.WithPrimaryReads(contextWrapper.WithTenantID(context.Background(), tenantID))
+		acc, err := service.Get(ctx, &models.Account{AccountID: id})
+		if err != nil {
+			return nil, err
+		}
+		if acc == nil {
+			return nil, inmemory.ErrNotFound
+		}
+		return acc, nil
+	}
+}
+
+func (service *cachedAccountService) Get(ctx context.Context, query *models.Account) (*models.Account, error) {
+	// reads in a transaction decide what it writes, such as whether an account is erased, so they skip the cache
+	key, ok := service.cacheKey(ctx, query.AccountID)
+	if !ok || *query != (models.Account{AccountID: query.AccountID}) || repository.InTransaction(ctx) {
+		return service.Service.Get(ctx, query)
+	}
+
+	val, err := service.cache.LoadValue(ctx, key)
+	if errors.Is(err, inmemory.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// callers get a copy so they cannot change the cached account
+	acc := *val.(*models.Account)
+	return &acc, nil
+}
+
+func (service *cachedAccountService) Create(ctx context.Context, acc *models.Account) error {
+	if err := service.Service.Create(ctx, acc); err != nil {
+		return err
+	}
+	// a lookup of the id before the account existed may have cached it as missing
+	service.invalidateAfterCommit(ctx, acc.AccountID)
+	return nil
+}
+
+func (service *cachedAccountService) Update(ctx context.Context, account *models.Account, update *models.Account) error {
+	err := service.Service.Update(ctx, account, update)
+	// the account may have changed even when the update failed, e.g. on a version conflict
+	service.invalidateAfterCommit(ctx, account.AccountID)
+	return err
+}
+
+func (service *cachedAccountService) Invalidate(ctx context.Context, accountID int64) {
+	if key, ok := service.cacheKey(ctx, accountID); ok {
+		service.cache.RemoveKey(ctx, key)
+	}
+}
+
+// invalidateAfterCommit drops the account once the transaction of ctx commits, so that reloads cannot cache the
+// account as it was before.
+func (service *cachedAccountService) invalidateAfterCommit(ctx context.Context, accountID int64) {
+	repository.AfterCommit(ctx, func() { service.Invalidate(ctx, accountID) })
+}
+
+// cacheKey identifies a cached account. The tenant is part of the key because the loader has no request context. It
+// reports false for lookups that cannot be cached: those without an account id or a tenant.
+func (service *cachedAccountService) cacheKey(ctx context.Context, accountID int64) (string, bool) {
+	tenantID, ok := contextWrapper.TenantID(ctx)
+	if !ok || accountID == 0 {
+		return "", false
+	}
+	return inmemory.TenantKey(tenantID, accountID), true
+}
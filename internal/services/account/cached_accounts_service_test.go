@@ -0,0 +1,179 @@
+package account
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/services/account/mocks"
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
+	contextWrapper "github.com/shahbaz275817/prismo/pkg/context"
+)
+
+func newTestCachedAccountService(t *testing.T) (CachedService, *mocks.MockAccountService) {
+	inner := &mocks.MockAccountService{}
+	cache, err := inmemory.NewInMemCache(inmemory.CacheConfig{
+		LoaderFunc:         CacheLoader(inner),
+		TTLSeconds:         60,
+		NegativeTTLSeconds: 5,
+		Size:               10,
+		Name:               "accounts",
+	})
+	assert.NoError(t, err)
+	t.Cleanup(func() { inner.AssertExpectations(t) })
+	return NewCachedAccountService(inner, cache), inner
+}
+
+func TestCachedAccountService_Get(t *testing.T) {
+	ctx := contextWrapper.WithTenantID(context.Background(), 1)
+	service, inner := newTestCachedAccountService(t)
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(&models.Account{AccountID: 7, DocumentNumber: "123"}, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		acc, err := service.Get(ctx, &models.Account{AccountID: 7})
+		assert.NoError(t, err)
+		assert.Equal(t, "123", acc.DocumentNumber)
+		// changing the returned account does not change the cached one
+		acc.DocumentNumber = "changed"
+	}
+}
+
+func TestCachedAccountService_Get_CachesMisses(t *testing.T) {
+	ctx := contextWrapper.WithTenantID(context.Background(), 1)
+	service, inner := newTestCachedAccountService(t)
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(nil, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		acc, err := service.Get(ctx, &models.Account{AccountID: 7})
+		assert.NoError(t, err)
+		assert.Nil(t, acc)
+	}
+}
+
+func TestCachedAccountService_Get_BypassesCache(t *testing.T) {
+	service, inner := newTestCachedAccountService(t)
+	byDocument := &models.Account{DocumentNumber: "123"}
+	inner.On("Get", mock.Anything, byDocument).Return(&models.Account{AccountID: 7}, nil).Twice()
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(&models.Account{AccountID: 7}, nil).Twice()
+
+	tenantCtx := contextWrapper.WithTenantID(context.Background(), 1)
+	for i := 0; i < 2; i++ {
+		// lookups by anything but the id
+		_, err := service.Get(tenantCtx, byDocument)
+		assert.NoError(t, err)
+		// lookups without a tenant
+		_, err = service.Get(context.Background(), &models.Account{AccountID: 7})
+		assert.NoError(t, err)
+	}
+}
+
+func TestCachedAccountService_Get_ReadsInTransactionSkipCache(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer sqlDB.Close()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	ctx := contextWrapper.WithTenantID(context.Background(), 1)
+	service, inner := newTestCachedAccountService(t)
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(&models.Account{AccountID: 7}, nil).Once()
+	erasedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(&models.Account{AccountID: 7, ErasedAt: &erasedAt}, nil).Once()
+
+	_, err = service.Get(ctx, &models.Account{AccountID: 7})
+	assert.NoError(t, err)
+
+	err = repository.NewAccessorFromDB(db, 1000).Transact(ctx, func(ctx context.Context) error {
+		acc, err := service.Get(ctx, &models.Account{AccountID: 7})
+		assert.NoError(t, err)
+		assert.True(t, acc.Erased())
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestCachedAccountService_UpdateInvalidates(t *testing.T) {
+	ctx := contextWrapper.WithTenantID(context.Background(), 1)
+	service, inner := newTestCachedAccountService(t)
+	acc := &models.Account{AccountID: 7, Version: 1}
+	update := &models.Account{DocumentNumber: "456"}
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(&models.Account{AccountID: 7, DocumentNumber: "123", Version: 1}, nil).Once()
+	inner.On("Update", mock.Anything, acc, update).Return(nil).Once()
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(&models.Account{AccountID: 7, DocumentNumber: "456", Version: 2}, nil).Once()
+
+	before, _ := service.Get(ctx, &models.Account{AccountID: 7})
+	assert.NoError(t, service.Update(ctx, acc, update))
+	after, _ := service.Get(ctx, &models.Account{AccountID: 7})
+
+	assert.Equal(t, int64(1), before.Version)
+	assert.Equal(t, int64(2), after.Version)
+	assert.Equal(t, "456", after.DocumentNumber)
+}
+
+func TestCachedAccountService_UpdateInTransactionInvalidatesAfterCommit(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer sqlDB.Close()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	ctx := contextWrapper.WithTenantID(context.Background(), 1)
+	service, inner := newTestCachedAccountService(t)
+	acc := &models.Account{AccountID: 7, Version: 1}
+	update := &models.Account{DocumentNumber: "456"}
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(&models.Account{AccountID: 7, DocumentNumber: "123", Version: 1}, nil).Once()
+	inner.On("Update", mock.Anything, acc, update).Return(nil).Once()
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(&models.Account{AccountID: 7, DocumentNumber: "456", Version: 2}, nil).Once()
+
+	_, err = service.Get(ctx, &models.Account{AccountID: 7})
+	assert.NoError(t, err)
+	err = repository.NewAccessorFromDB(db, 1000).Transact(ctx, func(txCtx context.Context) error {
+		assert.NoError(t, service.Update(txCtx, acc, update))
+		// until the commit, reloads would read the account as it was, so the cached one is kept
+		cached, err := service.Get(ctx, &models.Account{AccountID: 7})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), cached.Version)
+		return nil
+	})
+	assert.NoError(t, err)
+	after, err := service.Get(ctx, &models.Account{AccountID: 7})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), after.Version)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestCachedAccountService_CreateInvalidatesCachedMiss(t *testing.T) {
+	ctx := contextWrapper.WithTenantID(context.Background(), 1)
+	service, inner := newTestCachedAccountService(t)
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(nil, nil).Once()
+	inner.On("Create", mock.Anything, &models.Account{DocumentNumber: "123"}).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Account).AccountID = 7
+	}).Return(nil).Once()
+	inner.On("Get", mock.Anything, &models.Account{AccountID: 7}).Return(&models.Account{AccountID: 7, DocumentNumber: "123"}, nil).Once()
+
+	before, err := service.Get(ctx, &models.Account{AccountID: 7})
+	assert.NoError(t, err)
+	assert.Nil(t, before)
+
+	acc := &models.Account{DocumentNumber: "123"}
+	assert.NoError(t, service.Create(ctx, acc))
+	after, err := service.Get(ctx, &models.Account{AccountID: 7})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), acc.AccountID)
+	assert.Equal(t, "123", after.DocumentNumber)
+}
@@ -14,17 +14,17 @@ type MockAccountService struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, trx
-func (_m *MockAccountService) Create(ctx context.Context, trx models.Account) error {
-	ret := _m.Called(ctx, trx)
+// Create provides a mock function with given fields: ctx, acc
+func (_m *MockAccountService) Create(ctx context.Context, acc *models.Account) error {
+	ret := _m.Called(ctx, acc)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.Account) error); ok {
-		r0 = rf(ctx, trx)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account) error); ok {
+		r0 = rf(ctx, acc)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -62,6 +62,36 @@ func (_m *MockAccountService) Get(ctx context.Context, query *models.Account) (*
 	return r0, r1
 }
 
+// GetForShare provides a mock function with given fields: ctx, query
+func (_m *MockAccountService) GetForShare(ctx context.Context, query *models.Account) (*models.Account, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForShare")
+	}
+
+	var r0 *models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account) (*models.Account, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account) *models.Account); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Account) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, Account, update
 func (_m *MockAccountService) Update(ctx context.Context, Account *models.Account, update *models.Account) error {
 	ret := _m.Called(ctx, Account, update)
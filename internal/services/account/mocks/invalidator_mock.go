@@ -0,0 +1,33 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockInvalidator is an autogenerated mock type for the Invalidator type
+type MockInvalidator struct {
+	mock.Mock
+}
+
+// Invalidate provides a mock function with given fields: ctx, accountID
+func (_m *MockInvalidator) Invalidate(ctx context.Context, accountID int64) {
+	_m.Called(ctx, accountID)
+}
+
+// NewMockInvalidator creates a new instance of MockInvalidator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInvalidator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInvalidator {
+	mock := &MockInvalidator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,193 @@
+package apiclient
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/repository/apiclient"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const secretBytes = 32
+
+type Service interface {
+	// Authenticate returns the enabled client with the given name and secret, or nil when the credentials are wrong.
+	Authenticate(ctx context.Context, name, secret string) (*models.APIClient, error)
+	// Create registers a client and returns it with its secret, which is not stored and cannot be shown again.
+	Create(ctx context.Context, name string, role auth.Role, tenantID int64) (*models.APIClient, string, error)
+	// RotateSecret issues a new secret for the client. The current secret keeps working for overlap.
+	RotateSecret(ctx context.Context, name string, overlap time.Duration) (string, error)
+	SetEnabled(ctx context.Context, name string, enabled bool) error
+}
+
+type apiClientService struct {
+	repo     apiclient.Repository
+	cost     int
+	now      func() time.Time
+	verified *verifiedSecrets
+
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+}
+
+// NewAPIClientService returns a service that skips bcrypt for secrets it verified within verifiedTTL, or on every
+// request when verifiedTTL is zero. Rotating or disabling a client through the service forgets its secrets at once;
+// changes made by other instances take effect once verifiedTTL has passed.
+func NewAPIClientService(repo apiclient.Repository, verifiedTTL time.Duration) Service {
+	return &apiClientService{
+		repo:     repo,
+		cost:     bcrypt.DefaultCost,
+		now:      time.Now,
+		verified: newVerifiedSecrets(verifiedTTL),
+	}
+}
+
+func (service *apiClientService) Authenticate(ctx context.Context, name, secret string) (*models.APIClient, error) {
+	digest := secretDigest(sha256.Sum256([]byte(secret)))
+	if client := service.verified.get(name, digest, service.now()); client != nil {
+		return client, nil
+	}
+	generation := service.verified.generation(name)
+
+	// a lagging replica could still return a disabled client or a rotated out secret
+	client, err := service.repo.Get(repository.WithPrimaryReads(ctx), &models.APIClient{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		// a hash is still compared so unknown names take as long to reject as wrong secrets
+		_ = bcrypt.CompareHashAndPassword(service.getDummyHash(), []byte(secret))
+		return nil, nil
+	}
+
+	var notAfter *time.Time
+	valid := bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(secret)) == nil
+	if !valid && client.PreviousSecretHash != nil && client.PreviousSecretExpiresAt != nil &&
+		service.now().UTC().Before(*client.PreviousSecretExpiresAt) {
+		valid = bcrypt.CompareHashAndPassword([]byte(*client.PreviousSecretHash), []byte(secret)) == nil
+		notAfter = client.PreviousSecretExpiresAt
+	}
+
+	if !valid || !client.Enabled {
+		return nil, nil
+	}
+	service.verified.put(client, digest, generation, service.now(), notAfter)
+	return client, nil
+}
+
+func (service *apiClientService) Create(ctx context.Context, name string, role auth.Role, tenantID int64) (*models.APIClient, string, error) {
+	if !role.Valid() {
+		return nil, "", errors.NewStatusUnprocessableEntity("invalid_role", &errors.ErrDetails{
+			Message: "unknown role " + string(role),
+		})
+	}
+
+	existing, err := service.repo.Get(repository.WithPrimaryReads(ctx), &models.APIClient{Name: name})
+	if err != nil {
+		return nil, "", err
+	}
+	if existing != nil {
+		return nil, "", errors.NewStatusUnprocessableEntity("client_already_exists", &errors.ErrDetails{
+			Message: "an api client with this name already exists",
+		})
+	}
+
+	secret, hash, err := service.newSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	client := &models.APIClient{
+		TenantID:   tenantID,
+		Name:       name,
+		Role:       string(role),
+		SecretHash: hash,
+		Enabled:    true,
+	}
+	if err := service.repo.Save(ctx, client); err != nil {
+		return nil, "", err
+	}
+	return client, secret, nil
+}
+
+func (service *apiClientService) RotateSecret(ctx context.Context, name string, overlap time.Duration) (string, error) {
+	client, err := service.get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	secret, hash, err := service.newSecret()
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt := service.now().UTC().Add(overlap)
+	err = service.repo.Update(ctx, client, map[string]interface{}{
+		"secret_hash":                hash,
+		"previous_secret_hash":       client.SecretHash,
+		"previous_secret_expires_at": expiresAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	service.verified.forget(name)
+	return secret, nil
+}
+
+func (service *apiClientService) SetEnabled(ctx context.Context, name string, enabled bool) error {
+	client, err := service.get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if client.Enabled == enabled {
+		return nil
+	}
+	if err := service.repo.Update(ctx, client, map[string]interface{}{"enabled": enabled}); err != nil {
+		return err
+	}
+	service.verified.forget(name)
+	return nil
+}
+
+// get reads the client from the primary, as its secret and status are about to be changed.
+func (service *apiClientService) get(ctx context.Context, name string) (*models.APIClient, error) {
+	client, err := service.repo.Get(repository.WithPrimaryReads(ctx), &models.APIClient{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, errors.NewNotFoundError("client_not_found", &errors.ErrDetails{Message: "api client not found"})
+	}
+	return client, nil
+}
+
+// newSecret returns a random secret and its bcrypt hash.
+func (service *apiClientService) newSecret() (string, string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(b)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), service.cost)
+	if err != nil {
+		return "", "", err
+	}
+	return secret, string(hash), nil
+}
+
+func (service *apiClientService) getDummyHash() []byte {
+	service.dummyHashOnce.Do(func() {
+		service.dummyHash, _ = bcrypt.GenerateFromPassword([]byte("unknown client"), service.cost)
+	})
+	return service.dummyHash
+}
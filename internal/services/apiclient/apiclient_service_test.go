@@ -0,0 +1,223 @@
+package apiclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/shahbaz275817/prismo/internal/auth"
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/apiclient/mocks"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+type APIClientServiceTestSuite struct {
+	suite.Suite
+	repo    *mocks.MockAPIClientRepository
+	service *apiClientService
+	now     time.Time
+	ctx     context.Context
+}
+
+func (suite *APIClientServiceTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.repo = &mocks.MockAPIClientRepository{}
+	suite.service = &apiClientService{
+		repo: suite.repo,
+		cost: bcrypt.MinCost,
+		now:  func() time.Time { return suite.now },
+	}
+}
+
+func (suite *APIClientServiceTestSuite) hash(secret string) string {
+	h, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.MinCost)
+	suite.Require().NoError(err)
+	return string(h)
+}
+
+func (suite *APIClientServiceTestSuite) Test_Authenticate() {
+	previous := suite.hash("old-secret")
+	valid := suite.now.Add(time.Hour)
+	expired := suite.now.Add(-time.Second)
+
+	tests := []struct {
+		name   string
+		client *models.APIClient
+		secret string
+		want   bool
+	}{
+		{
+			name:   "accepts the current secret",
+			client: &models.APIClient{Name: "portal", SecretHash: suite.hash("secret"), Enabled: true},
+			secret: "secret",
+			want:   true,
+		},
+		{
+			name:   "rejects a wrong secret",
+			client: &models.APIClient{Name: "portal", SecretHash: suite.hash("secret"), Enabled: true},
+			secret: "guess",
+		},
+		{
+			name:   "rejects an unknown client",
+			secret: "secret",
+		},
+		{
+			name:   "rejects a disabled client",
+			client: &models.APIClient{Name: "portal", SecretHash: suite.hash("secret"), Enabled: false},
+			secret: "secret",
+		},
+		{
+			name: "accepts the previous secret during the overlap",
+			client: &models.APIClient{Name: "portal", SecretHash: suite.hash("secret"), Enabled: true,
+				PreviousSecretHash: &previous, PreviousSecretExpiresAt: &valid},
+			secret: "old-secret",
+			want:   true,
+		},
+		{
+			name: "rejects the previous secret after the overlap",
+			client: &models.APIClient{Name: "portal", SecretHash: suite.hash("secret"), Enabled: true,
+				PreviousSecretHash: &previous, PreviousSecretExpiresAt: &expired},
+			secret: "old-secret",
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "portal"}).Return(tt.client, nil).Once()
+
+			got, err := suite.service.Authenticate(suite.ctx, "portal", tt.secret)
+
+			suite.NoError(err)
+			suite.Equal(tt.want, got != nil)
+			suite.repo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *APIClientServiceTestSuite) Test_Authenticate_RemembersVerifiedSecrets() {
+	suite.service.verified = newVerifiedSecrets(time.Minute)
+	client := &models.APIClient{ClientID: 1, Name: "portal", SecretHash: suite.hash("secret"), Enabled: true}
+	suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "portal"}).Return(client, nil).Times(3)
+
+	for i := 0; i < 3; i++ {
+		got, err := suite.service.Authenticate(suite.ctx, "portal", "secret")
+		suite.NoError(err)
+		suite.NotNil(got)
+	}
+
+	// a wrong secret is never remembered
+	got, err := suite.service.Authenticate(suite.ctx, "portal", "guess")
+	suite.NoError(err)
+	suite.Nil(got)
+
+	suite.now = suite.now.Add(time.Minute)
+	got, err = suite.service.Authenticate(suite.ctx, "portal", "secret")
+	suite.NoError(err)
+	suite.NotNil(got)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *APIClientServiceTestSuite) Test_Authenticate_ForgetsSecretsOfDisabledClients() {
+	suite.service.verified = newVerifiedSecrets(time.Minute)
+	client := &models.APIClient{ClientID: 1, Name: "portal", SecretHash: suite.hash("secret"), Enabled: true}
+	suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "portal"}).Return(client, nil).Once()
+
+	got, err := suite.service.Authenticate(suite.ctx, "portal", "secret")
+	suite.NoError(err)
+	suite.NotNil(got)
+
+	suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "portal"}).Return(client, nil).Once()
+	suite.repo.On("Update", suite.ctx, client, map[string]interface{}{"enabled": false}).Return(nil).Once()
+	suite.NoError(suite.service.SetEnabled(suite.ctx, "portal", false))
+
+	disabled := *client
+	disabled.Enabled = false
+	suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "portal"}).Return(&disabled, nil).Once()
+	got, err = suite.service.Authenticate(suite.ctx, "portal", "secret")
+	suite.NoError(err)
+	suite.Nil(got)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *APIClientServiceTestSuite) Test_Authenticate_RemembersPreviousSecretsUntilTheyExpire() {
+	suite.service.verified = newVerifiedSecrets(time.Minute)
+	previous := suite.hash("old-secret")
+	expiresAt := suite.now.Add(10 * time.Second)
+	client := &models.APIClient{ClientID: 1, Name: "portal", SecretHash: suite.hash("secret"), Enabled: true,
+		PreviousSecretHash: &previous, PreviousSecretExpiresAt: &expiresAt}
+	suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "portal"}).Return(client, nil).Twice()
+
+	got, err := suite.service.Authenticate(suite.ctx, "portal", "old-secret")
+	suite.NoError(err)
+	suite.NotNil(got)
+
+	suite.now = expiresAt
+	got, err = suite.service.Authenticate(suite.ctx, "portal", "old-secret")
+	suite.NoError(err)
+	suite.Nil(got)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *APIClientServiceTestSuite) Test_Create() {
+	suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "portal"}).Return(nil, nil).Once()
+	suite.repo.On("Save", suite.ctx, mock.MatchedBy(func(c *models.APIClient) bool {
+		return c.Name == "portal" && c.Role == "operator" && c.TenantID == 2 && c.Enabled
+	})).Return(nil).Once()
+
+	client, secret, err := suite.service.Create(suite.ctx, "portal", auth.RoleOperator, 2)
+
+	suite.NoError(err)
+	suite.NotEmpty(secret)
+	suite.NotContains(client.SecretHash, secret)
+	suite.NoError(bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(secret)))
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *APIClientServiceTestSuite) Test_Create_RejectsDuplicatesAndUnknownRoles() {
+	suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "portal"}).Return(&models.APIClient{Name: "portal"}, nil).Once()
+
+	_, _, err := suite.service.Create(suite.ctx, "portal", auth.RoleOperator, 2)
+	suite.IsType(errors.UnprocessableEntityError{}, err)
+
+	_, _, err = suite.service.Create(suite.ctx, "portal", "root", 2)
+	suite.IsType(errors.UnprocessableEntityError{}, err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *APIClientServiceTestSuite) Test_RotateSecret() {
+	client := &models.APIClient{ClientID: 1, Name: "portal", SecretHash: "current-hash", Enabled: true}
+	suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "portal"}).Return(client, nil).Once()
+
+	var update map[string]interface{}
+	suite.repo.On("Update", suite.ctx, client, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		update = args.Get(2).(map[string]interface{})
+	}).Once()
+
+	secret, err := suite.service.RotateSecret(suite.ctx, "portal", 24*time.Hour)
+
+	suite.NoError(err)
+	suite.Equal("current-hash", update["previous_secret_hash"])
+	suite.Equal(suite.now.Add(24*time.Hour), update["previous_secret_expires_at"])
+	suite.NoError(bcrypt.CompareHashAndPassword([]byte(update["secret_hash"].(string)), []byte(secret)))
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *APIClientServiceTestSuite) Test_SetEnabled() {
+	client := &models.APIClient{ClientID: 1, Name: "portal", Enabled: true}
+	suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "portal"}).Return(client, nil).Once()
+	suite.repo.On("Update", suite.ctx, client, map[string]interface{}{"enabled": false}).Return(nil).Once()
+
+	suite.NoError(suite.service.SetEnabled(suite.ctx, "portal", false))
+
+	suite.repo.On("Get", mock.Anything, &models.APIClient{Name: "missing"}).Return(nil, nil).Once()
+	suite.IsType(errors.NotFoundError{}, suite.service.SetEnabled(suite.ctx, "missing", false))
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func TestAPIClientServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(APIClientServiceTestSuite))
+}
@@ -0,0 +1,147 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/shahbaz275817/prismo/internal/auth"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+
+	time "time"
+)
+
+// MockAPIClientService is an autogenerated mock type for the Service type
+type MockAPIClientService struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, name, secret
+func (_m *MockAPIClientService) Authenticate(ctx context.Context, name string, secret string) (*models.APIClient, error) {
+	ret := _m.Called(ctx, name, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *models.APIClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.APIClient, error)); ok {
+		return rf(ctx, name, secret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.APIClient); ok {
+		r0 = rf(ctx, name, secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, name, role, tenantID
+func (_m *MockAPIClientService) Create(ctx context.Context, name string, role auth.Role, tenantID int64) (*models.APIClient, string, error) {
+	ret := _m.Called(ctx, name, role, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.APIClient
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.Role, int64) (*models.APIClient, string, error)); ok {
+		return rf(ctx, name, role, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.Role, int64) *models.APIClient); ok {
+		r0 = rf(ctx, name, role, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.Role, int64) string); ok {
+		r1 = rf(ctx, name, role, tenantID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, auth.Role, int64) error); ok {
+		r2 = rf(ctx, name, role, tenantID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RotateSecret provides a mock function with given fields: ctx, name, overlap
+func (_m *MockAPIClientService) RotateSecret(ctx context.Context, name string, overlap time.Duration) (string, error) {
+	ret := _m.Called(ctx, name, overlap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateSecret")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (string, error)); ok {
+		return rf(ctx, name, overlap)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) string); ok {
+		r0 = rf(ctx, name, overlap)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, name, overlap)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetEnabled provides a mock function with given fields: ctx, name, enabled
+func (_m *MockAPIClientService) SetEnabled(ctx context.Context, name string, enabled bool) error {
+	ret := _m.Called(ctx, name, enabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEnabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, name, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockAPIClientService creates a new instance of MockAPIClientService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIClientService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIClientService {
+	mock := &MockAPIClientService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+package apiclient
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+)
+
+type secretDigest [sha256.Size]byte
+
+// verifiedSecrets remembers for a short while the secrets that passed a bcrypt comparison, so that clients sending
+// basic auth with every request are not hashed every time. Entries are keyed by client name and the SHA-256 of the
+// secret, never the secret itself. A nil *verifiedSecrets remembers nothing.
+type verifiedSecrets struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]map[secretDigest]verifiedSecret
+	// generations counts the forget calls of each client, so a verification that raced with one is not stored.
+	generations map[string]uint64
+}
+
+type verifiedSecret struct {
+	client    models.APIClient
+	expiresAt time.Time
+}
+
+func newVerifiedSecrets(ttl time.Duration) *verifiedSecrets {
+	if ttl <= 0 {
+		return nil
+	}
+	return &verifiedSecrets{
+		ttl:         ttl,
+		entries:     map[string]map[secretDigest]verifiedSecret{},
+		generations: map[string]uint64{},
+	}
+}
+
+// get returns a copy of the client when the secret was verified and has not expired.
+func (v *verifiedSecrets) get(name string, digest secretDigest, now time.Time) *models.APIClient {
+	if v == nil {
+		return nil
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	entry, ok := v.entries[name][digest]
+	if !ok {
+		return nil
+	}
+	if !now.Before(entry.expiresAt) {
+		delete(v.entries[name], digest)
+		return nil
+	}
+	client := entry.client
+	return &client
+}
+
+// generation is read before loading the client, and passed to put once its secret is verified.
+func (v *verifiedSecrets) generation(name string) uint64 {
+	if v == nil {
+		return 0
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.generations[name]
+}
+
+// put remembers the secret until the ttl passes, or until notAfter when that is earlier.
+func (v *verifiedSecrets) put(client *models.APIClient, digest secretDigest, generation uint64, now time.Time, notAfter *time.Time) {
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.generations[client.Name] != generation {
+		return
+	}
+	expiresAt := now.Add(v.ttl)
+	if notAfter != nil && notAfter.Before(expiresAt) {
+		expiresAt = *notAfter
+	}
+	if v.entries[client.Name] == nil {
+		v.entries[client.Name] = map[secretDigest]verifiedSecret{}
+	}
+	v.entries[client.Name][digest] = verifiedSecret{client: *client, expiresAt: expiresAt}
+}
+
+// forget drops the secrets verified for the client, after its secret or status changed.
+func (v *verifiedSecrets) forget(name string) {
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.entries, name)
+	v.generations[name]++
+}
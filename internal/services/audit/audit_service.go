@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/repository/audit"
+)
+
+type Service interface {
+	GetPage(ctx context.Context, query *models.AuditLog, request repository.FilterRequest) ([]models.AuditLog, repository.Page, error)
+}
+
+type auditService struct {
+	repo audit.Repository
+}
+
+func NewAuditService(repo audit.Repository) Service {
+	return &auditService{
+		repo: repo,
+	}
+}
+
+func (service *auditService) GetPage(ctx context.Context, query *models.AuditLog, request repository.FilterRequest) ([]models.AuditLog, repository.Page, error) {
+	return service.repo.GetPage(ctx, query, request)
+}
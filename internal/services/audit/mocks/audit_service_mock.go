@@ -0,0 +1,68 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/shahbaz275817/prismo/internal/repository"
+)
+
+// MockAuditService is an autogenerated mock type for the Service type
+type MockAuditService struct {
+	mock.Mock
+}
+
+// GetPage provides a mock function with given fields: ctx, query, request
+func (_m *MockAuditService) GetPage(ctx context.Context, query *models.AuditLog, request repository.FilterRequest) ([]models.AuditLog, repository.Page, error) {
+	ret := _m.Called(ctx, query, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 []models.AuditLog
+	var r1 repository.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditLog, repository.FilterRequest) ([]models.AuditLog, repository.Page, error)); ok {
+		return rf(ctx, query, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditLog, repository.FilterRequest) []models.AuditLog); ok {
+		r0 = rf(ctx, query, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.AuditLog, repository.FilterRequest) repository.Page); ok {
+		r1 = rf(ctx, query, request)
+	} else {
+		r1 = ret.Get(1).(repository.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.AuditLog, repository.FilterRequest) error); ok {
+		r2 = rf(ctx, query, request)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewMockAuditService creates a new instance of MockAuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditService {
+	mock := &MockAuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
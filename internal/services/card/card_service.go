@@ -0,0 +1,186 @@
+package card
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/repository/card"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+const maxPANGenerationAttempts = 5
+
+type Service interface {
+	Issue(ctx context.Context, accountID int64) (*models.Card, error)
+	GetByToken(ctx context.Context, token string) (*models.Card, error)
+	Activate(ctx context.Context, token string) (*models.Card, error)
+	Block(ctx context.Context, token string) (*models.Card, error)
+	Replace(ctx context.Context, token string) (*models.Card, error)
+}
+
+type Config struct {
+	BIN           string
+	PANLength     int
+	ValidityYears int
+}
+
+type cardService struct {
+	repo   card.Repository
+	cipher *PANCipher
+	cfg    Config
+}
+
+func NewCardService(repo card.Repository, cipher *PANCipher, cfg Config) Service {
+	return &cardService{
+		repo:   repo,
+		cipher: cipher,
+		cfg:    cfg,
+	}
+}
+
+func (service *cardService) Issue(ctx context.Context, accountID int64) (*models.Card, error) {
+	c, err := service.newCard(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.repo.Save(ctx, c); err != nil {
+		logger.WithContext(ctx).Errorf("Error while saving card for account %d Error: %s", accountID, err.Error())
+		return nil, err
+	}
+	return c, nil
+}
+
+func (service *cardService) GetByToken(ctx context.Context, token string) (*models.Card, error) {
+	return service.repo.Get(ctx, &models.Card{Token: token})
+}
+
+func (service *cardService) Activate(ctx context.Context, token string) (*models.Card, error) {
+	return service.transition(ctx, token, models.CardActive, models.CardInactive)
+}
+
+func (service *cardService) Block(ctx context.Context, token string) (*models.Card, error) {
+	return service.transition(ctx, token, models.CardBlocked, models.CardInactive, models.CardActive)
+}
+
+// Replace issues a new inactive card on the same account and retires the old one, pointing it at its replacement.
+func (service *cardService) Replace(ctx context.Context, token string) (*models.Card, error) {
+	var replacement *models.Card
+
+	err := service.repo.Transact(ctx, func(ctx context.Context) error {
+		old, err := service.getForUpdate(ctx, token, models.CardInactive, models.CardActive, models.CardBlocked)
+		if err != nil {
+			return err
+		}
+
+		replacement, err = service.newCard(ctx, old.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := service.repo.Save(ctx, replacement); err != nil {
+			return err
+		}
+
+		return service.repo.Update(ctx, old, map[string]interface{}{
+			"status":              models.CardReplaced,
+			"replaced_by_card_id": replacement.CardID,
+			"updated_at":          time.Now().UTC(),
+		})
+	})
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Error while replacing card Error: %s", err.Error())
+		return nil, err
+	}
+
+	return replacement, nil
+}
+
+func (service *cardService) transition(ctx context.Context, token string, to models.CardStatus, from ...models.CardStatus) (*models.Card, error) {
+	var c *models.Card
+
+	err := service.repo.Transact(ctx, func(ctx context.Context) error {
+		var err error
+		c, err = service.getForUpdate(ctx, token, from...)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if err := service.repo.Update(ctx, c, map[string]interface{}{"status": to, "updated_at": now}); err != nil {
+			return err
+		}
+		c.Status = to
+		c.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (service *cardService) getForUpdate(ctx context.Context, token string, allowed ...models.CardStatus) (*models.Card, error) {
+	c, err := service.repo.GetForUpdate(ctx, &models.Card{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, errors.NewNotFoundError("card_not_found", &errors.ErrDetails{})
+	}
+
+	for _, status := range allowed {
+		if c.Status == status {
+			return c, nil
+		}
+	}
+	return nil, errors.NewStatusUnprocessableEntity("invalid_card_status", &errors.ErrDetails{
+		Message: fmt.Sprintf("card is %s", c.Status),
+	})
+}
+
+func (service *cardService) newCard(ctx context.Context, accountID int64) (*models.Card, error) {
+	for attempt := 0; attempt < maxPANGenerationAttempts; attempt++ {
+		pan, err := GeneratePAN(service.cfg.BIN, service.cfg.PANLength, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		hash := service.cipher.Hash(pan)
+		taken, err := service.repo.PanHashTaken(repository.WithPrimaryReads(ctx), hash)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			continue
+		}
+
+		encrypted, err := service.cipher.Encrypt(pan)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now().UTC()
+		expiry := now.AddDate(service.cfg.ValidityYears, 0, 0)
+		return &models.Card{
+			AccountID:    accountID,
+			Token:        uuid.New().String(),
+			EncryptedPan: encrypted,
+			PanHash:      hash,
+			MaskedPan:    MaskPAN(pan, len(service.cfg.BIN)),
+			ExpiryMonth:  int(expiry.Month()),
+			ExpiryYear:   expiry.Year(),
+			Status:       models.CardInactive,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}, nil
+	}
+
+	return nil, errors.New("unable to generate a unique card number")
+}
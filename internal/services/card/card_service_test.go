@@ -0,0 +1,68 @@
+package card
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/card/mocks"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func TestCardService_Activate(t *testing.T) {
+	tests := []struct {
+		name       string
+		mockFunc   func(repo *mocks.MockCardRepository)
+		wantStatus models.CardStatus
+		wantErr    error
+	}{
+		{
+			name: "activates an inactive card locked for update",
+			mockFunc: func(repo *mocks.MockCardRepository) {
+				repo.On("GetForUpdate", mock.Anything, &models.Card{Token: "token"}).Return(&models.Card{CardID: 1, Token: "token", Status: models.CardInactive}, nil).Once()
+				repo.On("Update", mock.Anything, mock.Anything, mock.MatchedBy(func(update map[string]interface{}) bool {
+					return update["status"] == models.CardActive
+				})).Return(nil).Once()
+			},
+			wantStatus: models.CardActive,
+		},
+		{
+			name: "rejects a card another request already activated",
+			mockFunc: func(repo *mocks.MockCardRepository) {
+				repo.On("GetForUpdate", mock.Anything, &models.Card{Token: "token"}).Return(&models.Card{CardID: 1, Token: "token", Status: models.CardActive}, nil).Once()
+			},
+			wantErr: errors.UnprocessableEntityError{},
+		},
+		{
+			name: "returns not found for an unknown card",
+			mockFunc: func(repo *mocks.MockCardRepository) {
+				repo.On("GetForUpdate", mock.Anything, &models.Card{Token: "token"}).Return(nil, nil).Once()
+			},
+			wantErr: errors.NotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockCardRepository{}
+			repo.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+			tt.mockFunc(repo)
+
+			c, err := NewCardService(repo, nil, Config{}).Activate(context.Background(), "token")
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				assert.Nil(t, c)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, c.Status)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
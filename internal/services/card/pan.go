@@ -0,0 +1,85 @@
+package card
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const maskedDigitsVisible = 4
+
+// GeneratePAN returns a random card number of the given length that starts with bin and carries a valid Luhn check
+// digit.
+func GeneratePAN(bin string, length int, random io.Reader) (string, error) {
+	if !isDigits(bin) {
+		return "", errors.Errorf("card bin %q should only contain digits", bin)
+	}
+	if length < len(bin)+2 || length > 19 {
+		return "", errors.Errorf("card number length %d is invalid for bin %q", length, bin)
+	}
+	if random == nil {
+		random = rand.Reader
+	}
+
+	var sb strings.Builder
+	sb.WriteString(bin)
+	for sb.Len() < length-1 {
+		d, err := rand.Int(random, big.NewInt(10))
+		if err != nil {
+			return "", errors.Wrap(err, "unable to generate card number")
+		}
+		sb.WriteByte(byte('0' + d.Int64()))
+	}
+
+	partial := sb.String()
+	return partial + string(rune('0'+luhnCheckDigit(partial))), nil
+}
+
+// LuhnValid reports whether pan passes the Luhn (mod 10) checksum.
+func LuhnValid(pan string) bool {
+	if len(pan) < 2 || !isDigits(pan) {
+		return false
+	}
+	return luhnCheckDigit(pan[:len(pan)-1]) == int(pan[len(pan)-1]-'0')
+}
+
+// MaskPAN keeps the BIN and the last four digits visible.
+func MaskPAN(pan string, binLength int) string {
+	if len(pan) <= binLength+maskedDigitsVisible {
+		return strings.Repeat("*", len(pan))
+	}
+	hidden := len(pan) - binLength - maskedDigitsVisible
+	return pan[:binLength] + strings.Repeat("*", hidden) + pan[len(pan)-maskedDigitsVisible:]
+}
+
+func luhnCheckDigit(partial string) int {
+	sum := 0
+	double := true
+	for i := len(partial) - 1; i >= 0; i-- {
+		d := int(partial[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package card
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+// PANCipher encrypts card numbers with AES-256-GCM. The stored value is base64(nonce || ciphertext).
+type PANCipher struct {
+	aead    cipher.AEAD
+	hashKey []byte
+}
+
+func NewPANCipher(key []byte) (*PANCipher, error) {
+	if len(key) != 32 {
+		return nil, errors.New("card pan encryption key should be 32 bytes")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("pan-hash"))
+
+	return &PANCipher{aead: aead, hashKey: mac.Sum(nil)}, nil
+}
+
+func (c *PANCipher) Encrypt(pan string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(pan), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *PANCipher) Decrypt(encrypted string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < c.aead.NonceSize() {
+		return "", errors.New("encrypted pan is too short")
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	pan, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(pan), nil
+}
+
+// Hash returns a keyed hash of the PAN so duplicates can be detected without decrypting stored cards.
+func (c *PANCipher) Hash(pan string) string {
+	mac := hmac.New(sha256.New, c.hashKey)
+	mac.Write([]byte(pan))
+	return hex.EncodeToString(mac.Sum(nil))
+}
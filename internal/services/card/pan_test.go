@@ -0,0 +1,67 @@
+package card
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLuhnValid(t *testing.T) {
+	tests := []struct {
+		pan  string
+		want bool
+	}{
+		{pan: "4111111111111111", want: true},
+		{pan: "5500005555555559", want: true},
+		{pan: "4111111111111112", want: false},
+		{pan: "41111111a1111111", want: false},
+		{pan: "4", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pan, func(t *testing.T) {
+			assert.Equal(t, tt.want, LuhnValid(tt.pan))
+		})
+	}
+}
+
+func TestGeneratePAN(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		pan, err := GeneratePAN("412345", 16, nil)
+		assert.NoError(t, err)
+		assert.Len(t, pan, 16)
+		assert.True(t, strings.HasPrefix(pan, "412345"))
+		assert.True(t, LuhnValid(pan), pan)
+	}
+
+	_, err := GeneratePAN("41a345", 16, nil)
+	assert.Error(t, err)
+
+	_, err = GeneratePAN("412345", 7, nil)
+	assert.Error(t, err)
+}
+
+func TestMaskPAN(t *testing.T) {
+	assert.Equal(t, "412345******1234", MaskPAN("4123450000001234", 6))
+	assert.Equal(t, "********", MaskPAN("41234512", 6))
+}
+
+func TestPANCipher(t *testing.T) {
+	_, err := NewPANCipher([]byte("short"))
+	assert.Error(t, err)
+
+	c, err := NewPANCipher([]byte(strings.Repeat("k", 32)))
+	assert.NoError(t, err)
+
+	encrypted, err := c.Encrypt("4111111111111111")
+	assert.NoError(t, err)
+	assert.NotContains(t, encrypted, "4111111111111111")
+
+	decrypted, err := c.Decrypt(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "4111111111111111", decrypted)
+
+	assert.Equal(t, c.Hash("4111111111111111"), c.Hash("4111111111111111"))
+	assert.NotEqual(t, c.Hash("4111111111111111"), c.Hash("5500005555555559"))
+}
@@ -0,0 +1,128 @@
+package dispute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/account"
+	"github.com/shahbaz275817/prismo/internal/repository/dispute"
+	"github.com/shahbaz275817/prismo/internal/repository/transaction"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/locks"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+type Service interface {
+	// Open raises a dispute on an account, optionally against one of its transactions. The account cannot be erased
+	// until the dispute is resolved or rejected.
+	Open(ctx context.Context, d models.Dispute) (*models.Dispute, error)
+	Resolve(ctx context.Context, disputeID int64) (*models.Dispute, error)
+	Reject(ctx context.Context, disputeID int64) (*models.Dispute, error)
+}
+
+type disputeService struct {
+	repo        dispute.Repository
+	accountRepo account.Repository
+	txnRepo     transaction.Repository
+	lock        *locks.AtomicLock
+}
+
+func NewDisputeService(repo dispute.Repository, accountRepo account.Repository, txnRepo transaction.Repository, lock *locks.AtomicLock) Service {
+	return &disputeService{
+		repo:        repo,
+		accountRepo: accountRepo,
+		txnRepo:     txnRepo,
+		lock:        lock,
+	}
+}
+
+func (service *disputeService) Open(ctx context.Context, d models.Dispute) (*models.Dispute, error) {
+	// The account lock is shared with erasure, so an account is never erased while a dispute is being opened on it.
+	lockState := locks.LockState{}
+	_, err := service.lock.Execute(ctx, utils.BuildLockKey("account", d.AccountID), locks.AccountPosting, func(lockState *locks.LockState) ([]interface{}, error) {
+		return nil, service.repo.Transact(ctx, func(ctx context.Context) error {
+			acc, err := service.accountRepo.Get(ctx, &models.Account{AccountID: d.AccountID})
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return errors.NewNotFoundError("account_not_found", &errors.ErrDetails{
+					Message: fmt.Sprintf("account %d not found", d.AccountID),
+				})
+			}
+			if acc.Erased() {
+				return errors.NewStatusUnprocessableEntity("account_erased", &errors.ErrDetails{
+					Message: fmt.Sprintf("account %d is erased", d.AccountID),
+				})
+			}
+
+			if d.TransactionID != nil {
+				txn, err := service.txnRepo.Get(ctx, &models.Transaction{TransactionID: *d.TransactionID})
+				if err != nil {
+					return err
+				}
+				if txn == nil || txn.AccountID != d.AccountID {
+					return errors.NewNotFoundError("transaction_not_found", &errors.ErrDetails{
+						Message: fmt.Sprintf("transaction %d not found on account %d", *d.TransactionID, d.AccountID),
+					})
+				}
+			}
+
+			d.Status = models.DisputeOpen
+			d.CreatedAt = time.Now().UTC()
+			d.ResolvedAt = nil
+			return service.repo.Save(ctx, &d)
+		})
+	}, &lockState)
+
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Error while opening dispute on account %d Error: %s", d.AccountID, err.Error())
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (service *disputeService) Resolve(ctx context.Context, disputeID int64) (*models.Dispute, error) {
+	return service.close(ctx, disputeID, models.DisputeResolved)
+}
+
+func (service *disputeService) Reject(ctx context.Context, disputeID int64) (*models.Dispute, error) {
+	return service.close(ctx, disputeID, models.DisputeRejected)
+}
+
+func (service *disputeService) close(ctx context.Context, disputeID int64, to models.DisputeStatus) (*models.Dispute, error) {
+	var d *models.Dispute
+
+	err := service.repo.Transact(ctx, func(ctx context.Context) error {
+		var err error
+		d, err = service.repo.GetForUpdate(ctx, &models.Dispute{DisputeID: disputeID})
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return errors.NewNotFoundError("dispute_not_found", &errors.ErrDetails{})
+		}
+		if d.Status != models.DisputeOpen {
+			return errors.NewStatusUnprocessableEntity("invalid_dispute_status", &errors.ErrDetails{
+				Message: fmt.Sprintf("dispute is %s", d.Status),
+			})
+		}
+
+		now := time.Now().UTC()
+		if err := service.repo.Update(ctx, d, map[string]interface{}{"status": to, "resolved_at": now}); err != nil {
+			return err
+		}
+		d.Status = to
+		d.ResolvedAt = &now
+		return nil
+	})
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Error while closing dispute %d Error: %s", disputeID, err.Error())
+		return nil, err
+	}
+
+	return d, nil
+}
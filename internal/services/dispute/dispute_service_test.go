@@ -0,0 +1,149 @@
+package dispute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	accountMocks "github.com/shahbaz275817/prismo/internal/repository/account/mocks"
+	"github.com/shahbaz275817/prismo/internal/repository/dispute/mocks"
+	transactionMocks "github.com/shahbaz275817/prismo/internal/repository/transaction/mocks"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/locks"
+)
+
+func newTestService(repo *mocks.MockDisputeRepository, accRepo *accountMocks.MockAccountRepository, txnRepo *transactionMocks.MockTransactionRepository) Service {
+	repo.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}).Maybe()
+	lock := locks.NewAtomicLock(locks.NewMemoryLocker(), map[locks.KeyType]locks.LockConfig{
+		locks.Def: {LockExpiry: time.Second, RetryAttempts: 1, RetryDelay: time.Millisecond},
+	}, nil)
+	return NewDisputeService(repo, accRepo, txnRepo, lock)
+}
+
+func TestDisputeService_Open(t *testing.T) {
+	erasedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	transactionID := int64(9)
+
+	tests := []struct {
+		name     string
+		request  models.Dispute
+		mockFunc func(repo *mocks.MockDisputeRepository, accRepo *accountMocks.MockAccountRepository, txnRepo *transactionMocks.MockTransactionRepository)
+		wantErr  error
+	}{
+		{
+			name:    "opens a dispute against a transaction of the account",
+			request: models.Dispute{AccountID: 1, TransactionID: &transactionID},
+			mockFunc: func(repo *mocks.MockDisputeRepository, accRepo *accountMocks.MockAccountRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(&models.Account{AccountID: 1}, nil).Once()
+				txnRepo.On("Get", mock.Anything, &models.Transaction{TransactionID: 9}).Return(&models.Transaction{TransactionID: 9, AccountID: 1}, nil).Once()
+				repo.On("Save", mock.Anything, mock.MatchedBy(func(d *models.Dispute) bool {
+					return d.AccountID == 1 && d.Status == models.DisputeOpen && !d.CreatedAt.IsZero()
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:    "rejects a transaction of another account",
+			request: models.Dispute{AccountID: 1, TransactionID: &transactionID},
+			mockFunc: func(repo *mocks.MockDisputeRepository, accRepo *accountMocks.MockAccountRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(&models.Account{AccountID: 1}, nil).Once()
+				txnRepo.On("Get", mock.Anything, &models.Transaction{TransactionID: 9}).Return(&models.Transaction{TransactionID: 9, AccountID: 2}, nil).Once()
+			},
+			wantErr: errors.NotFoundError{},
+		},
+		{
+			name:    "rejects an erased account",
+			request: models.Dispute{AccountID: 1},
+			mockFunc: func(repo *mocks.MockDisputeRepository, accRepo *accountMocks.MockAccountRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(&models.Account{AccountID: 1, ErasedAt: &erasedAt}, nil).Once()
+			},
+			wantErr: errors.UnprocessableEntityError{},
+		},
+		{
+			name:    "fails when the account does not exist",
+			request: models.Dispute{AccountID: 1},
+			mockFunc: func(repo *mocks.MockDisputeRepository, accRepo *accountMocks.MockAccountRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(nil, nil).Once()
+			},
+			wantErr: errors.NotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockDisputeRepository{}
+			accRepo := &accountMocks.MockAccountRepository{}
+			txnRepo := &transactionMocks.MockTransactionRepository{}
+			tt.mockFunc(repo, accRepo, txnRepo)
+
+			d, err := newTestService(repo, accRepo, txnRepo).Open(context.Background(), tt.request)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				assert.Nil(t, d)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.DisputeOpen, d.Status)
+			}
+			repo.AssertExpectations(t)
+			accRepo.AssertExpectations(t)
+			txnRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDisputeService_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockFunc func(repo *mocks.MockDisputeRepository)
+		wantErr  error
+	}{
+		{
+			name: "resolves an open dispute",
+			mockFunc: func(repo *mocks.MockDisputeRepository) {
+				repo.On("GetForUpdate", mock.Anything, &models.Dispute{DisputeID: 3}).Return(&models.Dispute{DisputeID: 3, Status: models.DisputeOpen}, nil).Once()
+				repo.On("Update", mock.Anything, mock.Anything, mock.MatchedBy(func(update map[string]interface{}) bool {
+					return update["status"] == models.DisputeResolved
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "rejects a dispute already closed",
+			mockFunc: func(repo *mocks.MockDisputeRepository) {
+				repo.On("GetForUpdate", mock.Anything, &models.Dispute{DisputeID: 3}).Return(&models.Dispute{DisputeID: 3, Status: models.DisputeRejected}, nil).Once()
+			},
+			wantErr: errors.UnprocessableEntityError{},
+		},
+		{
+			name: "fails when the dispute does not exist",
+			mockFunc: func(repo *mocks.MockDisputeRepository) {
+				repo.On("GetForUpdate", mock.Anything, &models.Dispute{DisputeID: 3}).Return(nil, nil).Once()
+			},
+			wantErr: errors.NotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockDisputeRepository{}
+			tt.mockFunc(repo)
+
+			d, err := newTestService(repo, &accountMocks.MockAccountRepository{}, &transactionMocks.MockTransactionRepository{}).Resolve(context.Background(), 3)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				assert.Nil(t, d)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.DisputeResolved, d.Status)
+				assert.NotNil(t, d.ResolvedAt)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
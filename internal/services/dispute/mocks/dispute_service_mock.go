@@ -0,0 +1,120 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+)
+
+// MockDisputeService is an autogenerated mock type for the Service type
+type MockDisputeService struct {
+	mock.Mock
+}
+
+// Open provides a mock function with given fields: ctx, d
+func (_m *MockDisputeService) Open(ctx context.Context, d models.Dispute) (*models.Dispute, error) {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 *models.Dispute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Dispute) (*models.Dispute, error)); ok {
+		return rf(ctx, d)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Dispute) *models.Dispute); ok {
+		r0 = rf(ctx, d)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Dispute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Dispute) error); ok {
+		r1 = rf(ctx, d)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reject provides a mock function with given fields: ctx, disputeID
+func (_m *MockDisputeService) Reject(ctx context.Context, disputeID int64) (*models.Dispute, error) {
+	ret := _m.Called(ctx, disputeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reject")
+	}
+
+	var r0 *models.Dispute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.Dispute, error)); ok {
+		return rf(ctx, disputeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Dispute); ok {
+		r0 = rf(ctx, disputeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Dispute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, disputeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resolve provides a mock function with given fields: ctx, disputeID
+func (_m *MockDisputeService) Resolve(ctx context.Context, disputeID int64) (*models.Dispute, error) {
+	ret := _m.Called(ctx, disputeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 *models.Dispute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.Dispute, error)); ok {
+		return rf(ctx, disputeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Dispute); ok {
+		r0 = rf(ctx, disputeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Dispute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, disputeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockDisputeService creates a new instance of MockDisputeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDisputeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDisputeService {
+	mock := &MockDisputeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,120 @@
+package erasure
+
+import (
+	"context"
+	"time"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/internal/repository/account"
+	"github.com/shahbaz275817/prismo/internal/repository/audit"
+	"github.com/shahbaz275817/prismo/internal/repository/card"
+	"github.com/shahbaz275817/prismo/internal/repository/dispute"
+	account2 "github.com/shahbaz275817/prismo/internal/services/account"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/locks"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+// documentNumberLength matches Accounts.Document_Number so pseudonyms fit the existing column.
+const documentNumberLength = 15
+
+type Service interface {
+	// EraseAccount replaces the personal data of an account holder with irreversible pseudonyms and blanks what the
+	// audit trail recorded of the account and its cards. Transactions are kept. Erasing an already erased account returns it unchanged. A non zero version is the version of the
+	// account the caller expects to erase.
+	EraseAccount(ctx context.Context, accountID int64, version int64) (*models.Account, error)
+}
+
+type erasureService struct {
+	accountRepo  account.Repository
+	cardRepo     card.Repository
+	disputeRepo  dispute.Repository
+	auditRepo    audit.Repository
+	lock         *locks.AtomicLock
+	accountCache account2.Invalidator
+}
+
+// NewErasureService erases accounts through accountRepo, dropping them from accountCache once erased.
+func NewErasureService(accountRepo account.Repository, cardRepo card.Repository, disputeRepo dispute.Repository, auditRepo audit.Repository, lock *locks.AtomicLock, accountCache account2.Invalidator) Service {
+	return &erasureService{
+		accountRepo:  accountRepo,
+		cardRepo:     cardRepo,
+		disputeRepo:  disputeRepo,
+		auditRepo:    auditRepo,
+		lock:         lock,
+		accountCache: accountCache,
+	}
+}
+
+func (service *erasureService) EraseAccount(ctx context.Context, accountID int64, version int64) (*models.Account, error) {
+	var result *models.Account
+
+	// The account lock is shared with transfers, so no money moves while the account is being erased.
+	lockState := locks.LockState{}
+	_, err := service.lock.Execute(ctx, utils.BuildLockKey("account", accountID), locks.AccountPosting, func(lockState *locks.LockState) ([]interface{}, error) {
+		err := service.accountRepo.Transact(ctx, func(ctx context.Context) error {
+			acc, err := service.accountRepo.Get(ctx, &models.Account{AccountID: accountID})
+			if err != nil {
+				return err
+			}
+			if acc == nil {
+				return errors.NewNotFoundError("account_not_found", &errors.ErrDetails{Message: "account not found"})
+			}
+			if version != 0 && acc.Version != version {
+				return errors.NewPreconditionFailedError("version_mismatch", &errors.ErrDetails{
+					Message: "account was changed since it was read",
+				})
+			}
+			if acc.ErasedAt != nil {
+				result = acc
+				return nil
+			}
+
+			open, err := service.disputeRepo.HasOpenDisputes(ctx, accountID)
+			if err != nil {
+				return err
+			}
+			if open {
+				return errors.NewStatusUnprocessableEntity("open_disputes", &errors.ErrDetails{
+					Message: "account has open disputes and cannot be erased",
+				})
+			}
+
+			pseudonym, err := utils.NewPseudonym(documentNumberLength)
+			if err != nil {
+				return err
+			}
+
+			// before erasing, so the entries recording the erasure itself are kept
+			if err := service.auditRepo.ScrubAccount(ctx, accountID); err != nil {
+				return err
+			}
+
+			ctx = repository.WithAuditAction(ctx, models.AuditErase)
+			erasedAt := time.Now().UTC()
+			if err := service.accountRepo.Erase(ctx, acc, pseudonym, erasedAt); err != nil {
+				return err
+			}
+			if err := service.cardRepo.EraseByAccount(ctx, accountID); err != nil {
+				return err
+			}
+
+			acc.DocumentNumber, acc.ErasedAt = pseudonym, &erasedAt
+			result = acc
+			return nil
+		})
+		if err == nil {
+			// only after the commit, so reloads cannot cache the account as it was before
+			service.accountCache.Invalidate(ctx, accountID)
+		}
+		return nil, err
+	}, &lockState)
+
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Error while erasing account %d Error: %s", accountID, err.Error())
+		return nil, err
+	}
+	return result, nil
+}
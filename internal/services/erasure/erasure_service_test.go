@@ -0,0 +1,141 @@
+package erasure
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	accountMocks "github.com/shahbaz275817/prismo/internal/repository/account/mocks"
+	auditMocks "github.com/shahbaz275817/prismo/internal/repository/audit/mocks"
+	cardMocks "github.com/shahbaz275817/prismo/internal/repository/card/mocks"
+	disputeMocks "github.com/shahbaz275817/prismo/internal/repository/dispute/mocks"
+	accountServiceMocks "github.com/shahbaz275817/prismo/internal/services/account/mocks"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/locks"
+	"github.com/shahbaz275817/prismo/pkg/locks/lockstest"
+)
+
+func TestErasureService_EraseAccount(t *testing.T) {
+	erasedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		version  int64
+		mockFunc func(accRepo *accountMocks.MockAccountRepository, cardRepo *cardMocks.MockCardRepository, disputeRepo *disputeMocks.MockDisputeRepository, auditRepo *auditMocks.MockAuditRepository)
+		wantErr  error
+		wantDoc  string
+	}{
+		{
+			name: "pseudonymizes the account and its cards",
+			mockFunc: func(accRepo *accountMocks.MockAccountRepository, cardRepo *cardMocks.MockCardRepository, disputeRepo *disputeMocks.MockDisputeRepository, auditRepo *auditMocks.MockAuditRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(&models.Account{AccountID: 1, DocumentNumber: "12345678900"}, nil).Once()
+				disputeRepo.On("HasOpenDisputes", mock.Anything, int64(1)).Return(false, nil).Once()
+				auditRepo.On("ScrubAccount", mock.Anything, int64(1)).Return(nil).Once()
+				accRepo.On("Erase", mock.Anything, mock.Anything, mock.MatchedBy(func(doc string) bool {
+					return len(doc) == documentNumberLength && strings.HasPrefix(doc, utils.PseudonymPrefix)
+				}), mock.Anything).Return(nil).Once()
+				cardRepo.On("EraseByAccount", mock.Anything, int64(1)).Return(nil).Once()
+			},
+		},
+		{
+			name: "returns an already erased account unchanged",
+			mockFunc: func(accRepo *accountMocks.MockAccountRepository, cardRepo *cardMocks.MockCardRepository, disputeRepo *disputeMocks.MockDisputeRepository, auditRepo *auditMocks.MockAuditRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(&models.Account{AccountID: 1, DocumentNumber: "Xabc", ErasedAt: &erasedAt}, nil).Once()
+			},
+			wantDoc: "Xabc",
+		},
+		{
+			name: "is blocked by open disputes",
+			mockFunc: func(accRepo *accountMocks.MockAccountRepository, cardRepo *cardMocks.MockCardRepository, disputeRepo *disputeMocks.MockDisputeRepository, auditRepo *auditMocks.MockAuditRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(&models.Account{AccountID: 1, DocumentNumber: "12345678900"}, nil).Once()
+				disputeRepo.On("HasOpenDisputes", mock.Anything, int64(1)).Return(true, nil).Once()
+			},
+			wantErr: errors.UnprocessableEntityError{},
+		},
+		{
+			name:    "erases the expected version",
+			version: 3,
+			mockFunc: func(accRepo *accountMocks.MockAccountRepository, cardRepo *cardMocks.MockCardRepository, disputeRepo *disputeMocks.MockDisputeRepository, auditRepo *auditMocks.MockAuditRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(&models.Account{AccountID: 1, DocumentNumber: "12345678900", Version: 3}, nil).Once()
+				disputeRepo.On("HasOpenDisputes", mock.Anything, int64(1)).Return(false, nil).Once()
+				auditRepo.On("ScrubAccount", mock.Anything, int64(1)).Return(nil).Once()
+				accRepo.On("Erase", mock.Anything, mock.MatchedBy(func(acc *models.Account) bool { return acc.Version == 3 }), mock.Anything, mock.Anything).Return(nil).Once()
+				cardRepo.On("EraseByAccount", mock.Anything, int64(1)).Return(nil).Once()
+			},
+		},
+		{
+			name:    "fails when the account has another version",
+			version: 2,
+			mockFunc: func(accRepo *accountMocks.MockAccountRepository, cardRepo *cardMocks.MockCardRepository, disputeRepo *disputeMocks.MockDisputeRepository, auditRepo *auditMocks.MockAuditRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(&models.Account{AccountID: 1, DocumentNumber: "12345678900", Version: 3}, nil).Once()
+			},
+			wantErr: errors.PreconditionFailedError{},
+		},
+		{
+			name: "does not erase the account when the audit logs cannot be scrubbed",
+			mockFunc: func(accRepo *accountMocks.MockAccountRepository, cardRepo *cardMocks.MockCardRepository, disputeRepo *disputeMocks.MockDisputeRepository, auditRepo *auditMocks.MockAuditRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(&models.Account{AccountID: 1, DocumentNumber: "12345678900"}, nil).Once()
+				disputeRepo.On("HasOpenDisputes", mock.Anything, int64(1)).Return(false, nil).Once()
+				auditRepo.On("ScrubAccount", mock.Anything, int64(1)).Return(errors.NewUnknownError("db error")).Once()
+			},
+			wantErr: errors.UnknownError{},
+		},
+		{
+			name: "fails when the account does not exist",
+			mockFunc: func(accRepo *accountMocks.MockAccountRepository, cardRepo *cardMocks.MockCardRepository, disputeRepo *disputeMocks.MockDisputeRepository, auditRepo *auditMocks.MockAuditRepository) {
+				accRepo.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(nil, nil).Once()
+			},
+			wantErr: errors.NotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accRepo := &accountMocks.MockAccountRepository{}
+			cardRepo := &cardMocks.MockCardRepository{}
+			disputeRepo := &disputeMocks.MockDisputeRepository{}
+			auditRepo := &auditMocks.MockAuditRepository{}
+			locker := lockstest.NewRecordingLocker()
+			accountCache := &accountServiceMocks.MockInvalidator{}
+			if tt.wantErr == nil {
+				accountCache.On("Invalidate", mock.Anything, int64(1)).Once()
+			}
+			accRepo.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).Once()
+			tt.mockFunc(accRepo, cardRepo, disputeRepo, auditRepo)
+
+			lock := locks.NewAtomicLock(locker, map[locks.KeyType]locks.LockConfig{
+				locks.Def: {LockExpiry: time.Second, RetryAttempts: 1, RetryDelay: time.Millisecond},
+			}, nil)
+			service := NewErasureService(accRepo, cardRepo, disputeRepo, auditRepo, lock, accountCache)
+
+			acc, err := service.EraseAccount(context.Background(), 1, tt.version)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				assert.Nil(t, acc)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, acc.ErasedAt)
+				if tt.wantDoc != "" {
+					assert.Equal(t, tt.wantDoc, acc.DocumentNumber)
+				} else {
+					assert.NotEqual(t, "12345678900", acc.DocumentNumber)
+				}
+			}
+			accRepo.AssertExpectations(t)
+			cardRepo.AssertExpectations(t)
+			disputeRepo.AssertExpectations(t)
+			auditRepo.AssertExpectations(t)
+			accountCache.AssertExpectations(t)
+			assert.Equal(t, []string{"lock-account-1"}, locker.Acquired())
+		})
+	}
+}
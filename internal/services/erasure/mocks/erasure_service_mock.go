@@ -0,0 +1,60 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+)
+
+// MockErasureService is an autogenerated mock type for the Service type
+type MockErasureService struct {
+	mock.Mock
+}
+
+// EraseAccount provides a mock function with given fields: ctx, accountID, version
+func (_m *MockErasureService) EraseAccount(ctx context.Context, accountID int64, version int64) (*models.Account, error) {
+	ret := _m.Called(ctx, accountID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EraseAccount")
+	}
+
+	var r0 *models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (*models.Account, error)); ok {
+		return rf(ctx, accountID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Account); ok {
+		r0 = rf(ctx, accountID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, accountID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockErasureService creates a new instance of MockErasureService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockErasureService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockErasureService {
+	mock := &MockErasureService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+package fee
+
+import (
+	"context"
+	"math"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/fee"
+	"github.com/shahbaz275817/prismo/internal/repository/transaction"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+type Service interface {
+	// Apply posts the fee configured for the transaction's operation type, linked to the transaction. It returns nil
+	// when no active schedule exists or the computed fee is zero.
+	Apply(ctx context.Context, trx *models.Transaction) (*models.Transaction, error)
+}
+
+type Config struct {
+	FeeOperationTypeID      int64
+	InterestOperationTypeID int64
+}
+
+type feeService struct {
+	repo    fee.Repository
+	txnRepo transaction.Repository
+	cfg     Config
+}
+
+func NewFeeService(repo fee.Repository, txnRepo transaction.Repository, cfg Config) Service {
+	return &feeService{
+		repo:    repo,
+		txnRepo: txnRepo,
+		cfg:     cfg,
+	}
+}
+
+func (service *feeService) Apply(ctx context.Context, trx *models.Transaction) (*models.Transaction, error) {
+	if trx.OperationTypeID == service.cfg.FeeOperationTypeID || trx.OperationTypeID == service.cfg.InterestOperationTypeID {
+		return nil, nil
+	}
+
+	schedule, err := service.repo.Get(ctx, &models.FeeSchedule{OperationTypeID: trx.OperationTypeID})
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Error while fetching fee schedule for operation type %d Error: %s", trx.OperationTypeID, err.Error())
+		return nil, err
+	}
+	if schedule == nil || !schedule.IsActive {
+		return nil, nil
+	}
+
+	amount := Calculate(*schedule, trx.Amount)
+	if amount == 0 {
+		return nil, nil
+	}
+
+	parentID := trx.TransactionID
+	feeTrx := models.Transaction{
+		AccountID:           trx.AccountID,
+		OperationTypeID:     service.cfg.FeeOperationTypeID,
+		Amount:              amount,
+		EventDate:           trx.EventDate,
+		ParentTransactionID: &parentID,
+	}
+
+	if err := service.txnRepo.Save(ctx, &feeTrx); err != nil {
+		logger.WithContext(ctx).Errorf("Error while saving fee for transaction %d Error: %s", trx.TransactionID, err.Error())
+		return nil, err
+	}
+
+	return &feeTrx, nil
+}
+
+// Calculate returns the fee for a transaction amount: the fixed part plus the percentage of the absolute amount,
+// clamped to the schedule's min and max and rounded to cents.
+func Calculate(schedule models.FeeSchedule, amount float64) float64 {
+	fee := schedule.FixedAmount + math.Abs(amount)*schedule.Percentage/100
+
+	if schedule.MinAmount != nil && fee < *schedule.MinAmount {
+		fee = *schedule.MinAmount
+	}
+	if schedule.MaxAmount != nil && fee > *schedule.MaxAmount {
+		fee = *schedule.MaxAmount
+	}
+
+	return math.Round(fee*100) / 100
+}
@@ -0,0 +1,156 @@
+package fee
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/fee/mocks"
+	transactionMocks "github.com/shahbaz275817/prismo/internal/repository/transaction/mocks"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func TestCalculate(t *testing.T) {
+	floatPtr := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name     string
+		schedule models.FeeSchedule
+		amount   float64
+		want     float64
+	}{
+		{
+			name:     "fixed fee only",
+			schedule: models.FeeSchedule{FixedAmount: 1.5},
+			amount:   100,
+			want:     1.5,
+		},
+		{
+			name:     "percentage of absolute amount",
+			schedule: models.FeeSchedule{Percentage: 2.5},
+			amount:   -80,
+			want:     2,
+		},
+		{
+			name:     "fixed and percentage combined",
+			schedule: models.FeeSchedule{FixedAmount: 0.3, Percentage: 2.9},
+			amount:   10,
+			want:     0.59,
+		},
+		{
+			name:     "clamped to minimum",
+			schedule: models.FeeSchedule{Percentage: 1, MinAmount: floatPtr(0.5)},
+			amount:   10,
+			want:     0.5,
+		},
+		{
+			name:     "clamped to maximum",
+			schedule: models.FeeSchedule{Percentage: 1, MaxAmount: floatPtr(5)},
+			amount:   10000,
+			want:     5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Calculate(tt.schedule, tt.amount))
+		})
+	}
+}
+
+func TestFeeService_Apply(t *testing.T) {
+	cfg := Config{FeeOperationTypeID: 5, InterestOperationTypeID: 6}
+	eventDate := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	purchase := models.Transaction{TransactionID: 9, AccountID: 1, OperationTypeID: 1, Amount: 200, EventDate: eventDate}
+
+	tests := []struct {
+		name     string
+		trx      models.Transaction
+		mockFunc func(repo *mocks.MockFeeScheduleRepository, txnRepo *transactionMocks.MockTransactionRepository)
+		wantFee  *models.Transaction
+		wantErr  error
+	}{
+		{
+			name: "posts the fee linked to the transaction",
+			trx:  purchase,
+			mockFunc: func(repo *mocks.MockFeeScheduleRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("Get", mock.Anything, &models.FeeSchedule{OperationTypeID: 1}).
+					Return(&models.FeeSchedule{FixedAmount: 1, Percentage: 1, IsActive: true}, nil).Once()
+				txnRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			wantFee: &models.Transaction{
+				AccountID:           1,
+				OperationTypeID:     5,
+				Amount:              3,
+				EventDate:           eventDate,
+				ParentTransactionID: &purchase.TransactionID,
+			},
+		},
+		{
+			name: "charges no fee on fees",
+			trx:  models.Transaction{TransactionID: 10, AccountID: 1, OperationTypeID: 5, Amount: -3},
+		},
+		{
+			name: "charges no fee on interest",
+			trx:  models.Transaction{TransactionID: 11, AccountID: 1, OperationTypeID: 6, Amount: -1},
+		},
+		{
+			name: "charges no fee without a schedule",
+			trx:  purchase,
+			mockFunc: func(repo *mocks.MockFeeScheduleRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("Get", mock.Anything, &models.FeeSchedule{OperationTypeID: 1}).Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "charges no fee with an inactive schedule",
+			trx:  purchase,
+			mockFunc: func(repo *mocks.MockFeeScheduleRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("Get", mock.Anything, &models.FeeSchedule{OperationTypeID: 1}).
+					Return(&models.FeeSchedule{FixedAmount: 1, IsActive: false}, nil).Once()
+			},
+		},
+		{
+			name: "charges no zero fee",
+			trx:  purchase,
+			mockFunc: func(repo *mocks.MockFeeScheduleRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("Get", mock.Anything, &models.FeeSchedule{OperationTypeID: 1}).
+					Return(&models.FeeSchedule{IsActive: true}, nil).Once()
+			},
+		},
+		{
+			name: "fails when the fee cannot be saved",
+			trx:  purchase,
+			mockFunc: func(repo *mocks.MockFeeScheduleRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("Get", mock.Anything, &models.FeeSchedule{OperationTypeID: 1}).
+					Return(&models.FeeSchedule{FixedAmount: 1, IsActive: true}, nil).Once()
+				txnRepo.On("Save", mock.Anything, mock.Anything).Return(errors.NewUnknownError("db error")).Once()
+			},
+			wantErr: errors.UnknownError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockFeeScheduleRepository{}
+			txnRepo := &transactionMocks.MockTransactionRepository{}
+			if tt.mockFunc != nil {
+				tt.mockFunc(repo, txnRepo)
+			}
+
+			feeTrx, err := NewFeeService(repo, txnRepo, cfg).Apply(context.Background(), &tt.trx)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantFee, feeTrx)
+			repo.AssertExpectations(t)
+			txnRepo.AssertExpectations(t)
+		})
+	}
+}
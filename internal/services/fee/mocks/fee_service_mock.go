@@ -0,0 +1,60 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+)
+
+// MockFeeService is an autogenerated mock type for the Service type
+type MockFeeService struct {
+	mock.Mock
+}
+
+// Apply provides a mock function with given fields: ctx, trx
+func (_m *MockFeeService) Apply(ctx context.Context, trx *models.Transaction) (*models.Transaction, error) {
+	ret := _m.Called(ctx, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Apply")
+	}
+
+	var r0 *models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction) (*models.Transaction, error)); ok {
+		return rf(ctx, trx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction) *models.Transaction); ok {
+		r0 = rf(ctx, trx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Transaction) error); ok {
+		r1 = rf(ctx, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockFeeService creates a new instance of MockFeeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFeeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFeeService {
+	mock := &MockFeeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
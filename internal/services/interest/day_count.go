@@ -0,0 +1,51 @@
+package interest
+
+import (
+	"time"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const (
+	Actual365 = "ACT/365"
+	Actual360 = "ACT/360"
+	Thirty360 = "30/360"
+)
+
+// YearFraction returns the portion of a year between start and end under the given day-count convention.
+func YearFraction(convention string, start, end time.Time) (float64, error) {
+	switch convention {
+	case Actual365:
+		return actualDays(start, end) / 365, nil
+	case Actual360:
+		return actualDays(start, end) / 360, nil
+	case Thirty360:
+		return thirty360Days(start, end) / 360, nil
+	default:
+		return 0, errors.Errorf("unknown day count convention %s", convention)
+	}
+}
+
+func actualDays(start, end time.Time) float64 {
+	return float64(truncateToDay(end).Sub(truncateToDay(start)) / (24 * time.Hour))
+}
+
+// thirty360Days implements the 30/360 US (bond basis) day count.
+func thirty360Days(start, end time.Time) float64 {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+
+	return float64(360*(y2-y1) + 30*(int(m2)-int(m1)) + (d2 - d1))
+}
+
+func truncateToDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,42 @@
+package interest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYearFraction(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name       string
+		convention string
+		start      time.Time
+		end        time.Time
+		want       float64
+		wantErr    bool
+	}{
+		{name: "actual/365 single day", convention: Actual365, start: date(2024, 3, 1), end: date(2024, 3, 2), want: 1.0 / 365},
+		{name: "actual/360 single day", convention: Actual360, start: date(2024, 3, 1), end: date(2024, 3, 2), want: 1.0 / 360},
+		{name: "actual/365 leap february", convention: Actual365, start: date(2024, 2, 1), end: date(2024, 3, 1), want: 29.0 / 365},
+		{name: "30/360 the 31st accrues nothing", convention: Thirty360, start: date(2024, 1, 30), end: date(2024, 1, 31), want: 0},
+		{name: "30/360 from the 31st", convention: Thirty360, start: date(2024, 1, 31), end: date(2024, 2, 1), want: 1.0 / 360},
+		{name: "30/360 end of february", convention: Thirty360, start: date(2023, 2, 28), end: date(2023, 3, 1), want: 3.0 / 360},
+		{name: "30/360 full year", convention: Thirty360, start: date(2023, 1, 1), end: date(2024, 1, 1), want: 1},
+		{name: "unknown convention", convention: "ACT/ACT", start: date(2024, 3, 1), end: date(2024, 3, 2), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := YearFraction(tt.convention, tt.start, tt.end)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-12)
+		})
+	}
+}
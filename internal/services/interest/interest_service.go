@@ -0,0 +1,130 @@
+package interest
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/interest"
+	"github.com/shahbaz275817/prismo/internal/repository/transaction"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+type Service interface {
+	// AccrueDaily posts one day of interest on every revolving balance as of the end of date and returns the number
+	// of accounts charged. Accounts already accrued for date are skipped, so the job can be re-run safely.
+	AccrueDaily(ctx context.Context, date time.Time) (int, error)
+}
+
+type Config struct {
+	Apr                     float64
+	DayCountConvention      string
+	InterestOperationTypeID int64
+}
+
+type interestService struct {
+	repo    interest.Repository
+	txnRepo transaction.Repository
+	cfg     Config
+}
+
+func NewInterestService(repo interest.Repository, txnRepo transaction.Repository, cfg Config) Service {
+	return &interestService{
+		repo:    repo,
+		txnRepo: txnRepo,
+		cfg:     cfg,
+	}
+}
+
+func (service *interestService) AccrueDaily(ctx context.Context, date time.Time) (int, error) {
+	day := truncateToDay(date)
+	nextDay := day.AddDate(0, 0, 1)
+
+	fraction, err := YearFraction(service.cfg.DayCountConvention, day, nextDay)
+	if err != nil {
+		return 0, err
+	}
+
+	totals, err := service.repo.GetOperationTotals(ctx, nextDay)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Error while fetching account balances Error: %s", err.Error())
+		return 0, err
+	}
+
+	charged := 0
+	for _, balance := range revolvingBalances(totals) {
+		amount := math.Round(-balance.Balance*service.cfg.Apr*fraction*100) / 100
+		if amount <= 0 {
+			continue
+		}
+
+		posted, err := service.post(ctx, day, balance, amount)
+		if err != nil {
+			logger.WithContext(ctx).Errorf("Error while accruing interest for account %d Error: %s", balance.AccountID, err.Error())
+			return charged, err
+		}
+		if posted {
+			charged++
+		}
+	}
+
+	return charged, nil
+}
+
+// revolvingBalances adds up the totals of every account, crediting those of credit operation types and debiting the
+// others, and returns the negative balances. totals must be ordered by account.
+func revolvingBalances(totals []models.OperationTotal) []models.AccountBalance {
+	var balances []models.AccountBalance
+	for i := 0; i < len(totals); {
+		balance := models.AccountBalance{AccountID: totals[i].AccountID}
+		for ; i < len(totals) && totals[i].AccountID == balance.AccountID; i++ {
+			if totals[i].IsCredit {
+				balance.Balance += totals[i].Amount
+			} else {
+				balance.Balance -= totals[i].Amount
+			}
+		}
+
+		balance.Balance = math.Round(balance.Balance*100) / 100
+		if balance.Balance < 0 {
+			balances = append(balances, balance)
+		}
+	}
+	return balances
+}
+
+func (service *interestService) post(ctx context.Context, day time.Time, balance models.AccountBalance, amount float64) (bool, error) {
+	posted := false
+
+	err := service.repo.Transact(ctx, func(ctx context.Context) error {
+		accrual := models.InterestAccrual{
+			AccountID:          balance.AccountID,
+			AccrualDate:        day,
+			Balance:            balance.Balance,
+			Apr:                service.cfg.Apr,
+			DayCountConvention: service.cfg.DayCountConvention,
+			Amount:             amount,
+		}
+
+		inserted, err := service.repo.SaveAccrual(ctx, &accrual)
+		if err != nil || !inserted {
+			return err
+		}
+
+		trx := models.Transaction{
+			AccountID:       balance.AccountID,
+			OperationTypeID: service.cfg.InterestOperationTypeID,
+			Amount:          amount,
+			EventDate:       day.Add(24*time.Hour - time.Second),
+		}
+		if err := service.txnRepo.Save(ctx, &trx); err != nil {
+			return err
+		}
+
+		posted = true
+		return service.repo.SetAccrualTransaction(ctx, &accrual, trx.TransactionID)
+	})
+
+	return posted, err
+}
@@ -0,0 +1,132 @@
+package interest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/interest/mocks"
+	transactionMocks "github.com/shahbaz275817/prismo/internal/repository/transaction/mocks"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const (
+	purchaseOperationTypeID = 1
+	paymentOperationTypeID  = 4
+	feeOperationTypeID      = 5
+	interestOperationTypeID = 6
+)
+
+func purchases(accountID int64, amount float64) models.OperationTotal {
+	return models.OperationTotal{AccountID: accountID, OperationTypeID: purchaseOperationTypeID, Amount: amount}
+}
+
+func payments(accountID int64, amount float64) models.OperationTotal {
+	return models.OperationTotal{AccountID: accountID, OperationTypeID: paymentOperationTypeID, IsCredit: true, Amount: amount}
+}
+
+func fees(accountID int64, amount float64) models.OperationTotal {
+	return models.OperationTotal{AccountID: accountID, OperationTypeID: feeOperationTypeID, Amount: amount}
+}
+
+func TestInterestService_AccrueDaily(t *testing.T) {
+	date := time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC)
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	cfg := Config{Apr: 0.365, DayCountConvention: Actual365, InterestOperationTypeID: interestOperationTypeID}
+
+	tests := []struct {
+		name        string
+		mockFunc    func(repo *mocks.MockInterestRepository, txnRepo *transactionMocks.MockTransactionRepository)
+		wantCharged int
+		wantErr     error
+	}{
+		{
+			name: "charges purchases and fees not covered by payments",
+			mockFunc: func(repo *mocks.MockInterestRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("GetOperationTotals", mock.Anything, day.AddDate(0, 0, 1)).Return([]models.OperationTotal{
+					purchases(1, 1000), payments(1, 300), fees(1, 20),
+				}, nil).Once()
+				repo.On("SaveAccrual", mock.Anything, mock.MatchedBy(func(accrual *models.InterestAccrual) bool {
+					return accrual.AccountID == 1 && accrual.AccrualDate.Equal(day) && accrual.Balance == -720 && accrual.Amount == 0.72
+				})).Return(true, nil).Once()
+				txnRepo.On("Save", mock.Anything, mock.MatchedBy(func(trx *models.Transaction) bool {
+					return trx.AccountID == 1 && trx.OperationTypeID == interestOperationTypeID && trx.Amount == 0.72
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.Transaction).TransactionID = 42
+				}).Return(nil).Once()
+				repo.On("SetAccrualTransaction", mock.Anything, mock.Anything, int64(42)).Return(nil).Once()
+			},
+			wantCharged: 1,
+		},
+		{
+			name: "does not charge accounts whose payments cover their purchases and fees",
+			mockFunc: func(repo *mocks.MockInterestRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("GetOperationTotals", mock.Anything, day.AddDate(0, 0, 1)).Return([]models.OperationTotal{
+					purchases(1, 100), payments(1, 105), fees(1, 5),
+					payments(2, 50),
+				}, nil).Once()
+			},
+		},
+		{
+			name: "charges only the accounts with a negative balance",
+			mockFunc: func(repo *mocks.MockInterestRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("GetOperationTotals", mock.Anything, day.AddDate(0, 0, 1)).Return([]models.OperationTotal{
+					purchases(1, 100), payments(1, 200),
+					fees(2, 1000),
+				}, nil).Once()
+				repo.On("SaveAccrual", mock.Anything, mock.MatchedBy(func(accrual *models.InterestAccrual) bool {
+					return accrual.AccountID == 2 && accrual.Balance == -1000 && accrual.Amount == 1
+				})).Return(true, nil).Once()
+				txnRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+				repo.On("SetAccrualTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			wantCharged: 1,
+		},
+		{
+			name: "skips accounts already accrued for the day",
+			mockFunc: func(repo *mocks.MockInterestRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("GetOperationTotals", mock.Anything, day.AddDate(0, 0, 1)).Return([]models.OperationTotal{
+					purchases(1, 1000),
+				}, nil).Once()
+				repo.On("SaveAccrual", mock.Anything, mock.Anything).Return(false, nil).Once()
+			},
+		},
+		{
+			name: "stops when posting the interest fails",
+			mockFunc: func(repo *mocks.MockInterestRepository, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("GetOperationTotals", mock.Anything, day.AddDate(0, 0, 1)).Return([]models.OperationTotal{
+					purchases(1, 1000), purchases(2, 1000),
+				}, nil).Once()
+				repo.On("SaveAccrual", mock.Anything, mock.Anything).Return(true, nil).Once()
+				txnRepo.On("Save", mock.Anything, mock.Anything).Return(errors.NewUnknownError("db error")).Once()
+			},
+			wantErr: errors.UnknownError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockInterestRepository{}
+			txnRepo := &transactionMocks.MockTransactionRepository{}
+			repo.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).Maybe()
+			tt.mockFunc(repo, txnRepo)
+
+			charged, err := NewInterestService(repo, txnRepo, cfg).AccrueDaily(context.Background(), date)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCharged, charged)
+			repo.AssertExpectations(t)
+			txnRepo.AssertExpectations(t)
+		})
+	}
+}
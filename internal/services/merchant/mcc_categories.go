@@ -0,0 +1,115 @@
+package merchant
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	mccLength = 4
+
+	// Uncategorized is reported for transactions without an MCC or with an MCC absent from the mapping.
+	Uncategorized = "uncategorized"
+)
+
+// Categories maps merchant category codes to spend categories. The source file lists, per category, single codes
+// ("5411") or inclusive ranges ("5811-5814").
+type Categories struct {
+	byMCC      map[string]string
+	byCategory map[string][]string
+}
+
+func LoadCategories(path string) (*Categories, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCategories(data)
+}
+
+func ParseCategories(data []byte) (*Categories, error) {
+	var raw map[string][]string
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	c := &Categories{
+		byMCC:      map[string]string{},
+		byCategory: map[string][]string{},
+	}
+	for category, entries := range raw {
+		for _, entry := range entries {
+			codes, err := expandMCCs(entry)
+			if err != nil {
+				return nil, fmt.Errorf("category %s: %w", category, err)
+			}
+			for _, code := range codes {
+				if existing, ok := c.byMCC[code]; ok && existing != category {
+					return nil, fmt.Errorf("mcc %s is mapped to both %s and %s", code, existing, category)
+				}
+				c.byMCC[code] = category
+			}
+		}
+	}
+
+	for code, category := range c.byMCC {
+		c.byCategory[category] = append(c.byCategory[category], code)
+	}
+	for category := range c.byCategory {
+		sort.Strings(c.byCategory[category])
+	}
+	return c, nil
+}
+
+func (c *Categories) Category(mcc *string) string {
+	if c == nil || mcc == nil {
+		return Uncategorized
+	}
+	if category, ok := c.byMCC[*mcc]; ok {
+		return category
+	}
+	return Uncategorized
+}
+
+// MCCs returns the codes mapped to the category, or nil if the category is unknown.
+func (c *Categories) MCCs(category string) []string {
+	if c == nil {
+		return nil
+	}
+	return c.byCategory[category]
+}
+
+func ValidMCC(mcc string) bool {
+	if len(mcc) != mccLength {
+		return false
+	}
+	_, err := strconv.Atoi(mcc)
+	return err == nil && !strings.ContainsAny(mcc, "+-")
+}
+
+func expandMCCs(entry string) ([]string, error) {
+	from, to, isRange := strings.Cut(strings.TrimSpace(entry), "-")
+	if !isRange {
+		to = from
+	}
+	if !ValidMCC(from) || !ValidMCC(to) {
+		return nil, fmt.Errorf("invalid mcc %q", entry)
+	}
+
+	start, _ := strconv.Atoi(from)
+	end, _ := strconv.Atoi(to)
+	if start > end {
+		return nil, fmt.Errorf("invalid mcc range %q", entry)
+	}
+
+	codes := make([]string, 0, end-start+1)
+	for code := start; code <= end; code++ {
+		codes = append(codes, fmt.Sprintf("%04d", code))
+	}
+	return codes, nil
+}
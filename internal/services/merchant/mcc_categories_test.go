@@ -0,0 +1,55 @@
+package merchant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCategories(t *testing.T) {
+	data := []byte(`
+groceries:
+  - "5411"
+  - "5499"
+restaurants:
+  - "5811-5814"
+`)
+
+	c, err := ParseCategories(data)
+	assert.NoError(t, err)
+
+	mcc := func(s string) *string { return &s }
+	assert.Equal(t, "groceries", c.Category(mcc("5411")))
+	assert.Equal(t, "restaurants", c.Category(mcc("5812")))
+	assert.Equal(t, Uncategorized, c.Category(mcc("5815")))
+	assert.Equal(t, Uncategorized, c.Category(nil))
+	assert.Equal(t, []string{"5811", "5812", "5813", "5814"}, c.MCCs("restaurants"))
+	assert.Nil(t, c.MCCs("travel"))
+}
+
+func TestParseCategories_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "non numeric mcc", data: "groceries: [\"54a1\"]"},
+		{name: "short mcc", data: "groceries: [\"541\"]"},
+		{name: "reversed range", data: "restaurants: [\"5814-5811\"]"},
+		{name: "mcc in two categories", data: "groceries: [\"5411\"]\nfood: [\"5400-5420\"]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCategories([]byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadCategories_ShippedConfig(t *testing.T) {
+	c, err := LoadCategories("../../../configs/mcc_categories.yml")
+	assert.NoError(t, err)
+
+	mcc := "5812"
+	assert.Equal(t, "restaurants", c.Category(&mcc))
+}
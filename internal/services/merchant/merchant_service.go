@@ -0,0 +1,59 @@
+package merchant
+
+import (
+	"context"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/merchant"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+type Service interface {
+	// Register adds the transaction's merchant to the registry the first time it is seen. Transactions without a
+	// merchant ID are ignored.
+	Register(ctx context.Context, trx *models.Transaction) error
+	Category(mcc *string) string
+	MCCs(category string) []string
+}
+
+type merchantService struct {
+	repo       merchant.Repository
+	categories *Categories
+}
+
+func NewMerchantService(repo merchant.Repository, categories *Categories) Service {
+	return &merchantService{
+		repo:       repo,
+		categories: categories,
+	}
+}
+
+func (service *merchantService) Register(ctx context.Context, trx *models.Transaction) error {
+	if trx.MerchantID == nil {
+		return nil
+	}
+
+	inserted, err := service.repo.SaveIfAbsent(ctx, &models.Merchant{
+		MerchantID: *trx.MerchantID,
+		Name:       trx.MerchantName,
+		MCC:        trx.MCC,
+		City:       trx.MerchantCity,
+		Country:    trx.MerchantCountry,
+	})
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Error while registering merchant %s Error: %s", *trx.MerchantID, err.Error())
+		return err
+	}
+	if inserted {
+		logger.WithContext(ctx).Infof("Registered new merchant %s", *trx.MerchantID)
+	}
+	return nil
+}
+
+func (service *merchantService) Category(mcc *string) string {
+	return service.categories.Category(mcc)
+}
+
+func (service *merchantService) MCCs(category string) []string {
+	return service.categories.MCCs(category)
+}
@@ -0,0 +1,86 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+)
+
+// MockMerchantService is an autogenerated mock type for the Service type
+type MockMerchantService struct {
+	mock.Mock
+}
+
+// Category provides a mock function with given fields: mcc
+func (_m *MockMerchantService) Category(mcc *string) string {
+	ret := _m.Called(mcc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Category")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*string) string); ok {
+		r0 = rf(mcc)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MCCs provides a mock function with given fields: category
+func (_m *MockMerchantService) MCCs(category string) []string {
+	ret := _m.Called(category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MCCs")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(category)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// Register provides a mock function with given fields: ctx, trx
+func (_m *MockMerchantService) Register(ctx context.Context, trx *models.Transaction) error {
+	ret := _m.Called(ctx, trx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction) error); ok {
+		r0 = rf(ctx, trx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockMerchantService creates a new instance of MockMerchantService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMerchantService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMerchantService {
+	mock := &MockMerchantService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+package operationtype
+
+import (
+	"context"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository"
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
+	contextWrapper "github.com/shahbaz275817/prismo/pkg/context"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+type cachedOperationTypeService struct {
+	Service
+	cache inmemory.InMemCache
+}
+
+// NewCachedOperationTypeService serves lookups of operation types by id from cache, which must be built with
+// CacheLoader for service. Other lookups and writes go to service, and updates drop the operation type from the cache.
+func NewCachedOperationTypeService(service Service, cache inmemory.InMemCache) Service {
+	return &cachedOperationTypeService{
+		Service: service,
+		cache:   cache,
+	}
+}
+
+// CacheLoader loads operation types missing from the cache built for NewCachedOperationTypeService. Operation types
+// that do not exist are reported as inmemory.ErrNotFound, so they are cached for the cache's negative TTL.
+func CacheLoader(service Service) inmemory.LoaderFunc {
+	return func(key interface{}) (interface{}, error) {
+		tenantID, id, err := inmemory.ParseTenantKey(key)
+		if err != nil {
+			return nil, err
+		}
+		// loads follow invalidations, so they read the primary to not cache what the replica has not seen yet
+		ctx := repository.WithPrimaryReads(contextWrapper.WithTenantID(context.Background(), tenantID))
+		ot, err := service.Get(ctx, &models.OperationsType{OperationTypeID: id})
+		if err != nil {
+			return nil, err
+		}
+		if ot == nil {
+			return nil, inmemory.ErrNotFound
+		}
+		return ot, nil
+	}
+}
+
+func (service *cachedOperationTypeService) Get(ctx context.Context, query *models.OperationsType) (*models.OperationsType, error) {
+	key, ok := service.cacheKey(ctx, query.OperationTypeID)
+	if !ok || *query != (models.OperationsType{OperationTypeID: query.OperationTypeID}) {
+		return service.Service.Get(ctx, query)
+	}
+
+	val, err := service.cache.LoadValue(ctx, key)
+	if errors.Is(err, inmemory.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// callers get a copy so they cannot change the cached operation type
+	ot := *val.(*models.OperationsType)
+	return &ot, nil
+}
+
+func (service *cachedOperationTypeService) Update(ctx context.Context, operationType *models.OperationsType, update *models.OperationsType) error {
+	// the operation type may have changed even when the update failed, e.g. on a version conflict
+	defer service.invalidate(ctx, operationType.OperationTypeID)
+	return service.Service.Update(ctx, operationType, update)
+}
+
+func (service *cachedOperationTypeService) invalidate(ctx context.Context, operationTypeID int64) {
+	if key, ok := service.cacheKey(ctx, operationTypeID); ok {
+		service.cache.RemoveKey(ctx, key)
+	}
+}
+
+// cacheKey identifies a cached operation type. The tenant is part of the key because tenants see their own operation
+// types besides the shared ones, and the loader has no request context. It reports false for lookups that cannot be
+// cached: those without an operation type id or a tenant.
+func (service *cachedOperationTypeService) cacheKey(ctx context.Context, operationTypeID int64) (string, bool) {
+	tenantID, ok := contextWrapper.TenantID(ctx)
+	if !ok || operationTypeID == 0 {
+		return "", false
+	}
+	return inmemory.TenantKey(tenantID, operationTypeID), true
+}
@@ -0,0 +1,67 @@
+package operationtype
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/services/operationtype/mocks"
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
+	contextWrapper "github.com/shahbaz275817/prismo/pkg/context"
+)
+
+func newTestCachedOperationTypeService(t *testing.T) (Service, *mocks.MockOperationtypeService) {
+	inner := &mocks.MockOperationtypeService{}
+	cache, err := inmemory.NewInMemCache(inmemory.CacheConfig{
+		LoaderFunc:         CacheLoader(inner),
+		TTLSeconds:         60,
+		NegativeTTLSeconds: 5,
+		Size:               10,
+		Name:               "operation_types",
+	})
+	assert.NoError(t, err)
+	t.Cleanup(func() { inner.AssertExpectations(t) })
+	return NewCachedOperationTypeService(inner, cache), inner
+}
+
+func TestCachedOperationTypeService_Get(t *testing.T) {
+	service, inner := newTestCachedOperationTypeService(t)
+	inner.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: 1}).Return(&models.OperationsType{OperationTypeID: 1, Description: "Normal Purchase"}, nil).Once()
+	inner.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: 99}).Return(nil, nil).Once()
+
+	tenant1 := contextWrapper.WithTenantID(context.Background(), 1)
+	for i := 0; i < 2; i++ {
+		ot, err := service.Get(tenant1, &models.OperationsType{OperationTypeID: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, "Normal Purchase", ot.Description)
+
+		missing, err := service.Get(tenant1, &models.OperationsType{OperationTypeID: 99})
+		assert.NoError(t, err)
+		assert.Nil(t, missing)
+	}
+
+	// every tenant has its own entries, since tenants can have their own operation types
+	inner.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: 1}).Return(&models.OperationsType{OperationTypeID: 1, Description: "Normal Purchase"}, nil).Once()
+	_, err := service.Get(contextWrapper.WithTenantID(context.Background(), 2), &models.OperationsType{OperationTypeID: 1})
+	assert.NoError(t, err)
+}
+
+func TestCachedOperationTypeService_UpdateInvalidates(t *testing.T) {
+	ctx := contextWrapper.WithTenantID(context.Background(), 1)
+	service, inner := newTestCachedOperationTypeService(t)
+	ot := &models.OperationsType{OperationTypeID: 1, Version: 1}
+	update := &models.OperationsType{Description: "Purchase"}
+	inner.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: 1}).Return(&models.OperationsType{OperationTypeID: 1, Description: "Normal Purchase"}, nil).Once()
+	inner.On("Update", mock.Anything, ot, update).Return(nil).Once()
+	inner.On("Get", mock.Anything, &models.OperationsType{OperationTypeID: 1}).Return(&models.OperationsType{OperationTypeID: 1, Description: "Purchase"}, nil).Once()
+
+	_, _ = service.Get(ctx, &models.OperationsType{OperationTypeID: 1})
+	assert.NoError(t, service.Update(ctx, ot, update))
+	after, err := service.Get(ctx, &models.OperationsType{OperationTypeID: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Purchase", after.Description)
+}
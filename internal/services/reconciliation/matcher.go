@@ -0,0 +1,155 @@
+package reconciliation
+
+import (
+	"math"
+	"time"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const (
+	// MatchByExternalReference pairs records on the processor reference and then compares amounts.
+	MatchByExternalReference = "external_reference"
+	// MatchByAmountAndDate pairs records on account and amount when the event dates fall inside the date window.
+	MatchByAmountAndDate = "amount_date"
+)
+
+type MatchConfig struct {
+	MatchKey        string
+	DateWindow      time.Duration
+	AmountTolerance float64
+	// InternalOperationTypeIDs are the operation types the ledger posts on its own, such as fees, interest and
+	// transfers. The processor never reports them, so they are left out of the reconciliation.
+	InternalOperationTypeIDs []int64
+}
+
+func (cfg MatchConfig) Validate() error {
+	if cfg.MatchKey != MatchByExternalReference && cfg.MatchKey != MatchByAmountAndDate {
+		return errors.Errorf("unknown reconciliation match key %s", cfg.MatchKey)
+	}
+	if cfg.DateWindow < 0 {
+		return errors.New("reconciliation date window should not be negative")
+	}
+	return nil
+}
+
+// Classify pairs settlement records with internal transactions and returns one result per external record plus one
+// per internal transaction the processor did not report. Each internal transaction is matched at most once.
+// Transactions dated outside the records' dates are only candidates for a match: they belong to the files of other
+// days, so they are not reported as missing.
+func Classify(cfg MatchConfig, records []SettlementRecord, transactions []models.Transaction) []models.ReconciliationResult {
+	transactions = withoutInternalOperations(cfg, transactions)
+	used := make([]bool, len(transactions))
+	results := make([]models.ReconciliationResult, 0, len(records))
+
+	for _, rec := range records {
+		idx := findMatch(cfg, rec, transactions, used)
+		if idx < 0 {
+			results = append(results, externalResult(rec, models.ReconciliationMissingInternally))
+			continue
+		}
+
+		used[idx] = true
+		status := models.ReconciliationMatched
+		if !amountsEqual(rec.Amount, transactions[idx].Amount, cfg.AmountTolerance) {
+			status = models.ReconciliationAmountMismatch
+		}
+		res := externalResult(rec, status)
+		setInternal(&res, transactions[idx])
+		results = append(results, res)
+	}
+
+	if len(records) == 0 {
+		return results
+	}
+	from, to := settlementDateRange(records)
+	for i, trx := range transactions {
+		if used[i] || trx.EventDate.Before(from) || trx.EventDate.After(to) {
+			continue
+		}
+		res := models.ReconciliationResult{Status: models.ReconciliationMissingExternally}
+		setInternal(&res, trx)
+		results = append(results, res)
+	}
+
+	return results
+}
+
+func withoutInternalOperations(cfg MatchConfig, transactions []models.Transaction) []models.Transaction {
+	if len(cfg.InternalOperationTypeIDs) == 0 {
+		return transactions
+	}
+	internal := make(map[int64]bool, len(cfg.InternalOperationTypeIDs))
+	for _, id := range cfg.InternalOperationTypeIDs {
+		internal[id] = true
+	}
+	external := make([]models.Transaction, 0, len(transactions))
+	for _, trx := range transactions {
+		if !internal[trx.OperationTypeID] {
+			external = append(external, trx)
+		}
+	}
+	return external
+}
+
+func findMatch(cfg MatchConfig, rec SettlementRecord, transactions []models.Transaction, used []bool) int {
+	for i, trx := range transactions {
+		if used[i] {
+			continue
+		}
+
+		switch cfg.MatchKey {
+		case MatchByExternalReference:
+			if trx.ExternalReference != nil && *trx.ExternalReference == rec.ExternalReference {
+				return i
+			}
+		case MatchByAmountAndDate:
+			if trx.AccountID == rec.AccountID &&
+				amountsEqual(rec.Amount, trx.Amount, cfg.AmountTolerance) &&
+				withinWindow(rec.EventDate, trx.EventDate, cfg.DateWindow) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func externalResult(rec SettlementRecord, status models.ReconciliationStatus) models.ReconciliationResult {
+	ref := rec.ExternalReference
+	accountID := rec.AccountID
+	amount := rec.Amount
+	eventDate := rec.EventDate
+	return models.ReconciliationResult{
+		Status:            status,
+		AccountID:         &accountID,
+		ExternalReference: &ref,
+		ExternalAmount:    &amount,
+		ExternalEventDate: &eventDate,
+	}
+}
+
+func setInternal(res *models.ReconciliationResult, trx models.Transaction) {
+	trxID := trx.TransactionID
+	accountID := trx.AccountID
+	amount := trx.Amount
+	res.TransactionID = &trxID
+	res.AccountID = &accountID
+	res.InternalAmount = &amount
+	if res.ExternalReference == nil && trx.ExternalReference != nil {
+		ref := *trx.ExternalReference
+		res.ExternalReference = &ref
+	}
+}
+
+func amountsEqual(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func withinWindow(a, b time.Time, window time.Duration) bool {
+	diff := a.Sub(b)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= window
+}
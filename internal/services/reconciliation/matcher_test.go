@@ -0,0 +1,127 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+)
+
+func TestClassify(t *testing.T) {
+	day := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	ref := func(s string) *string { return &s }
+
+	transactions := []models.Transaction{
+		{TransactionID: 1, AccountID: 10, Amount: 50, EventDate: day, ExternalReference: ref("ref-1")},
+		{TransactionID: 2, AccountID: 10, Amount: 20, EventDate: day, ExternalReference: ref("ref-2")},
+		{TransactionID: 3, AccountID: 11, Amount: 75.5, EventDate: day.Add(2 * time.Hour)},
+	}
+
+	tests := []struct {
+		name    string
+		cfg     MatchConfig
+		records []SettlementRecord
+		want    map[models.ReconciliationStatus][]int64
+	}{
+		{
+			name: "classifies on external reference",
+			cfg:  MatchConfig{MatchKey: MatchByExternalReference, DateWindow: time.Hour, AmountTolerance: 0.005},
+			records: []SettlementRecord{
+				{ExternalReference: "ref-1", AccountID: 10, Amount: 50, EventDate: day},
+				{ExternalReference: "ref-2", AccountID: 10, Amount: 25, EventDate: day},
+				{ExternalReference: "ref-9", AccountID: 12, Amount: 5, EventDate: day},
+			},
+			want: map[models.ReconciliationStatus][]int64{
+				models.ReconciliationMatched:           {1},
+				models.ReconciliationAmountMismatch:    {2},
+				models.ReconciliationMissingInternally: {0},
+			},
+		},
+		{
+			name: "classifies on amount and date window",
+			cfg:  MatchConfig{MatchKey: MatchByAmountAndDate, DateWindow: time.Hour, AmountTolerance: 0.005},
+			records: []SettlementRecord{
+				{ExternalReference: "x-1", AccountID: 10, Amount: 50, EventDate: day.Add(30 * time.Minute)},
+				{ExternalReference: "x-2", AccountID: 11, Amount: 75.5, EventDate: day},
+			},
+			want: map[models.ReconciliationStatus][]int64{
+				models.ReconciliationMatched:           {1},
+				models.ReconciliationMissingInternally: {0},
+				models.ReconciliationMissingExternally: {2},
+			},
+		},
+		{
+			name: "does not match the same transaction twice",
+			cfg:  MatchConfig{MatchKey: MatchByExternalReference, DateWindow: time.Hour, AmountTolerance: 0.005},
+			records: []SettlementRecord{
+				{ExternalReference: "ref-1", AccountID: 10, Amount: 50, EventDate: day},
+				{ExternalReference: "ref-1", AccountID: 10, Amount: 50, EventDate: day},
+			},
+			want: map[models.ReconciliationStatus][]int64{
+				models.ReconciliationMatched:           {1},
+				models.ReconciliationMissingInternally: {0},
+				models.ReconciliationMissingExternally: {2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Classify(tt.cfg, tt.records, transactions)
+
+			got := map[models.ReconciliationStatus][]int64{}
+			for _, res := range results {
+				var id int64
+				if res.TransactionID != nil {
+					id = *res.TransactionID
+				}
+				got[res.Status] = append(got[res.Status], id)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClassify_ReportsMissingOnlyForProcessorTransactionsOfTheFileDates(t *testing.T) {
+	day := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	ref := func(s string) *string { return &s }
+	cfg := MatchConfig{MatchKey: MatchByExternalReference, DateWindow: 24 * time.Hour, InternalOperationTypeIDs: []int64{5, 6}}
+
+	transactions := []models.Transaction{
+		{TransactionID: 1, AccountID: 10, OperationTypeID: 1, Amount: 50, EventDate: day.Add(-2 * time.Hour), ExternalReference: ref("ref-1")},
+		{TransactionID: 2, AccountID: 10, OperationTypeID: 1, Amount: 20, EventDate: day},
+		{TransactionID: 3, AccountID: 10, OperationTypeID: 1, Amount: 30, EventDate: day.Add(-20 * time.Hour)},
+		{TransactionID: 4, AccountID: 10, OperationTypeID: 5, Amount: 1, EventDate: day},
+		{TransactionID: 5, AccountID: 10, OperationTypeID: 6, Amount: 2, EventDate: day.Add(time.Hour)},
+	}
+	records := []SettlementRecord{
+		{ExternalReference: "ref-1", AccountID: 10, Amount: 50, EventDate: day},
+		{ExternalReference: "ref-2", AccountID: 10, Amount: 40, EventDate: day.Add(time.Hour)},
+	}
+
+	results := Classify(cfg, records, transactions)
+
+	got := map[models.ReconciliationStatus][]int64{}
+	for _, res := range results {
+		var id int64
+		if res.TransactionID != nil {
+			id = *res.TransactionID
+		}
+		got[res.Status] = append(got[res.Status], id)
+	}
+	// a transaction of the previous day still matches, but is not missing from this file when unmatched
+	assert.Equal(t, map[models.ReconciliationStatus][]int64{
+		models.ReconciliationMatched:           {1},
+		models.ReconciliationMissingInternally: {0},
+		models.ReconciliationMissingExternally: {2},
+	}, got)
+}
+
+func TestMatchConfigValidate(t *testing.T) {
+	assert.NoError(t, MatchConfig{MatchKey: MatchByExternalReference}.Validate())
+	assert.NoError(t, MatchConfig{MatchKey: MatchByAmountAndDate, DateWindow: time.Hour}.Validate())
+	assert.Error(t, MatchConfig{MatchKey: "card_number"}.Validate())
+	assert.Error(t, MatchConfig{MatchKey: MatchByAmountAndDate, DateWindow: -time.Hour}.Validate())
+}
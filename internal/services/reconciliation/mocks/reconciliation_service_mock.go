@@ -0,0 +1,121 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	reconciliation "github.com/shahbaz275817/prismo/internal/services/reconciliation"
+)
+
+// MockReconciliationService is an autogenerated mock type for the Service type
+type MockReconciliationService struct {
+	mock.Mock
+}
+
+// GetRun provides a mock function with given fields: ctx, runID
+func (_m *MockReconciliationService) GetRun(ctx context.Context, runID int64) (*models.ReconciliationRun, error) {
+	ret := _m.Called(ctx, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRun")
+	}
+
+	var r0 *models.ReconciliationRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.ReconciliationRun, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.ReconciliationRun); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ReconciliationRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSummary provides a mock function with given fields: ctx, runID
+func (_m *MockReconciliationService) GetSummary(ctx context.Context, runID int64) ([]models.ReconciliationStatusSummary, error) {
+	ret := _m.Called(ctx, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSummary")
+	}
+
+	var r0 []models.ReconciliationStatusSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]models.ReconciliationStatusSummary, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []models.ReconciliationStatusSummary); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReconciliationStatusSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reconcile provides a mock function with given fields: ctx, fileName, records
+func (_m *MockReconciliationService) Reconcile(ctx context.Context, fileName string, records []reconciliation.SettlementRecord) (*models.ReconciliationRun, error) {
+	ret := _m.Called(ctx, fileName, records)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reconcile")
+	}
+
+	var r0 *models.ReconciliationRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []reconciliation.SettlementRecord) (*models.ReconciliationRun, error)); ok {
+		return rf(ctx, fileName, records)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []reconciliation.SettlementRecord) *models.ReconciliationRun); ok {
+		r0 = rf(ctx, fileName, records)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ReconciliationRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []reconciliation.SettlementRecord) error); ok {
+		r1 = rf(ctx, fileName, records)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockReconciliationService creates a new instance of MockReconciliationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReconciliationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReconciliationService {
+	mock := &MockReconciliationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
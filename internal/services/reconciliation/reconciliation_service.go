@@ -0,0 +1,95 @@
+package reconciliation
+
+import (
+	"context"
+	"time"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/reconciliation"
+	"github.com/shahbaz275817/prismo/internal/repository/transaction"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+type Service interface {
+	Reconcile(ctx context.Context, fileName string, records []SettlementRecord) (*models.ReconciliationRun, error)
+	GetRun(ctx context.Context, runID int64) (*models.ReconciliationRun, error)
+	GetSummary(ctx context.Context, runID int64) ([]models.ReconciliationStatusSummary, error)
+}
+
+type reconciliationService struct {
+	repo    reconciliation.Repository
+	txnRepo transaction.Repository
+	cfg     MatchConfig
+}
+
+func NewReconciliationService(repo reconciliation.Repository, txnRepo transaction.Repository, cfg MatchConfig) Service {
+	return &reconciliationService{
+		repo:    repo,
+		txnRepo: txnRepo,
+		cfg:     cfg,
+	}
+}
+
+func (service *reconciliationService) Reconcile(ctx context.Context, fileName string, records []SettlementRecord) (*models.ReconciliationRun, error) {
+	if err := service.cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("settlement file has no records")
+	}
+
+	from, to := settlementDateRange(records)
+	transactions, err := service.txnRepo.GetAllByEventDateRange(ctx, from.Add(-service.cfg.DateWindow), to.Add(service.cfg.DateWindow))
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Error while fetching transactions for reconciliation Error: %s", err.Error())
+		return nil, err
+	}
+
+	results := Classify(service.cfg, records, transactions)
+
+	run := models.ReconciliationRun{
+		FileName:     fileName,
+		MatchKey:     service.cfg.MatchKey,
+		TotalRecords: len(records),
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	err = service.repo.Transact(ctx, func(ctx context.Context) error {
+		if err := service.repo.SaveRun(ctx, &run); err != nil {
+			return err
+		}
+		for i := range results {
+			results[i].RunID = run.RunID
+			results[i].CreatedAt = run.CreatedAt
+		}
+		return service.repo.SaveResults(ctx, results)
+	})
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Error while saving reconciliation results Error: %s", err.Error())
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+func (service *reconciliationService) GetRun(ctx context.Context, runID int64) (*models.ReconciliationRun, error) {
+	return service.repo.GetRun(ctx, &models.ReconciliationRun{RunID: runID})
+}
+
+func (service *reconciliationService) GetSummary(ctx context.Context, runID int64) ([]models.ReconciliationStatusSummary, error) {
+	return service.repo.GetSummary(ctx, runID)
+}
+
+func settlementDateRange(records []SettlementRecord) (time.Time, time.Time) {
+	from, to := records[0].EventDate, records[0].EventDate
+	for _, rec := range records[1:] {
+		if rec.EventDate.Before(from) {
+			from = rec.EventDate
+		}
+		if rec.EventDate.After(to) {
+			to = rec.EventDate
+		}
+	}
+	return from, to
+}
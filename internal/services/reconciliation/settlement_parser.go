@@ -0,0 +1,114 @@
+package reconciliation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+const (
+	columnExternalReference = "external_reference"
+	columnAccountID         = "account_id"
+	columnAmount            = "amount"
+	columnEventDate         = "event_date"
+
+	settlementDateLayout = "2006-01-02"
+)
+
+var requiredColumns = []string{columnExternalReference, columnAccountID, columnAmount, columnEventDate}
+
+// SettlementRecord is a single line of the processor's daily settlement file.
+type SettlementRecord struct {
+	ExternalReference string
+	AccountID         int64
+	Amount            float64
+	EventDate         time.Time
+}
+
+// ParseSettlementCSV reads a settlement file with a header row. Columns are looked up by name, so the processor may
+// reorder them or add columns we do not use.
+func ParseSettlementCSV(r io.Reader) ([]SettlementRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("settlement file is empty")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read settlement file header")
+	}
+
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		index[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	for _, col := range requiredColumns {
+		if _, ok := index[col]; !ok {
+			return nil, errors.Errorf("settlement file is missing column %s", col)
+		}
+	}
+
+	var records []SettlementRecord
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("unable to read settlement file line %d", line))
+		}
+
+		record, err := parseSettlementRow(row, index)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid settlement file line %d", line))
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func parseSettlementRow(row []string, index map[string]int) (SettlementRecord, error) {
+	ref := strings.TrimSpace(row[index[columnExternalReference]])
+	if ref == "" {
+		return SettlementRecord{}, errors.New("external_reference is empty")
+	}
+
+	accountID, err := strconv.ParseInt(strings.TrimSpace(row[index[columnAccountID]]), 10, 64)
+	if err != nil {
+		return SettlementRecord{}, errors.New("account_id should be a number")
+	}
+
+	amount, err := strconv.ParseFloat(strings.TrimSpace(row[index[columnAmount]]), 64)
+	if err != nil {
+		return SettlementRecord{}, errors.New("amount should be a number")
+	}
+
+	eventDate, err := parseSettlementDate(strings.TrimSpace(row[index[columnEventDate]]))
+	if err != nil {
+		return SettlementRecord{}, err
+	}
+
+	return SettlementRecord{
+		ExternalReference: ref,
+		AccountID:         accountID,
+		Amount:            amount,
+		EventDate:         eventDate,
+	}, nil
+}
+
+func parseSettlementDate(val string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse(settlementDateLayout, val); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Time{}, errors.New("event_date should be RFC3339 or YYYY-MM-DD")
+}
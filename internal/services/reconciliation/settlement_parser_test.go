@@ -0,0 +1,56 @@
+package reconciliation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSettlementCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []SettlementRecord
+		wantErr bool
+	}{
+		{
+			name: "parses records with reordered columns",
+			input: "amount,event_date,account_id,external_reference,merchant\n" +
+				"12.50,2024-03-01,7,ref-1,acme\n" +
+				"3,2024-03-01T10:15:00Z,8,ref-2,acme\n",
+			want: []SettlementRecord{
+				{ExternalReference: "ref-1", AccountID: 7, Amount: 12.5, EventDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+				{ExternalReference: "ref-2", AccountID: 8, Amount: 3, EventDate: time.Date(2024, 3, 1, 10, 15, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:    "fails when a required column is missing",
+			input:   "external_reference,amount,event_date\nref-1,1,2024-03-01\n",
+			wantErr: true,
+		},
+		{
+			name:    "fails on an invalid amount",
+			input:   "external_reference,account_id,amount,event_date\nref-1,1,abc,2024-03-01\n",
+			wantErr: true,
+		},
+		{
+			name:    "fails on an empty file",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSettlementCSV(strings.NewReader(tt.input))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -76,18 +76,18 @@ func (_m *MockTransactionService) Get(ctx context.Context, query *models.Transac
 	return r0, r1
 }
 
-// GetAllWithCount provides a mock function with given fields: ctx, query, request
-func (_m *MockTransactionService) GetAllWithCount(ctx context.Context, query *models.Transaction, request repository.FilterRequest) ([]models.Transaction, int64, error) {
+// GetPage provides a mock function with given fields: ctx, query, request
+func (_m *MockTransactionService) GetPage(ctx context.Context, query *models.Transaction, request repository.FilterRequest) ([]models.Transaction, repository.Page, error) {
 	ret := _m.Called(ctx, query, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllWithCount")
+		panic("no return value specified for GetPage")
 	}
 
 	var r0 []models.Transaction
-	var r1 int64
+	var r1 repository.Page
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction, repository.FilterRequest) ([]models.Transaction, int64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction, repository.FilterRequest) ([]models.Transaction, repository.Page, error)); ok {
 		return rf(ctx, query, request)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction, repository.FilterRequest) []models.Transaction); ok {
@@ -98,10 +98,10 @@ func (_m *MockTransactionService) GetAllWithCount(ctx context.Context, query *mo
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *models.Transaction, repository.FilterRequest) int64); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Transaction, repository.FilterRequest) repository.Page); ok {
 		r1 = rf(ctx, query, request)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(repository.Page)
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, *models.Transaction, repository.FilterRequest) error); ok {
@@ -131,9 +131,9 @@ func (_m *MockTransactionService) Transact(ctx context.Context, f func(context.C
 	return r0
 }
 
-// Update provides a mock function with given fields: ctx, transaction, update
-func (_m *MockTransactionService) Update(ctx context.Context, transaction *models.Transaction, update *models.Transaction) error {
-	ret := _m.Called(ctx, transaction, update)
+// Update provides a mock function with given fields: ctx, _a1, update
+func (_m *MockTransactionService) Update(ctx context.Context, _a1 *models.Transaction, update *models.Transaction) error {
+	ret := _m.Called(ctx, _a1, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
@@ -141,7 +141,7 @@ func (_m *MockTransactionService) Update(ctx context.Context, transaction *model
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, *models.Transaction, *models.Transaction) error); ok {
-		r0 = rf(ctx, transaction, update)
+		r0 = rf(ctx, _a1, update)
 	} else {
 		r0 = ret.Error(0)
 	}
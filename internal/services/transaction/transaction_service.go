@@ -6,24 +6,30 @@ import (
 	"github.com/shahbaz275817/prismo/internal/models"
 	"github.com/shahbaz275817/prismo/internal/repository"
 	"github.com/shahbaz275817/prismo/internal/repository/transaction"
+	"github.com/shahbaz275817/prismo/internal/services/fee"
+	"github.com/shahbaz275817/prismo/internal/services/merchant"
 	"github.com/shahbaz275817/prismo/pkg/logger"
 )
 
 type Service interface {
 	Get(ctx context.Context, query *models.Transaction) (transaction *models.Transaction, err error)
-	GetAllWithCount(ctx context.Context, query *models.Transaction, request repository.FilterRequest) ([]models.Transaction, int64, error)
+	GetPage(ctx context.Context, query *models.Transaction, request repository.FilterRequest) ([]models.Transaction, repository.Page, error)
 	Create(ctx context.Context, trx models.Transaction) (transaction *models.Transaction, err error)
 	Update(ctx context.Context, transaction *models.Transaction, update *models.Transaction) error
 	Transact(ctx context.Context, f func(ctx context.Context) error) error
 }
 
 type transactionService struct {
-	repo transaction.Repository
+	repo            transaction.Repository
+	feeService      fee.Service
+	merchantService merchant.Service
 }
 
-func NewTransactionService(repo transaction.Repository) Service {
+func NewTransactionService(repo transaction.Repository, feeService fee.Service, merchantService merchant.Service) Service {
 	return &transactionService{
-		repo: repo,
+		repo:            repo,
+		feeService:      feeService,
+		merchantService: merchantService,
 	}
 }
 
@@ -31,8 +37,8 @@ func (service *transactionService) Get(ctx context.Context, query *models.Transa
 	return service.repo.Get(ctx, query)
 }
 
-func (service *transactionService) GetAllWithCount(ctx context.Context, query *models.Transaction, request repository.FilterRequest) ([]models.Transaction, int64, error) {
-	return service.repo.GetAllWithCount(ctx, query, request)
+func (service *transactionService) GetPage(ctx context.Context, query *models.Transaction, request repository.FilterRequest) ([]models.Transaction, repository.Page, error) {
+	return service.repo.List(ctx, query, request)
 }
 
 func (service *transactionService) Create(ctx context.Context, trx models.Transaction) (*models.Transaction, error) {
@@ -43,7 +49,13 @@ func (service *transactionService) Create(ctx context.Context, trx models.Transa
 			logger.WithContext(ctx).Errorf("Error while saving transaction Error: %s", err.Error())
 			return err
 		}
-		return nil
+
+		if err := service.merchantService.Register(ctx, &trx); err != nil {
+			return err
+		}
+
+		_, err = service.feeService.Apply(ctx, &trx)
+		return err
 	})
 
 	if err != nil {
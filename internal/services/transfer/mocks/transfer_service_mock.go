@@ -0,0 +1,66 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/shahbaz275817/prismo/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTransferService is an autogenerated mock type for the Service type
+type MockTransferService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, trf
+func (_m *MockTransferService) Create(ctx context.Context, trf models.Transfer) (*models.Transfer, bool, error) {
+	ret := _m.Called(ctx, trf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.Transfer
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Transfer) (*models.Transfer, bool, error)); ok {
+		return rf(ctx, trf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Transfer) *models.Transfer); ok {
+		r0 = rf(ctx, trf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Transfer) bool); ok {
+		r1 = rf(ctx, trf)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.Transfer) error); ok {
+		r2 = rf(ctx, trf)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewMockTransferService creates a new instance of MockTransferService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransferService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransferService {
+	mock := &MockTransferService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,145 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	"github.com/shahbaz275817/prismo/internal/repository/transaction"
+	"github.com/shahbaz275817/prismo/internal/repository/transfer"
+	"github.com/shahbaz275817/prismo/internal/services/account"
+	"github.com/shahbaz275817/prismo/internal/utils"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/locks"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+type Service interface {
+	// Create debits the source account and credits the destination account atomically. Retrying with the same client
+	// reference returns the original transfer and false instead of moving the money again.
+	Create(ctx context.Context, trf models.Transfer) (*models.Transfer, bool, error)
+}
+
+type Config struct {
+	TransferOutOperationTypeID int64
+	TransferInOperationTypeID  int64
+}
+
+type transferService struct {
+	repo           transfer.Repository
+	accountService account.Service
+	txnRepo        transaction.Repository
+	lock           *locks.AtomicLock
+	cfg            Config
+}
+
+// NewTransferService posts the legs of transfers straight through txnRepo: moving money between accounts is not a
+// purchase, so it is neither charged fees nor registered with a merchant.
+func NewTransferService(repo transfer.Repository, accountService account.Service, txnRepo transaction.Repository, lock *locks.AtomicLock, cfg Config) Service {
+	return &transferService{
+		repo:           repo,
+		accountService: accountService,
+		txnRepo:        txnRepo,
+		lock:           lock,
+		cfg:            cfg,
+	}
+}
+
+func (service *transferService) Create(ctx context.Context, trf models.Transfer) (*models.Transfer, bool, error) {
+	var result *models.Transfer
+	var created bool
+
+	// The client reference is locked along with both accounts so concurrent retries of the same transfer serialize.
+	keys := []string{
+		utils.BuildLockKey("account", trf.SourceAccountID),
+		utils.BuildLockKey("account", trf.DestinationAccountID),
+		utils.BuildLockKey("transfer", trf.ClientReference),
+	}
+
+	lockState := locks.LockState{}
+	_, err := service.lock.ExecuteAll(ctx, keys, locks.AccountPosting, func(lockState *locks.LockState) ([]interface{}, error) {
+		return nil, service.repo.Transact(ctx, func(ctx context.Context) error {
+			existing, err := service.repo.Get(ctx, &models.Transfer{ClientReference: trf.ClientReference})
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				if !sameTransfer(*existing, trf) {
+					return errors.NewStatusUnprocessableEntity("client_reference_reused", &errors.ErrDetails{
+						Message: "client_reference was already used for a different transfer",
+					})
+				}
+				result = existing
+				return nil
+			}
+
+			if err := service.checkAccounts(ctx, trf); err != nil {
+				return err
+			}
+
+			now := time.Now().UTC()
+			debit := models.Transaction{
+				AccountID:       trf.SourceAccountID,
+				OperationTypeID: service.cfg.TransferOutOperationTypeID,
+				Amount:          trf.Amount,
+				EventDate:       now,
+			}
+			if err := service.txnRepo.Save(ctx, &debit); err != nil {
+				return err
+			}
+
+			credit := models.Transaction{
+				AccountID:       trf.DestinationAccountID,
+				OperationTypeID: service.cfg.TransferInOperationTypeID,
+				Amount:          trf.Amount,
+				EventDate:       now,
+			}
+			if err := service.txnRepo.Save(ctx, &credit); err != nil {
+				return err
+			}
+
+			trf.DebitTransactionID = debit.TransactionID
+			trf.CreditTransactionID = credit.TransactionID
+			trf.CreatedAt = now
+			if err := service.repo.Save(ctx, &trf); err != nil {
+				return err
+			}
+
+			result, created = &trf, true
+			return nil
+		})
+	}, &lockState)
+
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Error while creating transfer %s Error: %s", trf.ClientReference, err.Error())
+		return nil, false, err
+	}
+	return result, created, nil
+}
+
+func (service *transferService) checkAccounts(ctx context.Context, trf models.Transfer) error {
+	for _, accountID := range []int64{trf.SourceAccountID, trf.DestinationAccountID} {
+		acc, err := service.accountService.Get(ctx, &models.Account{AccountID: accountID})
+		if err != nil {
+			return err
+		}
+		if acc == nil {
+			return errors.NewNotFoundError("account_not_found", &errors.ErrDetails{
+				Message: fmt.Sprintf("account %d not found", accountID),
+			})
+		}
+		if acc.Erased() {
+			return errors.NewStatusUnprocessableEntity("account_erased", &errors.ErrDetails{
+				Message: fmt.Sprintf("account %d is erased", accountID),
+			})
+		}
+	}
+	return nil
+}
+
+func sameTransfer(a, b models.Transfer) bool {
+	return a.SourceAccountID == b.SourceAccountID &&
+		a.DestinationAccountID == b.DestinationAccountID &&
+		a.Amount == b.Amount
+}
@@ -0,0 +1,136 @@
+package transfer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/shahbaz275817/prismo/internal/models"
+	transactionMocks "github.com/shahbaz275817/prismo/internal/repository/transaction/mocks"
+	"github.com/shahbaz275817/prismo/internal/repository/transfer/mocks"
+	accountMocks "github.com/shahbaz275817/prismo/internal/services/account/mocks"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/locks"
+	"github.com/shahbaz275817/prismo/pkg/locks/lockstest"
+)
+
+func TestTransferService_Create(t *testing.T) {
+	cfg := Config{TransferOutOperationTypeID: 7, TransferInOperationTypeID: 8}
+	request := models.Transfer{ClientReference: "ref-1", SourceAccountID: 2, DestinationAccountID: 1, Amount: 25}
+
+	tests := []struct {
+		name        string
+		mockFunc    func(repo *mocks.MockTransferRepository, accSvc *accountMocks.MockAccountService, txnRepo *transactionMocks.MockTransactionRepository)
+		wantCreated bool
+		wantErr     error
+		wantDebit   int64
+	}{
+		{
+			name: "creates both legs",
+			mockFunc: func(repo *mocks.MockTransferRepository, accSvc *accountMocks.MockAccountService, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("Get", mock.Anything, &models.Transfer{ClientReference: "ref-1"}).Return(nil, nil).Once()
+				accSvc.On("Get", mock.Anything, mock.Anything).Return(&models.Account{}, nil).Twice()
+				txnRepo.On("Save", mock.Anything, mock.MatchedBy(func(txn *models.Transaction) bool {
+					return txn.AccountID == 2 && txn.OperationTypeID == 7 && txn.Amount == 25
+				})).Run(savedAs(10)).Return(nil).Once()
+				txnRepo.On("Save", mock.Anything, mock.MatchedBy(func(txn *models.Transaction) bool {
+					return txn.AccountID == 1 && txn.OperationTypeID == 8 && txn.Amount == 25
+				})).Run(savedAs(11)).Return(nil).Once()
+				repo.On("Save", mock.Anything, mock.MatchedBy(func(trf *models.Transfer) bool {
+					return trf.DebitTransactionID == 10 && trf.CreditTransactionID == 11
+				})).Return(nil).Once()
+			},
+			wantCreated: true,
+			wantDebit:   10,
+		},
+		{
+			name: "replays an existing transfer",
+			mockFunc: func(repo *mocks.MockTransferRepository, accSvc *accountMocks.MockAccountService, txnRepo *transactionMocks.MockTransactionRepository) {
+				existing := request
+				existing.DebitTransactionID = 4
+				repo.On("Get", mock.Anything, &models.Transfer{ClientReference: "ref-1"}).Return(&existing, nil).Once()
+			},
+			wantDebit: 4,
+		},
+		{
+			name: "rejects a reused client reference",
+			mockFunc: func(repo *mocks.MockTransferRepository, accSvc *accountMocks.MockAccountService, txnRepo *transactionMocks.MockTransactionRepository) {
+				existing := request
+				existing.Amount = 30
+				repo.On("Get", mock.Anything, &models.Transfer{ClientReference: "ref-1"}).Return(&existing, nil).Once()
+			},
+			wantErr: errors.UnprocessableEntityError{},
+		},
+		{
+			name: "fails when the destination account does not exist",
+			mockFunc: func(repo *mocks.MockTransferRepository, accSvc *accountMocks.MockAccountService, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("Get", mock.Anything, &models.Transfer{ClientReference: "ref-1"}).Return(nil, nil).Once()
+				accSvc.On("Get", mock.Anything, &models.Account{AccountID: 2}).Return(&models.Account{}, nil).Once()
+				accSvc.On("Get", mock.Anything, &models.Account{AccountID: 1}).Return(nil, nil).Once()
+			},
+			wantErr: errors.NotFoundError{},
+		},
+		{
+			name: "rejects an erased source account",
+			mockFunc: func(repo *mocks.MockTransferRepository, accSvc *accountMocks.MockAccountService, txnRepo *transactionMocks.MockTransactionRepository) {
+				erasedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+				repo.On("Get", mock.Anything, &models.Transfer{ClientReference: "ref-1"}).Return(nil, nil).Once()
+				accSvc.On("Get", mock.Anything, &models.Account{AccountID: 2}).Return(&models.Account{AccountID: 2, ErasedAt: &erasedAt}, nil).Once()
+			},
+			wantErr: errors.UnprocessableEntityError{},
+		},
+		{
+			name: "does not record the transfer when the credit leg fails",
+			mockFunc: func(repo *mocks.MockTransferRepository, accSvc *accountMocks.MockAccountService, txnRepo *transactionMocks.MockTransactionRepository) {
+				repo.On("Get", mock.Anything, &models.Transfer{ClientReference: "ref-1"}).Return(nil, nil).Once()
+				accSvc.On("Get", mock.Anything, mock.Anything).Return(&models.Account{}, nil).Twice()
+				txnRepo.On("Save", mock.Anything, mock.Anything).Run(savedAs(10)).Return(nil).Once()
+				txnRepo.On("Save", mock.Anything, mock.Anything).Return(errors.NewUnknownError("db error")).Once()
+			},
+			wantErr: errors.UnknownError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockTransferRepository{}
+			accSvc := &accountMocks.MockAccountService{}
+			txnRepo := &transactionMocks.MockTransactionRepository{}
+			locker := lockstest.NewRecordingLocker()
+			repo.On("Transact", mock.Anything, mock.AnythingOfType("func(context.Context) error")).Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).Once()
+			tt.mockFunc(repo, accSvc, txnRepo)
+
+			lock := locks.NewAtomicLock(locker, map[locks.KeyType]locks.LockConfig{
+				locks.Def: {LockExpiry: time.Second, RetryAttempts: 1, RetryDelay: time.Millisecond},
+			}, nil)
+			service := NewTransferService(repo, accSvc, txnRepo, lock, cfg)
+
+			trf, created, err := service.Create(context.Background(), request)
+
+			assert.Equal(t, []string{"lock-account-1", "lock-account-2", "lock-transfer-ref-1"}, locker.Acquired())
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				assert.Nil(t, trf)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCreated, created)
+				assert.Equal(t, tt.wantDebit, trf.DebitTransactionID)
+			}
+			repo.AssertExpectations(t)
+			accSvc.AssertExpectations(t)
+			txnRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// savedAs has a mocked Save assign id to the transaction it saves.
+func savedAs(id int64) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		args.Get(1).(*models.Transaction).TransactionID = id
+	}
+}
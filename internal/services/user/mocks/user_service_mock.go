@@ -1,4 +1,4 @@
-// Code generated by mockery v2.31.1. DO NOT EDIT.
+// Code generated by mockery v2.38.0. DO NOT EDIT.
 
 package mocks
 
@@ -14,10 +14,32 @@ type MockUserService struct {
 	mock.Mock
 }
 
+// Create provides a mock function with given fields: ctx, _a1
+func (_m *MockUserService) Create(ctx context.Context, _a1 *models.User) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetByEmail provides a mock function with given fields: ctx, email
 func (_m *MockUserService) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	ret := _m.Called(ctx, email)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEmail")
+	}
+
 	var r0 *models.User
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
@@ -40,25 +62,29 @@ func (_m *MockUserService) GetByEmail(ctx context.Context, email string) (*model
 	return r0, r1
 }
 
-// GetUserWithHubsByID provides a mock function with given fields: ctx, ID
-func (_m *MockUserService) GetUserWithHubsByID(ctx context.Context, ID *int64) (*models.User, error) {
-	ret := _m.Called(ctx, ID)
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *MockUserService) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
 
 	var r0 *models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *int64) (*models.User, error)); ok {
-		return rf(ctx, ID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.User, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *int64) *models.User); ok {
-		r0 = rf(ctx, ID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.User); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
-		r1 = rf(ctx, ID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -66,27 +92,47 @@ func (_m *MockUserService) GetUserWithHubsByID(ctx context.Context, ID *int64) (
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: ctx, _a1, update
-func (_m *MockUserService) Update(ctx context.Context, _a1 *models.User, update map[string]interface{}) error {
-	ret := _m.Called(ctx, _a1, update)
+// SetActive provides a mock function with given fields: ctx, id, active
+func (_m *MockUserService) SetActive(ctx context.Context, id int64, active bool) (*models.User, error) {
+	ret := _m.Called(ctx, id, active)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.User, map[string]interface{}) error); ok {
-		r0 = rf(ctx, _a1, update)
+	if len(ret) == 0 {
+		panic("no return value specified for SetActive")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) (*models.User, error)); ok {
+		return rf(ctx, id, active)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) *models.User); ok {
+		r0 = rf(ctx, id, active)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64, bool) error); ok {
+		r1 = rf(ctx, id, active)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// UpdateHubPreference provides a mock function with given fields: ctx, _a1, hubID
-func (_m *MockUserService) UpdateHubPreference(ctx context.Context, _a1 *models.User, hubID int64) error {
-	ret := _m.Called(ctx, _a1, hubID)
+// Update provides a mock function with given fields: ctx, _a1, update
+func (_m *MockUserService) Update(ctx context.Context, _a1 *models.User, update map[string]interface{}) error {
+	ret := _m.Called(ctx, _a1, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.User, int64) error); ok {
-		r0 = rf(ctx, _a1, hubID)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, map[string]interface{}) error); ok {
+		r0 = rf(ctx, _a1, update)
 	} else {
 		r0 = ret.Error(0)
 	}
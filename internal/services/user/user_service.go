@@ -4,14 +4,23 @@ import (
 	"context"
 
 	"github.com/shahbaz275817/prismo/internal/models"
-	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
-
+	"github.com/shahbaz275817/prismo/internal/repository"
 	"github.com/shahbaz275817/prismo/internal/repository/user"
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
+	contextWrapper "github.com/shahbaz275817/prismo/pkg/context"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/logger"
 )
 
+var errUserNotFound = errors.New("user not found")
+
 type Service interface {
 	GetByEmail(ctx context.Context, email string) (user *models.User, err error)
+	// GetByID returns the user through the cache, or nil when it does not exist.
+	GetByID(ctx context.Context, id int64) (user *models.User, err error)
+	Create(ctx context.Context, user *models.User) error
 	Update(ctx context.Context, user *models.User, update map[string]interface{}) error
+	SetActive(ctx context.Context, id int64, active bool) (*models.User, error)
 }
 
 type userService struct {
@@ -26,15 +35,81 @@ func NewUserService(userRepo user.Repository, uCache inmemory.InMemCache) Servic
 	}
 }
 
+// CacheLoader loads users missing from the cache built for NewUserService.
+func CacheLoader(userRepo user.Repository) inmemory.LoaderFunc {
+	return func(key interface{}) (interface{}, error) {
+		tenantID, id, err := inmemory.ParseTenantKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		// loads follow invalidations, so they read the primary to not cache what the replica has not seen yet
+		ctx := repository.WithPrimaryReads(contextWrapper.WithTenantID(context.Background(), tenantID))
+		u, err := userRepo.Get(ctx, &models.User{ID: &id})
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			// errors are not cached, so a user created later is still found
+			return nil, errUserNotFound
+		}
+		return u, nil
+	}
+}
+
 func (service *userService) GetByEmail(ctx context.Context, email string) (user *models.User, err error) {
 	query := models.User{Email: email}
 	user, err = service.userRepo.Get(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+	return user, err
+}
 
+func (service *userService) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	key, err := service.cacheKey(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return user, err
+	val, err := service.uCache.LoadValue(ctx, key)
+	if errors.Is(err, errUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// callers get a copy so they cannot change the cached user
+	u := *val.(*models.User)
+	return &u, nil
+}
+
+func (service *userService) Create(ctx context.Context, u *models.User) error {
+	// a lagging replica could miss a user just registered with the email
+	existing, err := service.userRepo.Get(repository.WithPrimaryReads(ctx), &models.User{Email: u.Email})
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errEmailRegistered()
+	}
+
+	if err := service.userRepo.Save(ctx, u); err != nil {
+		logger.WithContext(ctx).Errorf("Error while saving user Error: %s", err.Error())
+		// a concurrent request registered the email between the lookup and the insert
+		if _, ok := err.(errors.ConflictError); ok {
+			return errEmailRegistered()
+		}
+		return err
+	}
+	return nil
+}
+
+func errEmailRegistered() error {
+	return errors.NewStatusUnprocessableEntity("email_already_registered", &errors.ErrDetails{
+		Message: "a user with this email already exists",
+	})
 }
 
 func (service *userService) Update(ctx context.Context, user *models.User, update map[string]interface{}) (err error) {
@@ -42,5 +117,46 @@ func (service *userService) Update(ctx context.Context, user *models.User, updat
 	if err != nil {
 		return err
 	}
+
+	service.invalidate(ctx, user)
 	return nil
 }
+
+func (service *userService) SetActive(ctx context.Context, id int64, active bool) (*models.User, error) {
+	u, err := service.userRepo.Get(repository.WithPrimaryReads(ctx), &models.User{ID: &id})
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, errors.NewNotFoundError("user_not_found", &errors.ErrDetails{Message: "user not found"})
+	}
+	if u.IsActive == active {
+		return u, nil
+	}
+
+	if err := service.Update(ctx, u, map[string]interface{}{"is_active": active}); err != nil {
+		return nil, err
+	}
+	u.IsActive = active
+	return u, nil
+}
+
+func (service *userService) invalidate(ctx context.Context, u *models.User) {
+	if service.uCache == nil || u == nil || u.ID == nil {
+		return
+	}
+	key, err := service.cacheKey(ctx, *u.ID)
+	if err != nil {
+		return
+	}
+	service.uCache.RemoveKey(ctx, key)
+}
+
+// cacheKey identifies a cached user. The tenant is part of the key because the loader has no request context.
+func (service *userService) cacheKey(ctx context.Context, id int64) (string, error) {
+	tenantID, ok := contextWrapper.TenantID(ctx)
+	if !ok {
+		return "", errors.NewUnknownError("tenant is not set in context")
+	}
+	return inmemory.TenantKey(tenantID, id), nil
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/shahbaz275817/prismo/pkg/cache/inmemory"
 	cache_mocks "github.com/shahbaz275817/prismo/pkg/cache/inmemory/mocks"
+	contextWrapper "github.com/shahbaz275817/prismo/pkg/context"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/shahbaz275817/prismo/internal/models"
@@ -64,7 +67,7 @@ func (suite *UserServiceTestSuite) Test_userService_Update() {
 				update: map[string]interface{}{"Email": "test1@ad.com"},
 			},
 			mockFunc: func() {
-				suite.repo.On("Update", suite.ctx, &user, map[string]interface{}{"HubID": int64(2)}).Return(errors.New("something error")).Once()
+				suite.repo.On("Update", suite.ctx, &user, map[string]interface{}{"Email": "test1@ad.com"}).Return(errors.New("something error")).Once()
 			},
 			wantErr: true,
 		},
@@ -82,6 +85,62 @@ func (suite *UserServiceTestSuite) Test_userService_Update() {
 	}
 }
 
+func (suite *UserServiceTestSuite) Test_userService_GetByID() {
+	ctx := contextWrapper.WithTenantID(context.Background(), 7)
+	userID := int64(1)
+	cached := &models.User{ID: &userID, HubID: 7, Name: "Ops"}
+
+	suite.cache.On("LoadValue", ctx, inmemory.TenantKey(7, 1)).Return(cached, nil).Once()
+	suite.cache.On("LoadValue", ctx, inmemory.TenantKey(7, 2)).Return(nil, errUserNotFound).Once()
+	service := NewUserService(&suite.repo, suite.cache)
+
+	u, err := service.GetByID(ctx, 1)
+	suite.Require().NoError(err)
+	suite.Equal("Ops", u.Name)
+	suite.NotSame(cached, u)
+
+	u, err = service.GetByID(ctx, 2)
+	suite.Require().NoError(err)
+	suite.Nil(u)
+	suite.cache.AssertExpectations(suite.T())
+}
+
+func (suite *UserServiceTestSuite) Test_userService_Create() {
+	u := &models.User{Name: "Ops", Email: "ops@prismo.io"}
+
+	suite.repo.On("Get", mock.Anything, &models.User{Email: "ops@prismo.io"}).Return(nil, nil).Once()
+	suite.repo.On("Save", suite.ctx, u).Return(nil).Once()
+	service := NewUserService(&suite.repo, suite.cache)
+	suite.NoError(service.Create(suite.ctx, u))
+
+	suite.repo.On("Get", mock.Anything, &models.User{Email: "ops@prismo.io"}).Return(&models.User{}, nil).Once()
+	err := service.Create(suite.ctx, u)
+	suite.IsType(errors.UnprocessableEntityError{}, err)
+
+	suite.repo.On("Get", mock.Anything, &models.User{Email: "ops@prismo.io"}).Return(nil, nil).Once()
+	suite.repo.On("Save", suite.ctx, u).Return(errors.NewConflictError("duplicate_record", &errors.ErrDetails{})).Once()
+	err = service.Create(suite.ctx, u)
+	suite.IsType(errors.UnprocessableEntityError{}, err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *UserServiceTestSuite) Test_userService_SetActiveInvalidatesCache() {
+	ctx := contextWrapper.WithTenantID(context.Background(), 7)
+	userID := int64(1)
+
+	suite.repo.On("Get", mock.Anything, &models.User{ID: &userID}).Return(&models.User{ID: &userID, IsActive: true}, nil).Once()
+	suite.repo.On("Update", ctx, mock.Anything, map[string]interface{}{"is_active": false}).Return(nil).Once()
+	suite.cache.On("RemoveKey", ctx, inmemory.TenantKey(7, 1)).Return().Once()
+	service := NewUserService(&suite.repo, suite.cache)
+
+	u, err := service.SetActive(ctx, userID, false)
+
+	suite.Require().NoError(err)
+	suite.False(u.IsActive)
+	suite.repo.AssertExpectations(suite.T())
+	suite.cache.AssertExpectations(suite.T())
+}
+
 func TestUserServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(UserServiceTestSuite))
 }
@@ -1,7 +1,18 @@
 package utils
 
-import "fmt"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
 
 func CreateAWBLegTypeLockKey(awbNumber string, legType string) string {
 	return fmt.Sprintf("%s%s", awbNumber, legType)
 }
+
+// HashLockKeyPart hashes personal data, such as a document number, before it becomes part of a lock key, so that the
+// locker never stores it in plain text.
+func HashLockKeyPart(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
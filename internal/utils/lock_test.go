@@ -24,3 +24,14 @@ func TestCreateAWBLegTypeLockKey(t *testing.T) {
 		}
 	}
 }
+
+func TestHashLockKeyPart(t *testing.T) {
+	hashed := HashLockKeyPart("12345678900")
+
+	if hashed != HashLockKeyPart("12345678900") {
+		t.Errorf("Expected the same value to hash to the same key part")
+	}
+	if hashed == HashLockKeyPart("12345678901") || len(hashed) != 64 {
+		t.Errorf("Expected a distinct sha256 hex digest, got %s", hashed)
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// PseudonymPrefix marks values that replaced personal data, so they are never mistaken for real documents.
+const PseudonymPrefix = "X"
+
+// NewPseudonym returns a random value of the given length. It is not derived from the data it replaces, so it cannot
+// be reversed or linked back to the original.
+func NewPseudonym(length int) (string, error) {
+	if length <= len(PseudonymPrefix) {
+		return PseudonymPrefix[:length], nil
+	}
+
+	buf := make([]byte, (length-len(PseudonymPrefix)+1)/2)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return (PseudonymPrefix + hex.EncodeToString(buf))[:length], nil
+}
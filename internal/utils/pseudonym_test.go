@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPseudonym(t *testing.T) {
+	for _, length := range []int{1, 2, 15, 64} {
+		p, err := NewPseudonym(length)
+
+		assert.NoError(t, err)
+		assert.Len(t, p, length)
+		assert.True(t, strings.HasPrefix(p, PseudonymPrefix))
+	}
+
+	a, _ := NewPseudonym(15)
+	b, _ := NewPseudonym(15)
+	assert.NotEqual(t, a, b)
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS Reconciliation_Results;
+DROP TABLE IF EXISTS Reconciliation_Runs;
+DROP INDEX IF EXISTS idx_transactions_eventdate;
+DROP INDEX IF EXISTS idx_transactions_external_reference;
+ALTER TABLE Transactions DROP COLUMN IF EXISTS External_Reference;
@@ -0,0 +1,32 @@
+-- External reference reported by the processor in settlement files
+ALTER TABLE Transactions ADD COLUMN External_Reference VARCHAR(64);
+
+CREATE INDEX idx_transactions_external_reference ON Transactions (External_Reference);
+CREATE INDEX idx_transactions_eventdate ON Transactions (EventDate);
+
+-- Create the ReconciliationRuns table
+CREATE TABLE Reconciliation_Runs (
+                                     Run_ID INT PRIMARY KEY generated always as identity,
+                                     File_Name VARCHAR(255) NOT NULL,
+                                     Match_Key VARCHAR(50) NOT NULL,
+                                     Total_Records INT NOT NULL,
+                                     Created_At TIMESTAMP NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+-- Create the ReconciliationResults table
+CREATE TABLE Reconciliation_Results (
+                                        Result_ID INT PRIMARY KEY generated always as identity,
+                                        Run_ID INT NOT NULL,
+                                        Status VARCHAR(30) NOT NULL,
+                                        Transaction_ID INT,
+                                        Account_ID INT,
+                                        External_Reference VARCHAR(64),
+                                        External_Amount DECIMAL(10, 2),
+                                        Internal_Amount DECIMAL(10, 2),
+                                        External_Event_Date TIMESTAMP,
+                                        Created_At TIMESTAMP NOT NULL DEFAULT (now() at time zone 'utc'),
+                                        FOREIGN KEY (Run_ID) REFERENCES Reconciliation_Runs(Run_ID),
+                                        FOREIGN KEY (Transaction_ID) REFERENCES Transactions(Transaction_ID)
+);
+
+CREATE INDEX idx_reconciliation_results_run_id_status ON Reconciliation_Results (Run_ID, Status);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS Interest_Accruals;
+DROP TABLE IF EXISTS Fee_Schedules;
+DELETE FROM OperationsTypes WHERE Description IN ('Fee', 'Interest');
+DROP INDEX IF EXISTS idx_transactions_parent_transaction_id;
+ALTER TABLE Transactions DROP COLUMN IF EXISTS Parent_Transaction_ID;
@@ -0,0 +1,35 @@
+-- Fees and interest are posted as their own transactions linked to the transaction that caused them
+ALTER TABLE Transactions ADD COLUMN Parent_Transaction_ID INT REFERENCES Transactions(Transaction_ID);
+
+CREATE INDEX idx_transactions_parent_transaction_id ON Transactions (Parent_Transaction_ID);
+
+INSERT INTO OperationsTypes (Description) VALUES
+                                              ('Fee'),
+                                              ('Interest');
+
+-- Create the FeeSchedules table
+CREATE TABLE Fee_Schedules (
+                               Fee_Schedule_ID INT PRIMARY KEY generated always as identity,
+                               OperationType_ID INT NOT NULL UNIQUE,
+                               Fixed_Amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+                               Percentage DECIMAL(7, 4) NOT NULL DEFAULT 0,
+                               Min_Amount DECIMAL(10, 2),
+                               Max_Amount DECIMAL(10, 2),
+                               Is_Active BOOLEAN NOT NULL DEFAULT TRUE,
+                               FOREIGN KEY (OperationType_ID) REFERENCES OperationsTypes(OperationType_ID)
+);
+
+-- Create the InterestAccruals table
+CREATE TABLE Interest_Accruals (
+                                   Accrual_ID INT PRIMARY KEY generated always as identity,
+                                   Account_ID INT NOT NULL,
+                                   Accrual_Date DATE NOT NULL,
+                                   Balance DECIMAL(12, 2) NOT NULL,
+                                   Apr DECIMAL(7, 4) NOT NULL,
+                                   Day_Count_Convention VARCHAR(10) NOT NULL,
+                                   Amount DECIMAL(10, 2) NOT NULL,
+                                   Transaction_ID INT,
+                                   UNIQUE (Account_ID, Accrual_Date),
+                                   FOREIGN KEY (Account_ID) REFERENCES Accounts(Account_ID),
+                                   FOREIGN KEY (Transaction_ID) REFERENCES Transactions(Transaction_ID)
+);
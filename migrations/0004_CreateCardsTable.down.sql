@@ -0,0 +1,2 @@
+ALTER TABLE Transactions DROP COLUMN IF EXISTS Card_ID;
+DROP TABLE IF EXISTS Cards;
@@ -0,0 +1,21 @@
+-- Create the Cards table. Only the encrypted PAN is stored; Pan_Hash is a keyed hash used to detect collisions.
+CREATE TABLE Cards (
+                       Card_ID INT PRIMARY KEY generated always as identity,
+                       Account_ID INT NOT NULL,
+                       Token VARCHAR(36) NOT NULL UNIQUE,
+                       Encrypted_Pan TEXT NOT NULL,
+                       Pan_Hash VARCHAR(64) NOT NULL UNIQUE,
+                       Masked_Pan VARCHAR(19) NOT NULL,
+                       Expiry_Month INT NOT NULL,
+                       Expiry_Year INT NOT NULL,
+                       Status VARCHAR(20) NOT NULL,
+                       Replaced_By_Card_ID INT,
+                       Created_At TIMESTAMP NOT NULL DEFAULT (now() at time zone 'utc'),
+                       Updated_At TIMESTAMP NOT NULL DEFAULT (now() at time zone 'utc'),
+                       FOREIGN KEY (Account_ID) REFERENCES Accounts(Account_ID),
+                       FOREIGN KEY (Replaced_By_Card_ID) REFERENCES Cards(Card_ID)
+);
+
+CREATE INDEX idx_cards_account_id ON Cards (Account_ID);
+
+ALTER TABLE Transactions ADD COLUMN Card_ID INT REFERENCES Cards(Card_ID);
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS Merchants;
+
+DROP INDEX IF EXISTS idx_transactions_mcc;
+DROP INDEX IF EXISTS idx_transactions_merchant_id;
+
+ALTER TABLE Transactions
+    DROP COLUMN IF EXISTS Merchant_Country,
+    DROP COLUMN IF EXISTS Merchant_City,
+    DROP COLUMN IF EXISTS Mcc,
+    DROP COLUMN IF EXISTS Merchant_Name,
+    DROP COLUMN IF EXISTS Merchant_ID;
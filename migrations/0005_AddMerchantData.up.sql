@@ -0,0 +1,20 @@
+-- Merchant details reported with card transactions
+ALTER TABLE Transactions
+    ADD COLUMN Merchant_ID VARCHAR(64),
+    ADD COLUMN Merchant_Name VARCHAR(255),
+    ADD COLUMN Mcc CHAR(4),
+    ADD COLUMN Merchant_City VARCHAR(64),
+    ADD COLUMN Merchant_Country CHAR(2);
+
+CREATE INDEX idx_transactions_merchant_id ON Transactions (Merchant_ID);
+CREATE INDEX idx_transactions_mcc ON Transactions (Mcc);
+
+-- Create the Merchants table, populated the first time a merchant is seen on a transaction
+CREATE TABLE Merchants (
+                           Merchant_ID VARCHAR(64) PRIMARY KEY,
+                           Name VARCHAR(255),
+                           Mcc CHAR(4),
+                           City VARCHAR(64),
+                           Country CHAR(2),
+                           Created_At TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_account_eventdate_id;
+DROP INDEX IF EXISTS idx_transactions_eventdate_id;
@@ -0,0 +1,3 @@
+-- Keyset pagination walks transactions by (EventDate, Transaction_ID), usually within one account
+CREATE INDEX idx_transactions_eventdate_id ON Transactions (EventDate, Transaction_ID);
+CREATE INDEX idx_transactions_account_eventdate_id ON Transactions (Account_ID, EventDate, Transaction_ID);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Transfers;
+DELETE FROM OperationsTypes WHERE Description IN ('Transfer Out', 'Transfer In');
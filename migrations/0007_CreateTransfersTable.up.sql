@@ -0,0 +1,19 @@
+INSERT INTO OperationsTypes (Description) VALUES
+                                              ('Transfer Out'),
+                                              ('Transfer In');
+
+-- Create the Transfers table, linking the debit and credit legs of a transfer between two accounts
+CREATE TABLE Transfers (
+                           Transfer_ID INT PRIMARY KEY generated always as identity,
+                           Client_Reference VARCHAR(64) NOT NULL UNIQUE,
+                           Source_Account_ID INT NOT NULL,
+                           Destination_Account_ID INT NOT NULL,
+                           Amount DECIMAL(10, 2) NOT NULL,
+                           Debit_Transaction_ID INT NOT NULL,
+                           Credit_Transaction_ID INT NOT NULL,
+                           Created_At TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                           FOREIGN KEY (Source_Account_ID) REFERENCES Accounts(Account_ID),
+                           FOREIGN KEY (Destination_Account_ID) REFERENCES Accounts(Account_ID),
+                           FOREIGN KEY (Debit_Transaction_ID) REFERENCES Transactions(Transaction_ID),
+                           FOREIGN KEY (Credit_Transaction_ID) REFERENCES Transactions(Transaction_ID)
+);
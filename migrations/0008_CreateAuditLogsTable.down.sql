@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Audit_Logs;
+DROP FUNCTION IF EXISTS reject_audit_log_change();
@@ -0,0 +1,27 @@
+-- Create the AuditLogs table, written by the GORM audit callbacks on every insert, update and delete
+CREATE TABLE Audit_Logs (
+                            Audit_ID BIGINT PRIMARY KEY generated always as identity,
+                            Actor VARCHAR(128) NOT NULL,
+                            Request_ID VARCHAR(64),
+                            Entity VARCHAR(64) NOT NULL,
+                            Entity_ID VARCHAR(64),
+                            Action VARCHAR(16) NOT NULL,
+                            Before_Data JSONB,
+                            After_Data JSONB,
+                            Created_At TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_entity ON Audit_Logs (Entity, Entity_ID, Created_At);
+CREATE INDEX idx_audit_logs_actor ON Audit_Logs (Actor, Created_At);
+CREATE INDEX idx_audit_logs_created_at ON Audit_Logs (Created_At, Audit_ID);
+
+-- Audit records are append only
+CREATE FUNCTION reject_audit_log_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_logs_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON Audit_Logs
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_log_change();
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS Disputes;
+
+ALTER TABLE Accounts DROP COLUMN IF EXISTS Erased_At;
@@ -0,0 +1,17 @@
+-- Erased accounts keep their row (and transactions) but their personal data is replaced by pseudonyms
+ALTER TABLE Accounts ADD COLUMN Erased_At TIMESTAMP;
+
+-- Create the Disputes table. An account cannot be erased while one of its disputes is open.
+CREATE TABLE Disputes (
+                          Dispute_ID INT PRIMARY KEY generated always as identity,
+                          Account_ID INT NOT NULL,
+                          Transaction_ID INT,
+                          Status VARCHAR(20) NOT NULL,
+                          Reason TEXT,
+                          Created_At TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                          Resolved_At TIMESTAMP,
+                          FOREIGN KEY (Account_ID) REFERENCES Accounts(Account_ID),
+                          FOREIGN KEY (Transaction_ID) REFERENCES Transactions(Transaction_ID)
+);
+
+CREATE INDEX idx_disputes_account_id_status ON Disputes (Account_ID, Status);
@@ -0,0 +1,28 @@
+DROP INDEX IF EXISTS idx_audit_logs_tenant_created_at;
+DROP INDEX IF EXISTS idx_disputes_tenant_id;
+DROP INDEX IF EXISTS idx_reconciliation_runs_tenant_id;
+DROP INDEX IF EXISTS idx_cards_tenant_id;
+DROP INDEX IF EXISTS idx_transactions_tenant_eventdate_id;
+DROP INDEX IF EXISTS idx_accounts_tenant_id;
+
+ALTER TABLE Merchants DROP CONSTRAINT merchants_pkey;
+ALTER TABLE Merchants ADD PRIMARY KEY (Merchant_ID);
+
+ALTER TABLE Fee_Schedules DROP CONSTRAINT fee_schedules_tenant_id_operationtype_id_key;
+ALTER TABLE Fee_Schedules ADD CONSTRAINT fee_schedules_operationtype_id_key UNIQUE (OperationType_ID);
+
+ALTER TABLE Transfers DROP CONSTRAINT transfers_tenant_id_client_reference_key;
+ALTER TABLE Transfers ADD CONSTRAINT transfers_client_reference_key UNIQUE (Client_Reference);
+
+ALTER TABLE OperationsTypes DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Disputes DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Audit_Logs DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Transfers DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Merchants DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Cards DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Interest_Accruals DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Fee_Schedules DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Reconciliation_Results DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Reconciliation_Runs DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Transactions DROP COLUMN IF EXISTS Tenant_ID;
+ALTER TABLE Accounts DROP COLUMN IF EXISTS Tenant_ID;
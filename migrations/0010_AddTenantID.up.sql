@@ -0,0 +1,44 @@
+-- Every row belongs to a tenant. Existing data is assigned to tenant 1; new rows must set the tenant explicitly.
+ALTER TABLE Accounts ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+ALTER TABLE Transactions ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+ALTER TABLE Reconciliation_Runs ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+ALTER TABLE Reconciliation_Results ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+ALTER TABLE Fee_Schedules ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+ALTER TABLE Interest_Accruals ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+ALTER TABLE Cards ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+ALTER TABLE Merchants ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+ALTER TABLE Transfers ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+ALTER TABLE Audit_Logs ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+ALTER TABLE Disputes ADD COLUMN Tenant_ID INT NOT NULL DEFAULT 1;
+
+ALTER TABLE Accounts ALTER COLUMN Tenant_ID DROP DEFAULT;
+ALTER TABLE Transactions ALTER COLUMN Tenant_ID DROP DEFAULT;
+ALTER TABLE Reconciliation_Runs ALTER COLUMN Tenant_ID DROP DEFAULT;
+ALTER TABLE Reconciliation_Results ALTER COLUMN Tenant_ID DROP DEFAULT;
+ALTER TABLE Fee_Schedules ALTER COLUMN Tenant_ID DROP DEFAULT;
+ALTER TABLE Interest_Accruals ALTER COLUMN Tenant_ID DROP DEFAULT;
+ALTER TABLE Cards ALTER COLUMN Tenant_ID DROP DEFAULT;
+ALTER TABLE Merchants ALTER COLUMN Tenant_ID DROP DEFAULT;
+ALTER TABLE Transfers ALTER COLUMN Tenant_ID DROP DEFAULT;
+ALTER TABLE Audit_Logs ALTER COLUMN Tenant_ID DROP DEFAULT;
+ALTER TABLE Disputes ALTER COLUMN Tenant_ID DROP DEFAULT;
+
+-- Operation types seeded by the migrations are shared by all tenants and keep a null Tenant_ID
+ALTER TABLE OperationsTypes ADD COLUMN Tenant_ID INT;
+
+-- Uniqueness is per tenant
+ALTER TABLE Transfers DROP CONSTRAINT transfers_client_reference_key;
+ALTER TABLE Transfers ADD CONSTRAINT transfers_tenant_id_client_reference_key UNIQUE (Tenant_ID, Client_Reference);
+
+ALTER TABLE Fee_Schedules DROP CONSTRAINT fee_schedules_operationtype_id_key;
+ALTER TABLE Fee_Schedules ADD CONSTRAINT fee_schedules_tenant_id_operationtype_id_key UNIQUE (Tenant_ID, OperationType_ID);
+
+ALTER TABLE Merchants DROP CONSTRAINT merchants_pkey;
+ALTER TABLE Merchants ADD PRIMARY KEY (Tenant_ID, Merchant_ID);
+
+CREATE INDEX idx_accounts_tenant_id ON Accounts (Tenant_ID, Account_ID);
+CREATE INDEX idx_transactions_tenant_eventdate_id ON Transactions (Tenant_ID, EventDate, Transaction_ID);
+CREATE INDEX idx_cards_tenant_id ON Cards (Tenant_ID);
+CREATE INDEX idx_reconciliation_runs_tenant_id ON Reconciliation_Runs (Tenant_ID);
+CREATE INDEX idx_disputes_tenant_id ON Disputes (Tenant_ID);
+CREATE INDEX idx_audit_logs_tenant_created_at ON Audit_Logs (Tenant_ID, Created_At, Audit_ID);
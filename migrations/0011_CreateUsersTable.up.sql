@@ -0,0 +1,13 @@
+-- Create the Users table. A user belongs to a hub, which is its tenant.
+CREATE TABLE Users (
+                       ID INT PRIMARY KEY generated always as identity,
+                       Tenant_ID INT NOT NULL,
+                       Name VARCHAR(255) NOT NULL,
+                       Email VARCHAR(255) NOT NULL,
+                       Is_Active BOOLEAN NOT NULL DEFAULT TRUE,
+                       Created_At TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                       Created_By INT,
+                       Updated_At TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                       Updated_By INT,
+                       UNIQUE (Tenant_ID, Email)
+);
@@ -0,0 +1,14 @@
+-- Create the Api_Clients table. Clients authenticate with their name and a secret, of which only bcrypt hashes are
+-- stored. After a rotation the previous secret keeps working until Previous_Secret_Expires_At.
+CREATE TABLE Api_Clients (
+                             Client_ID INT PRIMARY KEY generated always as identity,
+                             Tenant_ID INT NOT NULL,
+                             Name VARCHAR(100) NOT NULL UNIQUE,
+                             Role VARCHAR(20) NOT NULL,
+                             Secret_Hash VARCHAR(60) NOT NULL,
+                             Previous_Secret_Hash VARCHAR(60),
+                             Previous_Secret_Expires_At TIMESTAMP,
+                             Enabled BOOLEAN NOT NULL DEFAULT TRUE,
+                             Created_At TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                             Updated_At TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+ALTER TABLE Accounts DROP COLUMN IF EXISTS Version;
+ALTER TABLE OperationsTypes DROP COLUMN IF EXISTS Version;
@@ -0,0 +1,4 @@
+-- Versions for optimistic concurrency control. Every update increments the version and updates made with a stale
+-- version are rejected.
+ALTER TABLE Accounts ADD COLUMN Version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE OperationsTypes ADD COLUMN Version BIGINT NOT NULL DEFAULT 1;
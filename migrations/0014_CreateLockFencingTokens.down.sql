@@ -0,0 +1 @@
+DROP SEQUENCE IF EXISTS Lock_Fencing_Tokens;
@@ -0,0 +1,3 @@
+-- Fencing tokens handed out with the locks of the postgres atomic lock backend. Sequences are not rolled back, so
+-- tokens keep increasing even when the transaction holding a lock fails.
+CREATE SEQUENCE Lock_Fencing_Tokens;
@@ -0,0 +1 @@
+ALTER TABLE OperationsTypes DROP COLUMN IF EXISTS Is_Credit;
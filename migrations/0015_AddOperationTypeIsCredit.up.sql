@@ -0,0 +1,5 @@
+-- Whether transactions of the operation type credit the account. Amounts are stored as entered, so balances take
+-- their sign from the operation type rather than from the amount.
+ALTER TABLE OperationsTypes ADD COLUMN Is_Credit BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE OperationsTypes SET Is_Credit = TRUE WHERE Tenant_ID IS NULL AND Description IN ('Credit Voucher', 'Transfer In');
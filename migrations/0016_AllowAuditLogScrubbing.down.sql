@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS audit_logs_scrub_only ON Audit_Logs;
+DROP FUNCTION IF EXISTS reject_audit_log_update();
+
+DROP TRIGGER IF EXISTS audit_logs_append_only ON Audit_Logs;
+CREATE TRIGGER audit_logs_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON Audit_Logs
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_log_change();
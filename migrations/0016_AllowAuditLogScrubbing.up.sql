@@ -0,0 +1,22 @@
+-- Audit records stay append only, except that the erasure of an account may blank the values recorded for it. Only
+-- Before_Data and After_Data can change, and only in a transaction that set prismo.audit_scrub to on.
+DROP TRIGGER audit_logs_append_only ON Audit_Logs;
+
+CREATE TRIGGER audit_logs_append_only
+    BEFORE DELETE OR TRUNCATE ON Audit_Logs
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_log_change();
+
+CREATE FUNCTION reject_audit_log_update() RETURNS TRIGGER AS $$
+BEGIN
+    IF current_setting('prismo.audit_scrub', true) IS DISTINCT FROM 'on'
+        OR (NEW.Audit_ID, NEW.Tenant_ID, NEW.Actor, NEW.Request_ID, NEW.Entity, NEW.Entity_ID, NEW.Action, NEW.Created_At)
+            IS DISTINCT FROM (OLD.Audit_ID, OLD.Tenant_ID, OLD.Actor, OLD.Request_ID, OLD.Entity, OLD.Entity_ID, OLD.Action, OLD.Created_At) THEN
+        RAISE EXCEPTION 'audit_logs is append only';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_logs_scrub_only
+    BEFORE UPDATE ON Audit_Logs
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_log_update();
@@ -0,0 +1,5 @@
+UPDATE Transactions SET Amount = -Amount
+WHERE Amount > 0 AND OperationType_ID IN (
+    SELECT OperationType_ID FROM OperationsTypes
+    WHERE Tenant_ID IS NULL AND Description IN ('Fee', 'Interest', 'Transfer Out')
+);
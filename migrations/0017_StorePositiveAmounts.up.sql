@@ -0,0 +1,7 @@
+-- Fee, interest and transfer out legs were stored with negative amounts. Amounts are positive, as entered, and the
+-- operation type's Is_Credit gives their direction.
+UPDATE Transactions SET Amount = -Amount
+WHERE Amount < 0 AND OperationType_ID IN (
+    SELECT OperationType_ID FROM OperationsTypes
+    WHERE Tenant_ID IS NULL AND Description IN ('Fee', 'Interest', 'Transfer Out')
+);
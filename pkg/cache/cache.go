@@ -11,6 +11,7 @@ import (
 type Client interface {
 	redis.Cmdable
 	Process(ctx context.Context, cmd redis.Cmder) error
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
 	Close() error
 }
 
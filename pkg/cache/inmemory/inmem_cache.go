@@ -22,14 +22,23 @@ type CacheStats struct {
 type InMemCache interface {
 	LoadValue(ctx context.Context, key interface{}) (interface{}, error)
 	RemoveKey(ctx context.Context, key interface{})
+	Purge(ctx context.Context)
 	Stats() CacheStats
 }
 
 type LoaderFunc func(interface{}) (interface{}, error)
 
+// ErrNotFound is returned by loaders for keys without a value. With NegativeTTLSeconds set the miss is cached for
+// that long, so lookups of a missing key do not all reach the loader.
+var ErrNotFound = errors.New("not found")
+
+// notFound is cached in place of the value of a key its loader did not find.
+type notFound struct{}
+
 type CacheConfig struct {
 	LoaderFunc                   LoaderFunc
 	TTLSeconds                   int64
+	NegativeTTLSeconds           int64
 	Reporter                     *reporting.Reporter
 	Name                         string
 	MetricsPublishIntervalInSecs int64
@@ -50,7 +59,8 @@ func NewInMemCache(cfg CacheConfig) (cache InMemCache, err error) {
 		return cache, errors.NewUnknownError("Cache config loader function is nil")
 	}
 
-	gc := gcache.New(cfg.Size).LRU().LoaderFunc(gcache.LoaderFunc(cfg.LoaderFunc))
+	// concurrent lookups of a key missing from the cache share a single call of the loader
+	gc := gcache.New(cfg.Size).LRU().LoaderExpireFunc(newLoaderExpireFunc(cfg))
 
 	isCacheExpirable := cfg.TTLSeconds > 0
 
@@ -107,10 +117,26 @@ func NewInMemCache(cfg CacheConfig) (cache InMemCache, err error) {
 
 }
 
+// newLoaderExpireFunc wraps the loader of cfg so misses are cached for the negative TTL.
+func newLoaderExpireFunc(cfg CacheConfig) gcache.LoaderExpireFunc {
+	negativeTTL := time.Duration(cfg.NegativeTTLSeconds) * time.Second
+
+	return func(key interface{}) (interface{}, *time.Duration, error) {
+		val, err := cfg.LoaderFunc(key)
+		if negativeTTL > 0 && errors.Is(err, ErrNotFound) {
+			return notFound{}, &negativeTTL, nil
+		}
+		return val, nil, err
+	}
+}
+
 func (c GcCacheInMemCache) LoadValue(ctx context.Context, key interface{}) (interface{}, error) {
 
 	val, err := c.GcCache.Get(key)
 
+	if _, ok := val.(notFound); ok || errors.Is(err, ErrNotFound) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
 		logger.WithContext(ctx).Errorf("Error in loading values from cache for key : %s , error : %s", key, err.Error())
 		return nil, err
@@ -124,13 +150,38 @@ func (c GcCacheInMemCache) RemoveKey(ctx context.Context, key interface{}) {
 	res := c.GcCache.Remove(key)
 
 	if !res {
-		logger.WithContext(ctx).Errorf("Error in removing key %s from cache %s", key, c.Name)
+		// keys invalidated by other instances are often not cached here
+		logger.WithContext(ctx).Debugf("Key %s was not in cache %s", key, c.Name)
 		return
 	}
 
 	logger.WithContext(ctx).Infof("Removed key %s from cache %s", key, c.Name)
 }
 
+func (c GcCacheInMemCache) Purge(ctx context.Context) {
+	c.GcCache.Purge()
+
+	logger.WithContext(ctx).Infof("Purged cache %s", c.Name)
+}
+
+// TenantKey is the key of the entity with id of a tenant. Keys are strings so the InvalidationBus can send them to
+// other instances.
+func TenantKey(tenantID, id int64) string {
+	return fmt.Sprintf("%d:%d", tenantID, id)
+}
+
+// ParseTenantKey splits a key made by TenantKey.
+func ParseTenantKey(key interface{}) (tenantID, id int64, err error) {
+	k, ok := key.(string)
+	if !ok {
+		return 0, 0, errors.Errorf("invalid cache key %v", key)
+	}
+	if _, err = fmt.Sscanf(k, "%d:%d", &tenantID, &id); err != nil {
+		return 0, 0, errors.Errorf("invalid cache key %q", k)
+	}
+	return tenantID, id, nil
+}
+
 func (c GcCacheInMemCache) Stats() CacheStats {
 	return CacheStats{
 		lookupCount: c.GcCache.LookupCount(),
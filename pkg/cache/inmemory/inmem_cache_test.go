@@ -2,6 +2,8 @@ package inmemory
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -161,3 +163,75 @@ func (suite *InMemCacheClientTest) TestNoErrorWhenRemoveKeyIsCalled() {
 	suite.Equal(newKey, value)
 	suite.Equal(2, loadedFromCache)
 }
+
+// Test case 7: Test that misses are only cached when a negative TTL is configured.
+func (suite *InMemCacheClientTest) TestCachesMissesForNegativeTTL() {
+	tests := []struct {
+		negativeTTLSeconds int64
+		wantLoads          int
+	}{
+		{negativeTTLSeconds: 0, wantLoads: 2},
+		{negativeTTLSeconds: 10, wantLoads: 1},
+	}
+	for _, tt := range tests {
+		loads := 0
+		cfg := CacheConfig{
+			LoaderFunc: func(key interface{}) (interface{}, error) {
+				loads++
+				return nil, ErrNotFound
+			},
+			TTLSeconds:         60,
+			NegativeTTLSeconds: tt.negativeTTLSeconds,
+			Name:               "test",
+			Size:               100,
+		}
+		cache, _ := NewInMemCache(cfg)
+
+		for i := 0; i < 2; i++ {
+			value, err := cache.LoadValue(suite.ctx, "missing_key")
+			suite.Assert().ErrorIs(err, ErrNotFound)
+			suite.Assert().Nil(value)
+		}
+		suite.Equal(tt.wantLoads, loads)
+
+		// a removed miss is loaded again
+		cache.RemoveKey(suite.ctx, "missing_key")
+		_, _ = cache.LoadValue(suite.ctx, "missing_key")
+		suite.Equal(tt.wantLoads+1, loads)
+	}
+}
+
+// Test case 8: Test that concurrent lookups of a missing key share a single load.
+func (suite *InMemCacheClientTest) TestConcurrentMissesShareOneLoad() {
+	var loads int32
+	release := make(chan struct{})
+	cfg := CacheConfig{
+		LoaderFunc: func(key interface{}) (interface{}, error) {
+			atomic.AddInt32(&loads, 1)
+			<-release
+			return key, nil
+		},
+		TTLSeconds: 60,
+		Name:       "test",
+		Size:       100,
+	}
+	cache, _ := NewInMemCache(cfg)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cache.LoadValue(suite.ctx, "test_key")
+			suite.Assert().Nil(err)
+			suite.Assert().Equal("test_key", value)
+		}()
+	}
+	suite.Assert().Eventually(func() bool { return atomic.LoadInt32(&loads) == 1 }, time.Second, time.Millisecond)
+	// give the other lookups time to join the load in flight
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	suite.Equal(int32(1), atomic.LoadInt32(&loads))
+}
@@ -0,0 +1,235 @@
+package inmemory
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/shahbaz275817/prismo/pkg/cache"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+	"github.com/shahbaz275817/prismo/pkg/reporting"
+)
+
+const (
+	invalidationKeyPrefix  = "cache:"
+	minResubscribeDelay    = 100 * time.Millisecond
+	maxResubscribeDelay    = 10 * time.Second
+	defaultPingInterval    = 30 * time.Second
+	invalidationMetricName = "cache_invalidation"
+)
+
+// invalidationMessage is published on the bus's channel for every key removed from a registered cache. Key is
+// "cache:<name>:<key>"; Origin and PublishedAt let receivers skip their own messages and measure the lag.
+type invalidationMessage struct {
+	Key         string `json:"key"`
+	Origin      string `json:"origin"`
+	PublishedAt int64  `json:"published_at"`
+}
+
+// InvalidationBus keeps the in-memory caches of every instance sharing a Redis in sync. Keys removed from a
+// registered cache are published on a Redis channel, and every other instance removes them from its cache of the
+// same name. Invalidations published while an instance is not subscribed are lost, so it purges its registered caches
+// whenever it (re)subscribes.
+type InvalidationBus struct {
+	client       cache.Client
+	channel      string
+	origin       string
+	reporter     *reporting.Reporter
+	pingInterval time.Duration
+	now          func() time.Time
+	mu           sync.RWMutex
+	caches       map[string]InMemCache
+}
+
+// NewInvalidationBus creates a bus publishing on channel. Run must be called to receive invalidations.
+func NewInvalidationBus(client cache.Client, channel string, reporter *reporting.Reporter) (*InvalidationBus, error) {
+	origin := make([]byte, 16)
+	if _, err := rand.Read(origin); err != nil {
+		return nil, err
+	}
+	return &InvalidationBus{
+		client:       client,
+		channel:      channel,
+		origin:       hex.EncodeToString(origin),
+		reporter:     reporter,
+		pingInterval: defaultPingInterval,
+		now:          time.Now,
+		caches:       map[string]InMemCache{},
+	}, nil
+}
+
+// Register has the bus remove keys from c when other instances invalidate them in their cache named name. It returns
+// the cache to use in place of c, whose RemoveKey also tells the other instances; its keys must be strings, such as
+// those made by TenantKey. A nil bus returns c as is, so caches are only local without Redis.
+func (b *InvalidationBus) Register(name string, c InMemCache) InMemCache {
+	if b == nil {
+		return c
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.caches[name] = c
+	return broadcastingCache{InMemCache: c, name: name, bus: b}
+}
+
+// Run receives the invalidations of other instances until ctx is done. When the subscription fails or its connection
+// stops answering pings, it subscribes again with a backoff.
+func (b *InvalidationBus) Run(ctx context.Context) {
+	delay := minResubscribeDelay
+	for {
+		subscribed, err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if subscribed {
+			delay = minResubscribeDelay
+		}
+
+		logger.Warnf("Lost subscription to cache invalidations on %s, resubscribing in %s: %s", b.channel, delay, err)
+		entry := b.reporter.Report(invalidationMetricName)
+		entry.Incr("resubscribe")
+		entry.Publish()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxResubscribeDelay {
+			delay = maxResubscribeDelay
+		}
+	}
+}
+
+// listen subscribes to the bus's channel and handles its messages until the subscription fails or ctx is done,
+// reporting whether the subscription was made.
+func (b *InvalidationBus) listen(ctx context.Context) (bool, error) {
+	pubsub := b.client.Subscribe(ctx, b.channel)
+	defer pubsub.Close()
+
+	// receiving does not watch ctx, so the subscription is closed to stop it as soon as ctx is done
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			pubsub.Close()
+		case <-stop:
+		}
+	}()
+
+	subscribed, pinged := false, false
+	for {
+		msg, err := pubsub.ReceiveTimeout(ctx, b.pingInterval)
+		if err != nil {
+			if ctx.Err() != nil || !os.IsTimeout(err) || pinged {
+				return subscribed, err
+			}
+			// nothing was received for a while; a connection that does not answer a ping either is dead
+			pinged = true
+			if err = pubsub.Ping(ctx); err != nil {
+				return subscribed, err
+			}
+			continue
+		}
+
+		pinged = false
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			logger.Infof("Subscribed to cache invalidations on %s", b.channel)
+			subscribed = true
+			b.purge(ctx)
+		case *redis.Message:
+			b.receive(ctx, m.Payload)
+		}
+	}
+}
+
+// publish tells the other instances that key was removed from the cache named name.
+func (b *InvalidationBus) publish(ctx context.Context, name string, key interface{}) {
+	entry := b.reporter.Report(fmt.Sprintf("%s.%s", invalidationMetricName, name))
+	defer entry.Publish()
+
+	k, ok := key.(string)
+	if !ok {
+		logger.WithContext(ctx).Errorf("Unable to publish invalidation of key %v of cache %s: keys must be strings", key, name)
+		entry.Failure()
+		return
+	}
+
+	payload, err := json.Marshal(invalidationMessage{
+		Key:         invalidationKeyPrefix + name + ":" + k,
+		Origin:      b.origin,
+		PublishedAt: b.now().UnixMicro(),
+	})
+	if err == nil {
+		err = b.client.Publish(ctx, b.channel, payload).Err()
+	}
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Unable to publish invalidation of key %s of cache %s: %s", k, name, err)
+		entry.Failure()
+		return
+	}
+	entry.Success()
+}
+
+// receive removes the key of an invalidation published by another instance from the cache it names.
+func (b *InvalidationBus) receive(ctx context.Context, payload string) {
+	var msg invalidationMessage
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		logger.Warnf("Ignoring malformed cache invalidation %q: %s", payload, err)
+		return
+	}
+	if msg.Origin == b.origin {
+		// already removed by broadcastingCache.RemoveKey
+		return
+	}
+
+	name, key, ok := strings.Cut(strings.TrimPrefix(msg.Key, invalidationKeyPrefix), ":")
+	if !ok || !strings.HasPrefix(msg.Key, invalidationKeyPrefix) {
+		logger.Warnf("Ignoring cache invalidation of malformed key %q", msg.Key)
+		return
+	}
+
+	b.mu.RLock()
+	c, ok := b.caches[name]
+	b.mu.RUnlock()
+	if !ok {
+		return
+	}
+	c.RemoveKey(ctx, key)
+
+	entry := b.reporter.Report(fmt.Sprintf("%s.%s", invalidationMetricName, name))
+	entry.Timing("lag", b.now().Sub(time.UnixMicro(msg.PublishedAt)))
+	entry.Publish()
+}
+
+// purge empties every registered cache, dropping whatever invalidations were missed while not subscribed.
+func (b *InvalidationBus) purge(ctx context.Context) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, c := range b.caches {
+		c.Purge(ctx)
+	}
+}
+
+// broadcastingCache is a cache registered with an InvalidationBus, publishing the keys it removes.
+type broadcastingCache struct {
+	InMemCache
+	name string
+	bus  *InvalidationBus
+}
+
+func (c broadcastingCache) RemoveKey(ctx context.Context, key interface{}) {
+	c.InMemCache.RemoveKey(ctx, key)
+	c.bus.publish(ctx, c.name, key)
+}
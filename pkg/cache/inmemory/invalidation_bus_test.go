@@ -0,0 +1,168 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shahbaz275817/prismo/pkg/reporting"
+)
+
+const testInvalidationChannel = "cache_invalidations"
+
+type recordingMetricReporter struct {
+	reporting.MetricReporter
+	mu      sync.Mutex
+	metrics []string
+}
+
+func (r *recordingMetricReporter) Incr(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, key)
+}
+
+func (r *recordingMetricReporter) Timing(key string, _ interface{}) {
+	r.Incr(key)
+}
+
+func (r *recordingMetricReporter) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.metrics...)
+}
+
+// countingCache returns a cache whose loader counts its calls, so tests can tell whether a key was evicted.
+func countingCache(t *testing.T) (InMemCache, *int32) {
+	var loads int32
+	cache, err := NewInMemCache(CacheConfig{
+		LoaderFunc: func(key interface{}) (interface{}, error) {
+			atomic.AddInt32(&loads, 1)
+			return key, nil
+		},
+		Size: 10,
+		Name: "accounts",
+	})
+	assert.NoError(t, err)
+	return cache, &loads
+}
+
+// runBus starts a bus on mr, stopped when the test ends.
+func runBus(t *testing.T, mr *miniredis.Miniredis, reporter *reporting.Reporter) *InvalidationBus {
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	bus, err := NewInvalidationBus(client, testInvalidationChannel, reporter)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		bus.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		_ = client.Close()
+	})
+	return bus
+}
+
+func waitForSubscribers(t *testing.T, mr *miniredis.Miniredis, n int) {
+	assert.Eventually(t, func() bool {
+		return mr.PubSubNumSub(testInvalidationChannel)[testInvalidationChannel] == n
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestInvalidationBus_EvictsKeysRemovedByOtherInstances(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	metrics := &recordingMetricReporter{}
+	first := runBus(t, mr, nil)
+	second := runBus(t, mr, &reporting.Reporter{MetricReporter: metrics})
+	waitForSubscribers(t, mr, 2)
+
+	firstCache, firstLoads := countingCache(t)
+	firstCache = first.Register("accounts", firstCache)
+	secondCache, secondLoads := countingCache(t)
+	secondCache = second.Register("accounts", secondCache)
+
+	for _, c := range []InMemCache{firstCache, secondCache} {
+		_, _ = c.LoadValue(ctx, TenantKey(1, 7))
+		_, _ = c.LoadValue(ctx, TenantKey(1, 8))
+	}
+
+	firstCache.RemoveKey(ctx, TenantKey(1, 7))
+
+	assert.Eventually(t, func() bool {
+		_, _ = secondCache.LoadValue(ctx, TenantKey(1, 7))
+		return atomic.LoadInt32(secondLoads) == 3
+	}, time.Second, 5*time.Millisecond)
+	_, _ = secondCache.LoadValue(ctx, TenantKey(1, 8))
+	assert.Equal(t, int32(3), atomic.LoadInt32(secondLoads))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"timers.cache_invalidation.accounts.lag"}, metrics.recorded())
+	}, time.Second, 5*time.Millisecond)
+
+	// the instance removing the key evicts it right away
+	_, _ = firstCache.LoadValue(ctx, TenantKey(1, 7))
+	assert.Equal(t, int32(3), atomic.LoadInt32(firstLoads))
+}
+
+func TestInvalidationBus_IgnoresCachesNotRegistered(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	first := runBus(t, mr, nil)
+	second := runBus(t, mr, nil)
+	waitForSubscribers(t, mr, 2)
+
+	firstCache, _ := countingCache(t)
+	firstCache = first.Register("users", firstCache)
+	secondCache, secondLoads := countingCache(t)
+	second.Register("accounts", secondCache)
+	_, _ = secondCache.LoadValue(ctx, TenantKey(1, 7))
+
+	firstCache.RemoveKey(ctx, TenantKey(1, 7))
+	time.Sleep(50 * time.Millisecond)
+
+	_, _ = secondCache.LoadValue(ctx, TenantKey(1, 7))
+	assert.Equal(t, int32(1), atomic.LoadInt32(secondLoads))
+}
+
+func TestInvalidationBus_PurgesCachesWhenResubscribing(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	metrics := &recordingMetricReporter{}
+	bus := runBus(t, mr, &reporting.Reporter{MetricReporter: metrics})
+	waitForSubscribers(t, mr, 1)
+
+	cache, loads := countingCache(t)
+	cache = bus.Register("accounts", cache)
+	_, _ = cache.LoadValue(ctx, TenantKey(1, 7))
+
+	// invalidations published while the connection is down are lost
+	mr.Close()
+	assert.NoError(t, mr.Restart())
+	waitForSubscribers(t, mr, 1)
+
+	assert.Eventually(t, func() bool {
+		_, _ = cache.LoadValue(ctx, TenantKey(1, 7))
+		return atomic.LoadInt32(loads) == 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"counters.cache_invalidation.resubscribe.count"}, metrics.recorded())
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestInvalidationBus_NilBusLeavesCacheLocal(t *testing.T) {
+	var bus *InvalidationBus
+	cache, _ := countingCache(t)
+
+	_, broadcasting := bus.Register("accounts", cache).(broadcastingCache)
+	assert.False(t, broadcasting)
+}
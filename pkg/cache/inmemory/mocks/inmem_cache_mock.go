@@ -40,6 +40,11 @@ func (_m *MockInMemCache) LoadValue(ctx context.Context, key interface{}) (inter
 	return r0, r1
 }
 
+// Purge provides a mock function with given fields: ctx
+func (_m *MockInMemCache) Purge(ctx context.Context) {
+	_m.Called(ctx)
+}
+
 // RemoveKey provides a mock function with given fields: ctx, key
 func (_m *MockInMemCache) RemoveKey(ctx context.Context, key interface{}) {
 	_m.Called(ctx, key)
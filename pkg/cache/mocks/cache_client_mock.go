@@ -5418,6 +5418,29 @@ func (_m *MockCacheClient) StrLen(ctx context.Context, key string) *redis.IntCmd
 	return r0
 }
 
+// Subscribe provides a mock function with given fields: ctx, channels
+func (_m *MockCacheClient) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	_va := make([]interface{}, len(channels))
+	for _i := range channels {
+		_va[_i] = channels[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *redis.PubSub
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) *redis.PubSub); ok {
+		r0 = rf(ctx, channels...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*redis.PubSub)
+		}
+	}
+
+	return r0
+}
+
 // TDigestAdd provides a mock function with given fields: ctx, key, elements
 func (_m *MockCacheClient) TDigestAdd(ctx context.Context, key string, elements ...float64) *redis.StatusCmd {
 	_va := make([]interface{}, len(elements))
@@ -11,6 +11,7 @@ const (
 	metricReporter = contextKey("metricReporter")
 	reporterKey    = contextKey("reporter")
 	languageKey    = contextKey("language")
+	tenantIDKey    = contextKey("tenantID")
 )
 
 func GetAPIEndpoint(ctx context.Context) string {
@@ -49,6 +50,16 @@ func Language(ctx context.Context) string {
 	return h
 }
 
+// WithTenantID scopes every repository call made with ctx to the given tenant.
+func WithTenantID(ctx context.Context, tenantID int64) context.Context {
+	return context.WithValue(ctx, tenantIDKey, tenantID)
+}
+
+func TenantID(ctx context.Context) (int64, bool) {
+	t, ok := ctx.Value(tenantIDKey).(int64)
+	return t, ok
+}
+
 func GetReporterEntry(ctx context.Context) *reporting.ReporterEntry {
 	rep, ok := ctx.Value(reporterKey).(*reporting.ReporterEntry)
 	if !ok {
@@ -244,6 +244,39 @@ func NewTooManyRequestsError(code string, details *ErrDetails) TooManyRequestsEr
 	return TooManyRequestsError{newGenericError(code, details)}
 }
 
+type ConflictError struct { // Should map to 409 status code
+	genericErrorImpl
+}
+
+func NewConflictError(code string, details *ErrDetails) ConflictError {
+	return ConflictError{newGenericError(code, details)}
+}
+
+type PreconditionFailedError struct { // Should map to 412 status code
+	genericErrorImpl
+}
+
+func NewPreconditionFailedError(code string, details *ErrDetails) PreconditionFailedError {
+	return PreconditionFailedError{newGenericError(code, details)}
+}
+
+// RetryableError reports an operation that failed because of concurrent work and may succeed when tried again.
+type RetryableError struct { // Should map to 503 status code
+	genericErrorImpl
+}
+
+func NewRetryableError(code string, details *ErrDetails) RetryableError {
+	return RetryableError{newGenericError(code, details)}
+}
+
+type TimeoutError struct { // Should map to 504 status code
+	genericErrorImpl
+}
+
+func NewTimeoutError(code string, details *ErrDetails) TimeoutError {
+	return TimeoutError{newGenericError(code, details)}
+}
+
 type InternalServerError struct {
 	genericErrorImpl
 }
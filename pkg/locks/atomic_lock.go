@@ -2,70 +2,134 @@ package locks
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	defaultErrors "errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/avast/retry-go/v4"
 
-	"github.com/shahbaz275817/prismo/pkg/cache"
 	"github.com/shahbaz275817/prismo/pkg/errors"
 	"github.com/shahbaz275817/prismo/pkg/logger"
+	"github.com/shahbaz275817/prismo/pkg/reporting"
 )
 
-// KeyType represents the type of lock.
+// KeyType represents the type of lock. Every type has its own LockConfig, types without one use the Def config.
 type KeyType string
 
 const (
-	Def KeyType = "DEFAULT"
+	Def             KeyType = "DEFAULT"
+	AccountCreation KeyType = "ACCOUNT_CREATION"
+	// AccountPosting guards the balance affecting changes of an account, e.g. transfers and erasure.
+	AccountPosting KeyType = "ACCOUNT_POSTING"
+	// Scheduler keeps scheduled jobs from running more than once at a time.
+	Scheduler KeyType = "SCHEDULER"
 )
 
-// LockConfig holds configuration for a particular type of lock.
+// LockConfig holds configuration for a particular type of lock. Retries back off exponentially from RetryDelay up to
+// RetryMaxDelay, if set, with a random jitter of up to RetryMaxJitter added to every delay.
 type LockConfig struct {
-	LockExpiry    time.Duration
-	RetryAttempts uint
-	RetryDelay    time.Duration
+	LockExpiry     time.Duration
+	RetryAttempts  uint
+	RetryDelay     time.Duration
+	RetryMaxDelay  time.Duration
+	RetryMaxJitter time.Duration
 }
 
+const lockMetricKey = "lock"
+
 // LockState holds the state of the lock.
 type LockState struct {
 	sync.Mutex
-	LockKeys map[string]struct{}
+	LockKeys      map[string]struct{}
+	ownerTokens   map[string]string
+	fencingTokens map[string]int64
+}
+
+// FencingToken returns the fencing token handed out when the lock on key was acquired. Tokens for a key increase
+// with every acquisition, so storage that remembers the highest token it has seen can reject writes from a holder
+// whose lock expired in the meantime.
+func (s *LockState) FencingToken(key string) (int64, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	token, ok := s.fencingTokens[key]
+	return token, ok
 }
 
 // AtomicLock provides methods for distributed locking.
 type AtomicLock struct {
-	client  cache.Client
-	configs map[KeyType]LockConfig
+	locker   Locker
+	configs  map[KeyType]LockConfig
+	reporter *reporting.Reporter
 }
 
 // AtomicLock's Singleton Instance
 var atomicLockInstance *AtomicLock
 
-// NewAtomicLock creates a new AtomicLock with the given cache client and configuration.
-func NewAtomicLock(client cache.Client, config map[KeyType]LockConfig) *AtomicLock {
+// NewAtomicLock creates a new AtomicLock keeping its locks in the given locker. Acquisition latency, contention,
+// timeouts and lost locks are reported per key type through reporter, which may be nil.
+func NewAtomicLock(locker Locker, config map[KeyType]LockConfig, reporter *reporting.Reporter) *AtomicLock {
 	return &AtomicLock{
-		client:  client,
-		configs: config,
+		locker:   locker,
+		configs:  config,
+		reporter: reporter,
 	}
 }
 
-// Execute executes a function within a lock for a given key.
+// Execute executes a function within a lock for a given key. While the function runs the lock's expiry is extended
+// in the background, so long running functions do not lose the lock half way through.
 func (a *AtomicLock) Execute(ctx context.Context, key string, keyType KeyType, f func(*LockState) ([]interface{}, error), state *LockState) ([]interface{}, error) {
 	config := a.getConfig(keyType)
 
 	if !a.isReentrant(state, key) {
-		err := a.getLockWithRetries(ctx, key, state, config.LockExpiry, config.RetryAttempts, config.RetryDelay)
+		entry := a.reporter.Report(fmt.Sprintf("%s.%s", lockMetricKey, strings.ToLower(string(keyType))))
+		defer entry.Publish()
+
+		err := a.getLockWithRetries(ctx, key, state, config, entry)
 		if err != nil {
 			return nil, err
 		}
-		defer a.releaseLock(state, key)
+		stop := a.keepAlive(ctx, key, a.ownerToken(state, key), config.LockExpiry, entry)
+		defer func() {
+			stop()
+			a.releaseLock(state, key)
+		}()
 	}
 
 	return f(state)
 }
 
+// ExecuteAll executes a function while holding locks on all the given keys. Keys are acquired in sorted order, so
+// callers locking overlapping sets of keys cannot deadlock each other.
+func (a *AtomicLock) ExecuteAll(ctx context.Context, keys []string, keyType KeyType, f func(*LockState) ([]interface{}, error), state *LockState) ([]interface{}, error) {
+	sorted := make([]string, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	var execute func(i int) ([]interface{}, error)
+	execute = func(i int) ([]interface{}, error) {
+		if i == len(sorted) {
+			return f(state)
+		}
+		return a.Execute(ctx, sorted[i], keyType, func(*LockState) ([]interface{}, error) {
+			return execute(i + 1)
+		}, state)
+	}
+	return execute(0)
+}
+
 // isReentrant checks if the current goroutine already owns the lock.
 func (a *AtomicLock) isReentrant(state *LockState, key string) bool {
 	state.Lock()
@@ -75,84 +139,168 @@ func (a *AtomicLock) isReentrant(state *LockState, key string) bool {
 	return exists
 }
 
-// getConfig gets the lock configuration for the given key type, falling back to default if not found.
+// getConfig gets the lock configuration for the given key type, falling back to Def if not found.
 func (a *AtomicLock) getConfig(keyType KeyType) LockConfig {
 	if config, ok := a.configs[keyType]; ok {
 		return config
 	}
-	return a.configs["default"]
+	return a.configs[Def]
 }
 
-func (a *AtomicLock) getLockWithRetries(ctx context.Context, key string, state *LockState, expiry time.Duration, attempts uint, delay time.Duration) error {
+// getLockWithRetries acquires the lock on key, reporting how long it took and whether it timed out waiting for it.
+func (a *AtomicLock) getLockWithRetries(ctx context.Context, key string, state *LockState, config LockConfig, entry *reporting.ReporterEntry) error {
+	start := time.Now()
 	err := retry.Do(
 		func() error {
-			return a.lock(state, key, expiry)
+			return a.lock(ctx, state, key, config.LockExpiry, entry)
 		},
-		retry.Attempts(attempts), retry.Delay(delay))
+		append(retryOptions(config), retry.Context(ctx))...)
+	entry.Timing("acquire_latency", time.Since(start))
 	if err != nil {
-		return handleError(ctx, err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			entry.Timeout()
+			return ctxErr
+		}
+		err = handleError(ctx, err)
+		if defaultErrors.As(err, &errors.EntityLockedError{}) {
+			entry.Timeout()
+		} else {
+			entry.Failure()
+		}
+		return err
 	}
+	entry.Success()
 	return nil
 }
 
-// lock attempts to acquire a lock on the given key with the specified expiry.
-func (a *AtomicLock) lock(state *LockState, key string, expiry time.Duration) error {
-	err := a.setKeyInCache(key, expiry)
+func retryOptions(config LockConfig) []retry.Option {
+	options := []retry.Option{retry.Attempts(config.RetryAttempts), retry.Delay(config.RetryDelay)}
+	if config.RetryMaxDelay > 0 {
+		options = append(options, retry.MaxDelay(config.RetryMaxDelay))
+	}
+	if config.RetryMaxJitter > 0 {
+		return append(options, retry.MaxJitter(config.RetryMaxJitter),
+			retry.DelayType(retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)))
+	}
+	return append(options, retry.DelayType(retry.BackOffDelay))
+}
+
+// lock attempts to acquire a lock on the given key with the specified expiry under a fresh owner token.
+func (a *AtomicLock) lock(ctx context.Context, state *LockState, key string, expiry time.Duration, entry *reporting.ReporterEntry) error {
+	token, err := newOwnerToken()
+	if err != nil {
+		return err
+	}
+	fencingToken, err := a.locker.Acquire(ctx, key, token, expiry)
 	if err != nil {
 		return err
 	}
-	a.setKeyInLockState(state, key)
+	if fencingToken == 0 {
+		entry.Incr("contention")
+		return errors.NewEntityLockedError("unable_to_acquire_lock", &errors.ErrDetails{
+			Message: fmt.Sprintf("Unable to acquire lock on key %s", key),
+		})
+	}
+	a.setKeyInLockState(state, key, token, fencingToken)
 	return nil
 }
 
-// releaseLock releases the lock on the given key if owned.
+// keepAlive extends the lock on key every third of its expiry for as long as it is still held by token. The returned
+// function stops the extension and waits for it to finish.
+func (a *AtomicLock) keepAlive(ctx context.Context, key, token string, expiry time.Duration, entry *reporting.ReporterEntry) func() {
+	interval := expiry / 3
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				extended, err := a.locker.Extend(context.Background(), key, token, expiry)
+				if err != nil {
+					logger.WithContext(ctx).Warnf("Unable to extend lock on key %s: %s", key, err)
+					continue
+				}
+				if !extended {
+					entry.Incr("lost")
+					logger.WithContext(ctx).Errorf("Lock on key %s was lost before the locked operation finished", key)
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// releaseLock releases the lock on the given key if it is still owned by the state's owner token.
 func (a *AtomicLock) releaseLock(state *LockState, key string) error {
-	_, err := a.deleteKeyInCache(key)
+	token := a.ownerToken(state, key)
+	a.deleteKeyFromLockState(state, key)
+
+	released, err := a.locker.Release(context.Background(), key, token)
 	if err != nil {
+		logger.Warnf("Unable to release lock on key %s: %s", key, err)
 		return err
 	}
-	a.deleteKeyFromLockState(state, key)
+	if !released {
+		logger.Warnf("Lock on key %s expired before it was released", key)
+	}
 	return nil
 }
 
-func (a *AtomicLock) setKeyInLockState(state *LockState, key string) {
+func (a *AtomicLock) ownerToken(state *LockState, key string) string {
+	state.Lock()
+	defer state.Unlock()
+
+	return state.ownerTokens[key]
+}
+
+func (a *AtomicLock) setKeyInLockState(state *LockState, key, token string, fencingToken int64) {
 	state.Lock()
 	defer state.Unlock()
 
 	if state.LockKeys == nil {
 		state.LockKeys = make(map[string]struct{})
 	}
+	if state.ownerTokens == nil {
+		state.ownerTokens = make(map[string]string)
+	}
+	if state.fencingTokens == nil {
+		state.fencingTokens = make(map[string]int64)
+	}
 	state.LockKeys[key] = struct{}{}
+	state.ownerTokens[key] = token
+	state.fencingTokens[key] = fencingToken
 }
 
 func (a *AtomicLock) deleteKeyFromLockState(l *LockState, key string) {
 	l.Lock()
 	defer l.Unlock()
 
-	if l.LockKeys != nil {
-		delete(l.LockKeys, key)
-	}
-}
-
-func (a *AtomicLock) setKeyInCache(key string, expiry time.Duration) error {
-	res, err := a.client.SetNX(context.Background(), key, true, expiry).Result()
-	if err != nil {
-		return err
-	}
-	if !res {
-		return errors.NewEntityLockedError("unable_to_acquire_lock", &errors.ErrDetails{
-			Message: fmt.Sprintf("Unable to acquire lock on key %s", key),
-		})
-	}
-	return nil
+	delete(l.LockKeys, key)
+	delete(l.ownerTokens, key)
+	delete(l.fencingTokens, key)
 }
 
-func (a *AtomicLock) deleteKeyInCache(key string) (bool, error) {
-	res, err := a.client.Del(context.Background(), key).Result()
-	if err != nil {
-		return false, err
+func newOwnerToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
-	return res != 0, err
+	return hex.EncodeToString(b), nil
 }
 
 func handleError(ctx context.Context, err error) error {
@@ -6,228 +6,389 @@ import (
 	"testing"
 	"time"
 
-	"github.com/stretchr/testify/assert"
-
+	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
-	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/assert"
 
-	"github.com/shahbaz275817/prismo/pkg/cache/mocks"
 	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/reporting"
 )
 
-func TestAtomicLockExecute_Lock_Success(t *testing.T) {
-	client := &mocks.MockCacheClient{}
-	config := map[KeyType]LockConfig{
-		Def: {
-			LockExpiry:    time.Duration(10) * time.Millisecond,
-			RetryAttempts: uint(1),
-			RetryDelay:    time.Duration(1) * time.Millisecond,
-		},
+// scriptRecordingClient records the keys of every script run against redis, so tests can follow the order in which
+// locks are acquired and released.
+type scriptRecordingClient struct {
+	*redis.Client
+	mu      sync.Mutex
+	scripts [][]string
+	after   func(keys []string)
+}
+
+func (c *scriptRecordingClient) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	return c.record(c.Client.EvalSha(ctx, sha1, keys, args...), keys)
+}
+
+func (c *scriptRecordingClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd {
+	return c.record(c.Client.Eval(ctx, script, keys, args...), keys)
+}
+
+// record skips the EvalSha calls redis rejects before the script is loaded, since they are retried with Eval.
+func (c *scriptRecordingClient) record(cmd *redis.Cmd, keys []string) *redis.Cmd {
+	if redis.HasErrorPrefix(cmd.Err(), "NOSCRIPT") {
+		return cmd
+	}
+	c.mu.Lock()
+	c.scripts = append(c.scripts, keys)
+	after := c.after
+	c.mu.Unlock()
+	if after != nil {
+		after(keys)
+	}
+	return cmd
+}
+
+// acquired returns the keys acquisitions were attempted on, in order. Acquisitions are the only scripts that also
+// touch the fencing counter.
+func (c *scriptRecordingClient) acquired() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []string
+	for _, script := range c.scripts {
+		if len(script) > 1 && script[1] == fencingCounterKey {
+			keys = append(keys, script[0])
+		}
 	}
+	return keys
+}
 
-	atomicLock := NewAtomicLock(client, config)
+func newTestAtomicLock(t *testing.T, config LockConfig) (*AtomicLock, *scriptRecordingClient, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := &scriptRecordingClient{Client: redis.NewClient(&redis.Options{Addr: mr.Addr()})}
+	t.Cleanup(func() { _ = client.Close() })
+	return NewAtomicLock(NewRedisLocker(client), map[KeyType]LockConfig{Def: config}, nil), client, mr
+}
+
+var testLockConfig = LockConfig{
+	LockExpiry:    time.Second,
+	RetryAttempts: uint(1),
+	RetryDelay:    time.Duration(1) * time.Millisecond,
+}
 
+func TestAtomicLockExecute_Lock_Success(t *testing.T) {
 	tests := []struct {
 		name       string
 		key        string
 		keyType    KeyType
-		atomicFunc func(lockState *LockState) ([]interface{}, error)
-		mockFunc   func()
+		atomicFunc func(atomicLock *AtomicLock) func(lockState *LockState) ([]interface{}, error)
 		state      *LockState
-		wantErr    bool
+		wantLocked bool
 	}{
 		{
 			name:    "test successful lock on a default key",
 			key:     "abc",
 			keyType: Def,
-			atomicFunc: func(lockState *LockState) ([]interface{}, error) {
-				return nil, nil
-			},
-			mockFunc: func() {
-				client.On("SetNX", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(redis.NewBoolResult(true, nil)).Once()
-				client.On("Del", mock.Anything, mock.Anything).Return(redis.NewIntResult(1, nil)).Once()
-			},
-			state:   &LockState{Mutex: sync.Mutex{}},
-			wantErr: false,
-		},
-		{
-			name:    "test different KeyType config",
-			key:     "xyz",
-			keyType: Def,
-			atomicFunc: func(lockState *LockState) ([]interface{}, error) {
-				return nil, nil
-			},
-			mockFunc: func() {
-				client.On("SetNX", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(redis.NewBoolResult(true, nil)).Once()
-				client.On("Del", mock.Anything, mock.Anything).Return(redis.NewIntResult(1, nil)).Once()
+			atomicFunc: func(*AtomicLock) func(lockState *LockState) ([]interface{}, error) {
+				return func(lockState *LockState) ([]interface{}, error) {
+					return nil, nil
+				}
 			},
-			state:   &LockState{Mutex: sync.Mutex{}},
-			wantErr: false,
+			state:      &LockState{Mutex: sync.Mutex{}},
+			wantLocked: true,
 		},
 		{
 			name:    "test reentrant lock when key is already present in lock state",
 			key:     "abc",
 			keyType: Def,
-			atomicFunc: func(lockState *LockState) ([]interface{}, error) {
-				return nil, nil
-			},
-			mockFunc: func() {
-				// No SetNX or Del calls expected because it should recognize the reentrant lock
+			atomicFunc: func(*AtomicLock) func(lockState *LockState) ([]interface{}, error) {
+				return func(lockState *LockState) ([]interface{}, error) {
+					return nil, nil
+				}
 			},
 			state: &LockState{
 				Mutex:    sync.Mutex{},
 				LockKeys: map[string]struct{}{"abc": {}},
 			},
-			wantErr: false,
-		}, {
+			// no acquisition expected because it should recognize the reentrant lock
+			wantLocked: false,
+		},
+		{
 			name:    "test re-entrant lock when atomic lock is called inside inner func",
 			key:     "xyz",
 			keyType: Def,
-			atomicFunc: func(lockState *LockState) ([]interface{}, error) {
-				innerFunc := func(innerLockState *LockState) ([]interface{}, error) {
-					// Do something here if needed
-					return nil, nil
-				}
-				ctx := context.Background()
-				_, err := atomicLock.Execute(ctx, "xyz", Def, innerFunc, lockState)
-				if err != nil {
-					return nil, err
+			atomicFunc: func(atomicLock *AtomicLock) func(lockState *LockState) ([]interface{}, error) {
+				return func(lockState *LockState) ([]interface{}, error) {
+					innerFunc := func(innerLockState *LockState) ([]interface{}, error) {
+						return nil, nil
+					}
+					return atomicLock.Execute(context.Background(), "xyz", Def, innerFunc, lockState)
 				}
-				return nil, nil
-			},
-			mockFunc: func() {
-				client.On("SetNX", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(redis.NewBoolResult(true, nil)).Once()
-				client.On("Del", mock.Anything, mock.Anything).Return(redis.NewIntResult(1, nil)).Once()
 			},
-			state:   &LockState{Mutex: sync.Mutex{}},
-			wantErr: false,
+			state:      &LockState{Mutex: sync.Mutex{}},
+			wantLocked: true,
 		},
 	}
 
 	for _, tt := range tests {
-
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
-			// Clear expectations from previous runs
-			client.ExpectedCalls = nil
-			client.Calls = nil
-
-			//Execute mock function
-			tt.mockFunc()
+			atomicLock, client, mr := newTestAtomicLock(t, testLockConfig)
 
-			//Execute the main testing function
-			_, err := atomicLock.Execute(ctx, tt.key, tt.keyType, tt.atomicFunc, tt.state)
+			_, err := atomicLock.Execute(context.Background(), tt.key, tt.keyType, tt.atomicFunc(atomicLock), tt.state)
 
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Test Failed: got error = %v, wantErr %v", err, tt.wantErr)
+			assert.NoError(t, err)
+			if tt.wantLocked {
+				assert.Equal(t, []string{tt.key}, client.acquired())
+				assert.Empty(t, tt.state.LockKeys)
+			} else {
+				assert.Empty(t, client.acquired())
 			}
-
-			// Assert that all expected calls were made
-			client.AssertExpectations(t)
-
+			assert.False(t, mr.Exists(tt.key))
 		})
-
 	}
 }
 
 func TestAtomicLock_Execute_Lock_Failures(t *testing.T) {
-	client := &mocks.MockCacheClient{}
-	config := map[KeyType]LockConfig{
-		Def: {
-			LockExpiry:    time.Duration(10) * time.Millisecond,
-			RetryAttempts: uint(1),
-			RetryDelay:    time.Duration(1) * time.Millisecond,
-		},
-	}
-
-	atomicLock := NewAtomicLock(client, config)
-
 	tests := []struct {
 		name        string
-		key         string
-		keyType     KeyType
-		atomicFunc  func(lockState *LockState) ([]interface{}, error)
-		mockFunc    func()
-		state       *LockState
+		config      LockConfig
+		setup       func(client *scriptRecordingClient, mr *miniredis.Miniredis)
 		wantErr     bool
 		expectedErr error
 	}{
 		{
-			name:    "when lock acquisition fails with no retries",
-			key:     "def",
-			keyType: Def,
-			atomicFunc: func(lockState *LockState) ([]interface{}, error) {
-				return nil, nil
+			name:   "when lock acquisition fails with no retries",
+			config: testLockConfig,
+			setup: func(client *scriptRecordingClient, mr *miniredis.Miniredis) {
+				_ = mr.Set("abc", "other-owner")
 			},
-			mockFunc: func() {
-				client.On("SetNX", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(redis.NewBoolResult(false, nil)).Once()
-			},
-			state:       &LockState{Mutex: sync.Mutex{}},
 			wantErr:     true,
 			expectedErr: errors.EntityLockedError{},
 		},
-
 		{
-			name:    "when lock is acquired on second attempt",
-			key:     "abc",
-			keyType: Def,
-			atomicFunc: func(lockState *LockState) ([]interface{}, error) {
-				return nil, nil
-			},
-			mockFunc: func() {
-				client.On("SetNX", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(redis.NewBoolResult(false, nil)).Once()
-				client.On("SetNX", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(redis.NewBoolResult(true, nil)).Once()
-				client.On("Del", mock.Anything, mock.Anything).Return(redis.NewIntResult(1, nil)).Once()
+			name: "when lock is acquired on second attempt",
+			config: LockConfig{
+				LockExpiry:    time.Second,
+				RetryAttempts: uint(2),
+				RetryDelay:    time.Duration(1) * time.Millisecond,
 			},
-			state: &LockState{
-				Mutex: sync.Mutex{},
+			setup: func(client *scriptRecordingClient, mr *miniredis.Miniredis) {
+				_ = mr.Set("abc", "other-owner")
+				client.after = func([]string) {
+					// the other owner releases its lock after our first attempt
+					mr.Del("abc")
+				}
 			},
 			wantErr: false,
 		},
 		{
-			name:    "when client keeps throwing error while retry attempts are exhausted",
-			key:     "abc",
-			keyType: Def,
-			atomicFunc: func(lockState *LockState) ([]interface{}, error) {
-				return nil, nil
+			name:   "when client keeps throwing error while retry attempts are exhausted",
+			config: testLockConfig,
+			setup: func(client *scriptRecordingClient, mr *miniredis.Miniredis) {
+				mr.SetError("Lock Error")
 			},
-			mockFunc: func() {
-				client.On("SetNX", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(redis.NewBoolResult(false, errors.New("Lock Error"))).Once()
-			},
-			state: &LockState{
-				Mutex: sync.Mutex{},
-			},
-			wantErr: true,
+			wantErr:     true,
+			expectedErr: errors.InternalServerError{},
 		},
 	}
 
 	for _, tt := range tests {
-
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
-			// Clear expectations from previous runs
-			client.ExpectedCalls = nil
-			client.Calls = nil
+			atomicLock, client, mr := newTestAtomicLock(t, tt.config)
+			tt.setup(client, mr)
 
-			//Execute mock function
-			tt.mockFunc()
-
-			//Execute the main testing function
-			_, err := atomicLock.Execute(ctx, tt.key, tt.keyType, tt.atomicFunc, tt.state)
+			_, err := atomicLock.Execute(context.Background(), "abc", Def, func(lockState *LockState) ([]interface{}, error) {
+				return nil, nil
+			}, &LockState{})
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Test Failed: got error = %v, wantErr %v", err, tt.wantErr)
 			}
-
 			if tt.expectedErr != nil {
 				assert.ErrorAs(t, err, &tt.expectedErr)
 			}
+		})
+	}
+}
 
-			// Assert that all expected calls were made
-			client.AssertExpectations(t)
+func TestAtomicLock_Execute_DoesNotReleaseLockOwnedByAnotherInstance(t *testing.T) {
+	atomicLock, _, mr := newTestAtomicLock(t, testLockConfig)
 
-		})
+	_, err := atomicLock.Execute(context.Background(), "abc", Def, func(lockState *LockState) ([]interface{}, error) {
+		// our lock expires and another instance acquires it before we are done
+		mr.FastForward(2 * time.Second)
+		_ = mr.Set("abc", "other-owner")
+		return nil, nil
+	}, &LockState{})
+
+	assert.NoError(t, err)
+	value, _ := mr.Get("abc")
+	assert.Equal(t, "other-owner", value)
+}
+
+func TestAtomicLock_Execute_ExtendsLockWhileFunctionRuns(t *testing.T) {
+	atomicLock, _, mr := newTestAtomicLock(t, LockConfig{
+		LockExpiry:    30 * time.Millisecond,
+		RetryAttempts: uint(1),
+		RetryDelay:    time.Millisecond,
+	})
+
+	_, err := atomicLock.Execute(context.Background(), "abc", Def, func(lockState *LockState) ([]interface{}, error) {
+		mr.FastForward(20 * time.Millisecond)
+		assert.Eventually(t, func() bool {
+			return mr.TTL("abc") == 30*time.Millisecond
+		}, time.Second, 5*time.Millisecond)
+		return nil, nil
+	}, &LockState{})
+
+	assert.NoError(t, err)
+	assert.False(t, mr.Exists("abc"))
+}
 
+func TestAtomicLock_Execute_HandsOutIncreasingFencingTokens(t *testing.T) {
+	atomicLock, _, _ := newTestAtomicLock(t, testLockConfig)
+
+	var tokens []int64
+	for i := 0; i < 2; i++ {
+		_, err := atomicLock.Execute(context.Background(), "abc", Def, func(lockState *LockState) ([]interface{}, error) {
+			token, ok := lockState.FencingToken("abc")
+			assert.True(t, ok)
+			tokens = append(tokens, token)
+			return nil, nil
+		}, &LockState{})
+		assert.NoError(t, err)
 	}
 
+	assert.Equal(t, []int64{1, 2}, tokens)
+}
+
+func TestAtomicLock_Execute_StopsRetryingWhenContextIsDone(t *testing.T) {
+	atomicLock, client, mr := newTestAtomicLock(t, LockConfig{
+		LockExpiry:    time.Second,
+		RetryAttempts: uint(100),
+		RetryDelay:    50 * time.Millisecond,
+	})
+	_ = mr.Set("abc", "other-owner")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := atomicLock.Execute(ctx, "abc", Def, func(lockState *LockState) ([]interface{}, error) {
+		return nil, nil
+	}, &LockState{})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, client.acquired(), 1)
+}
+
+func TestAtomicLock_ExecuteAll(t *testing.T) {
+	atomicLock, client, mr := newTestAtomicLock(t, testLockConfig)
+
+	state := &LockState{}
+	_, err := atomicLock.ExecuteAll(context.Background(), []string{"account_9", "account_10", "account_9"}, Def, func(lockState *LockState) ([]interface{}, error) {
+		assert.Len(t, lockState.LockKeys, 2)
+		assert.True(t, mr.Exists("account_9"))
+		assert.True(t, mr.Exists("account_10"))
+		return nil, nil
+	}, state)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"account_10", "account_9"}, client.acquired())
+	assert.False(t, mr.Exists("account_9"))
+	assert.False(t, mr.Exists("account_10"))
+	assert.Empty(t, state.LockKeys)
+}
+
+func TestAtomicLock_getConfig(t *testing.T) {
+	defaultConfig := LockConfig{LockExpiry: time.Second, RetryAttempts: 5}
+	postingConfig := LockConfig{LockExpiry: 5 * time.Second, RetryAttempts: 8}
+	atomicLock := NewAtomicLock(NewMemoryLocker(), map[KeyType]LockConfig{Def: defaultConfig, AccountPosting: postingConfig}, nil)
+
+	assert.Equal(t, postingConfig, atomicLock.getConfig(AccountPosting))
+	assert.Equal(t, defaultConfig, atomicLock.getConfig(Scheduler))
+}
+
+type recordingMetricReporter struct {
+	reporting.MetricReporter
+	mu      sync.Mutex
+	metrics []string
+}
+
+func (r *recordingMetricReporter) Incr(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, key)
+}
+
+func (r *recordingMetricReporter) Timing(key string, _ interface{}) {
+	r.Incr(key)
+}
+
+func (r *recordingMetricReporter) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.metrics...)
+}
+
+func TestAtomicLock_Execute_ReportsMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts uint
+		// releaseAfter frees the lock held by another owner after that many of our attempts, 0 keeps it held
+		releaseAfter int
+		want         []string
+	}{
+		{
+			name:         "acquired after contention",
+			attempts:     2,
+			releaseAfter: 1,
+			want: []string{
+				"counters.lock.account_posting.contention.count",
+				"counters.lock.account_posting.success.count",
+				"timers.lock.account_posting.acquire_latency",
+			},
+		},
+		{
+			name:     "timed out waiting",
+			attempts: 2,
+			want: []string{
+				"counters.lock.account_posting.contention.count",
+				"counters.lock.account_posting.contention.count",
+				"counters.lock.account_posting.timeout.count",
+				"timers.lock.account_posting.acquire_latency",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			memoryLocker := NewMemoryLocker()
+			_, _ = memoryLocker.Acquire(ctx, "abc", "other-owner", time.Minute)
+			locker := &releasingLocker{Locker: memoryLocker, releaseAfter: tt.releaseAfter}
+
+			mr := &recordingMetricReporter{}
+			atomicLock := NewAtomicLock(locker, map[KeyType]LockConfig{
+				Def: {LockExpiry: time.Second, RetryAttempts: tt.attempts, RetryDelay: time.Millisecond},
+			}, &reporting.Reporter{MetricReporter: mr})
+
+			_, _ = atomicLock.Execute(ctx, "abc", AccountPosting, func(*LockState) ([]interface{}, error) {
+				return nil, nil
+			}, &LockState{})
+
+			assert.Eventually(t, func() bool { return len(mr.recorded()) == len(tt.want) }, time.Second, time.Millisecond)
+			assert.ElementsMatch(t, tt.want, mr.recorded())
+		})
+	}
+}
+
+// releasingLocker releases the lock another owner holds on a key once the given number of attempts failed on it.
+type releasingLocker struct {
+	Locker
+	attempts     int
+	releaseAfter int
+}
+
+func (l *releasingLocker) Acquire(ctx context.Context, key, token string, expiry time.Duration) (int64, error) {
+	if l.attempts == l.releaseAfter && l.releaseAfter > 0 {
+		_, _ = l.Locker.Release(ctx, key, "other-owner")
+	}
+	l.attempts++
+	return l.Locker.Acquire(ctx, key, token, expiry)
 }
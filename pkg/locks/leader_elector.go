@@ -0,0 +1,149 @@
+package locks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shahbaz275817/prismo/pkg/cache"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+// minLeaderLease is the shortest lease an elector accepts. Leases are kept in Redis in milliseconds and renewed every
+// third of their length.
+const minLeaderLease = 3 * time.Millisecond
+
+// LeadershipObserver is told whenever an elector becomes or stops being the leader.
+type LeadershipObserver func(isLeader bool)
+
+// LeaderElector elects a single leader among the instances running it with the same key, e.g. to run background
+// workers on one instance only. The leader holds a lease on key and renews it every third of its length; when it
+// has not renewed the lease for two thirds of its length, it steps down before the lease runs out and another
+// instance can take over.
+type LeaderElector struct {
+	client    cache.Client
+	key       string
+	id        string
+	lease     time.Duration
+	mu        sync.Mutex
+	leader    bool
+	observers []LeadershipObserver
+}
+
+// NewLeaderElector creates an elector competing for the leadership kept under key. The lease must be at least 3ms.
+func NewLeaderElector(client cache.Client, key string, lease time.Duration) (*LeaderElector, error) {
+	if lease < minLeaderLease {
+		return nil, fmt.Errorf("leader lease must be at least %s, got %s", minLeaderLease, lease)
+	}
+	id, err := newOwnerToken()
+	if err != nil {
+		return nil, err
+	}
+	return &LeaderElector{
+		client: client,
+		key:    key,
+		id:     id,
+		lease:  lease,
+	}, nil
+}
+
+// OnLeadershipChange registers an observer. Observers are called in order from the goroutine running Run.
+func (e *LeaderElector) OnLeadershipChange(observer LeadershipObserver) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.observers = append(e.observers, observer)
+}
+
+// IsLeader reports whether this elector currently holds the leadership.
+func (e *LeaderElector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.leader
+}
+
+// Run campaigns for the leadership and renews it until ctx is done, after which it resigns.
+func (e *LeaderElector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.lease / 3)
+	defer ticker.Stop()
+
+	var lastRenewal time.Time
+	for {
+		// the lease runs from when it was asked for at the latest, so that is when it counts as renewed
+		start := time.Now()
+		if e.IsLeader() {
+			lastRenewal = e.renew(ctx, start, lastRenewal)
+		} else if e.campaign(ctx) {
+			lastRenewal = start
+		}
+
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign tries to take the leadership, reporting whether it did.
+func (e *LeaderElector) campaign(ctx context.Context) bool {
+	acquired, err := e.client.SetNX(ctx, e.key, e.id, e.lease).Result()
+	if err != nil {
+		logger.Warnf("Unable to campaign for leadership of %s: %s", e.key, err)
+		return false
+	}
+	if acquired {
+		logger.Infof("Became leader of %s", e.key)
+		e.setLeader(true)
+	}
+	return acquired
+}
+
+// renew extends the leader's lease, returning when it was last renewed. The leader steps down when the lease is held
+// by someone else, or when it could not be renewed for two thirds of the lease, leaving a third of it as a margin for
+// clock drift and for the work it leads to notice.
+func (e *LeaderElector) renew(ctx context.Context, start, lastRenewal time.Time) time.Time {
+	renewed, err := extendScript.Run(ctx, e.client, []string{e.key}, e.id, milliseconds(e.lease)).Int()
+	if err == nil && renewed == 1 {
+		return start
+	}
+
+	if err != nil {
+		logger.Warnf("Unable to renew leadership of %s: %s", e.key, err)
+		if time.Since(lastRenewal) <= e.lease-e.lease/3 {
+			return lastRenewal
+		}
+	}
+	logger.Warnf("Lost leadership of %s", e.key)
+	e.setLeader(false)
+	return lastRenewal
+}
+
+// resign gives up the leadership, so another instance can take over without waiting for the lease to run out.
+func (e *LeaderElector) resign() {
+	if !e.IsLeader() {
+		return
+	}
+	if err := releaseScript.Run(context.Background(), e.client, []string{e.key}, e.id).Err(); err != nil {
+		logger.Warnf("Unable to resign leadership of %s: %s", e.key, err)
+	}
+	e.setLeader(false)
+}
+
+func (e *LeaderElector) setLeader(leader bool) {
+	e.mu.Lock()
+	changed := e.leader != leader
+	e.leader = leader
+	observers := append([]LeadershipObserver(nil), e.observers...)
+	e.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	for _, observer := range observers {
+		observer(leader)
+	}
+}
@@ -0,0 +1,116 @@
+package locks
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type leadershipRecorder struct {
+	mu      sync.Mutex
+	changes []bool
+}
+
+func (r *leadershipRecorder) observe(isLeader bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, isLeader)
+}
+
+func (r *leadershipRecorder) recorded() []bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]bool(nil), r.changes...)
+}
+
+func runTestElector(t *testing.T, elector *LeaderElector) (*leadershipRecorder, context.CancelFunc) {
+	recorder := &leadershipRecorder{}
+	elector.OnLeadershipChange(recorder.observe)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.Run(ctx)
+	}()
+	stop := func() {
+		cancel()
+		<-done
+	}
+	t.Cleanup(stop)
+	return recorder, stop
+}
+
+func TestLeaderElector_ElectsOneLeaderAndHandsOverOnResign(t *testing.T) {
+	client, mr := newTestRedisClient(t)
+	first, err := NewLeaderElector(client, "workers", 30*time.Millisecond)
+	assert.NoError(t, err)
+	second, err := NewLeaderElector(client, "workers", 30*time.Millisecond)
+	assert.NoError(t, err)
+
+	firstChanges, stopFirst := runTestElector(t, first)
+	assert.Eventually(t, first.IsLeader, time.Second, time.Millisecond)
+	secondChanges, _ := runTestElector(t, second)
+
+	// the leader keeps renewing its lease, so the other elector stays a follower
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, first.IsLeader())
+	assert.False(t, second.IsLeader())
+	value, _ := mr.Get("workers")
+	assert.Equal(t, first.id, value)
+
+	stopFirst()
+	assert.False(t, first.IsLeader())
+	assert.Eventually(t, second.IsLeader, time.Second, time.Millisecond)
+
+	assert.Equal(t, []bool{true, false}, firstChanges.recorded())
+	assert.Equal(t, []bool{true}, secondChanges.recorded())
+}
+
+func TestLeaderElector_StepsDownWhenLeaseIsTakenOver(t *testing.T) {
+	client, mr := newTestRedisClient(t)
+	elector, err := NewLeaderElector(client, "workers", 30*time.Millisecond)
+	assert.NoError(t, err)
+
+	changes, _ := runTestElector(t, elector)
+	assert.Eventually(t, elector.IsLeader, time.Second, time.Millisecond)
+
+	// the lease ran out and another instance took over
+	_ = mr.Set("workers", "other-instance")
+	assert.Eventually(t, func() bool { return !elector.IsLeader() }, time.Second, time.Millisecond)
+	assert.Equal(t, []bool{true, false}, changes.recorded())
+
+	mr.Del("workers")
+	assert.Eventually(t, elector.IsLeader, time.Second, time.Millisecond)
+	assert.Equal(t, []bool{true, false, true}, changes.recorded())
+}
+
+func TestLeaderElector_StepsDownBeforeLeaseRunsOutWhenRedisIsUnavailable(t *testing.T) {
+	client, mr := newTestRedisClient(t)
+	elector, err := NewLeaderElector(client, "workers", 30*time.Second)
+	assert.NoError(t, err)
+	elector.setLeader(true)
+	mr.Close()
+
+	// a failed renewal within two thirds of the lease keeps the leadership
+	lastRenewal := time.Now().Add(-15 * time.Second)
+	assert.Equal(t, lastRenewal, elector.renew(context.Background(), time.Now(), lastRenewal))
+	assert.True(t, elector.IsLeader())
+
+	// past that the leader steps down, a third of the lease before another instance could take over
+	elector.renew(context.Background(), time.Now(), time.Now().Add(-21*time.Second))
+	assert.False(t, elector.IsLeader())
+}
+
+func TestNewLeaderElector_RejectsTooShortLeases(t *testing.T) {
+	client, _ := newTestRedisClient(t)
+
+	for _, lease := range []time.Duration{0, time.Nanosecond, 2 * time.Millisecond} {
+		elector, err := NewLeaderElector(client, "workers", lease)
+		assert.Error(t, err)
+		assert.Nil(t, elector)
+	}
+}
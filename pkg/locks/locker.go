@@ -0,0 +1,18 @@
+package locks
+
+import (
+	"context"
+	"time"
+)
+
+// Locker keeps the locks taken through an AtomicLock. Every acquisition is identified by a random owner token, and a
+// lock is only extended or released for the token it was acquired with.
+type Locker interface {
+	// Acquire takes the lock on key for expiry. It returns the acquisition's fencing token, which is greater than the
+	// tokens of all earlier acquisitions of key, or 0 when the lock is held by someone else.
+	Acquire(ctx context.Context, key, token string, expiry time.Duration) (int64, error)
+	// Extend resets the expiry of the lock on key, reporting false when it is no longer held by token.
+	Extend(ctx context.Context, key, token string, expiry time.Duration) (bool, error)
+	// Release frees the lock on key, reporting false when it was no longer held by token.
+	Release(ctx context.Context, key, token string) (bool, error)
+}
@@ -0,0 +1,37 @@
+// Package lockstest provides helpers for testing code that takes locks.
+package lockstest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shahbaz275817/prismo/pkg/locks"
+)
+
+// RecordingLocker records the keys locks are taken on, and otherwise behaves like the Locker it wraps.
+type RecordingLocker struct {
+	locks.Locker
+
+	mu       sync.Mutex
+	acquired []string
+}
+
+// NewRecordingLocker returns a RecordingLocker keeping its locks in memory.
+func NewRecordingLocker() *RecordingLocker {
+	return &RecordingLocker{Locker: locks.NewMemoryLocker()}
+}
+
+func (l *RecordingLocker) Acquire(ctx context.Context, key, token string, expiry time.Duration) (int64, error) {
+	l.mu.Lock()
+	l.acquired = append(l.acquired, key)
+	l.mu.Unlock()
+	return l.Locker.Acquire(ctx, key, token, expiry)
+}
+
+// Acquired returns the keys acquisitions were attempted on, in order.
+func (l *RecordingLocker) Acquired() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.acquired...)
+}
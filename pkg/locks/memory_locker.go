@@ -0,0 +1,69 @@
+package locks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLocker keeps locks in the memory of the current process. It only guards against concurrent work within a
+// single instance, which makes it suitable for development and single node deployments.
+type MemoryLocker struct {
+	mu               sync.Mutex
+	locks            map[string]memoryLock
+	lastFencingToken int64
+	now              func() time.Time
+}
+
+type memoryLock struct {
+	token     string
+	expiresAt time.Time
+}
+
+// NewMemoryLocker creates an empty in-process Locker. Fencing tokens are drawn from a single counter shared by all
+// keys, so they increase per key without being consecutive.
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{
+		locks: make(map[string]memoryLock),
+		now:   time.Now,
+	}
+}
+
+func (m *MemoryLocker) Acquire(_ context.Context, key, token string, expiry time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if lock, ok := m.locks[key]; ok && now.Before(lock.expiresAt) {
+		return 0, nil
+	}
+	m.locks[key] = memoryLock{token: token, expiresAt: now.Add(expiry)}
+	m.lastFencingToken++
+	return m.lastFencingToken, nil
+}
+
+func (m *MemoryLocker) Extend(_ context.Context, key, token string, expiry time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	lock, ok := m.locks[key]
+	if !ok || lock.token != token || !now.Before(lock.expiresAt) {
+		return false, nil
+	}
+	m.locks[key] = memoryLock{token: token, expiresAt: now.Add(expiry)}
+	return true, nil
+}
+
+func (m *MemoryLocker) Release(_ context.Context, key, token string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock, ok := m.locks[key]
+	if !ok || lock.token != token {
+		return false, nil
+	}
+	// the lock is dropped even when it expired, nobody else took it in the meantime
+	delete(m.locks, key)
+	return m.now().Before(lock.expiresAt), nil
+}
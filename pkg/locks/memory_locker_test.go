@@ -0,0 +1,73 @@
+package locks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLocker(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	locker := NewMemoryLocker()
+	locker.now = func() time.Time { return now }
+
+	first, err := locker.Acquire(ctx, "abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), first)
+
+	held, err := locker.Acquire(ctx, "abc", "owner-2", time.Second)
+	assert.NoError(t, err)
+	assert.Zero(t, held)
+
+	// other keys are independent
+	other, err := locker.Acquire(ctx, "xyz", "owner-2", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), other)
+
+	now = now.Add(900 * time.Millisecond)
+	extended, err := locker.Extend(ctx, "abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+	assert.True(t, extended)
+	extended, _ = locker.Extend(ctx, "abc", "owner-2", time.Second)
+	assert.False(t, extended)
+
+	// the extension keeps the lock past its original expiry
+	now = now.Add(900 * time.Millisecond)
+	held, _ = locker.Acquire(ctx, "abc", "owner-2", time.Second)
+	assert.Zero(t, held)
+
+	released, err := locker.Release(ctx, "abc", "owner-2")
+	assert.NoError(t, err)
+	assert.False(t, released)
+	released, err = locker.Release(ctx, "abc", "owner-1")
+	assert.NoError(t, err)
+	assert.True(t, released)
+
+	next, _ := locker.Acquire(ctx, "abc", "owner-2", time.Second)
+	assert.Equal(t, int64(3), next)
+}
+
+func TestMemoryLocker_ExpiredLockCanBeTakenOver(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	locker := NewMemoryLocker()
+	locker.now = func() time.Time { return now }
+
+	_, _ = locker.Acquire(ctx, "abc", "owner-1", time.Second)
+	now = now.Add(time.Second)
+
+	extended, _ := locker.Extend(ctx, "abc", "owner-1", time.Second)
+	assert.False(t, extended)
+
+	fencingToken, _ := locker.Acquire(ctx, "abc", "owner-2", time.Second)
+	assert.Equal(t, int64(2), fencingToken)
+
+	// the previous owner cannot release the new owner's lock
+	released, _ := locker.Release(ctx, "abc", "owner-1")
+	assert.False(t, released)
+	extended, _ = locker.Extend(ctx, "abc", "owner-2", time.Second)
+	assert.True(t, extended)
+}
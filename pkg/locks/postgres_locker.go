@@ -0,0 +1,144 @@
+package locks
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+// PostgresLocker keeps locks as Postgres transaction level advisory locks, shared by every instance using the same
+// database. Each held lock keeps a transaction open, and with it a connection of db, until it is released: ending the
+// transaction is what releases the lock, so a lock never outlives its holder's connection and no connection goes back
+// to the pool still holding one. Locks do not expire otherwise.
+type PostgresLocker struct {
+	db *sql.DB
+	// conns has a slot per connection db may open, so that Acquire fails fast rather than waiting for a connection
+	// that only a Release frees. It is nil when db has no connection limit.
+	conns chan struct{}
+	mu    sync.Mutex
+	locks map[string]postgresLock
+}
+
+type postgresLock struct {
+	key string
+	tx  *sql.Tx
+}
+
+// NewPostgresLocker creates a Locker on top of db, which should be a pool of its own whose MaxOpenConns bounds how
+// many locks are held at once. Fencing tokens are drawn from the lock_fencing_tokens sequence, shared by all keys, so
+// they increase per key without being consecutive.
+func NewPostgresLocker(db *sql.DB) *PostgresLocker {
+	p := &PostgresLocker{
+		db:    db,
+		locks: make(map[string]postgresLock),
+	}
+	if maxConns := db.Stats().MaxOpenConnections; maxConns > 0 {
+		p.conns = make(chan struct{}, maxConns)
+	}
+	return p
+}
+
+func (p *PostgresLocker) Acquire(ctx context.Context, key, token string, _ time.Duration) (int64, error) {
+	if !p.takeConn() {
+		// every connection holds a lock, so this one counts as contended and is retried like one
+		logger.WithContext(ctx).Warnf("No connection left to lock key %s, %d locks are held", key, cap(p.conns))
+		return 0, nil
+	}
+
+	// the transaction holds the lock until Release, so it must not be rolled back when ctx ends before that
+	tx, err := p.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		p.returnConn()
+		return 0, err
+	}
+
+	var locked bool
+	if err = tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock(hashtextextended($1, 0))", key).Scan(&locked); err != nil || !locked {
+		p.endTx(tx)
+		return 0, err
+	}
+	var fencingToken int64
+	if err = tx.QueryRowContext(ctx, "SELECT nextval('lock_fencing_tokens')").Scan(&fencingToken); err != nil {
+		p.endTx(tx)
+		return 0, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.locks[token] = postgresLock{key: key, tx: tx}
+	return fencingToken, nil
+}
+
+// Extend checks that the transaction holding the lock is still alive, since advisory locks have no expiry to reset.
+func (p *PostgresLocker) Extend(ctx context.Context, key, token string, _ time.Duration) (bool, error) {
+	lock, ok := p.lock(key, token)
+	if !ok {
+		return false, nil
+	}
+	if _, err := lock.tx.ExecContext(ctx, "SELECT 1"); err != nil {
+		// the transaction is unusable, so Postgres released the lock with it
+		logger.Warnf("Transaction holding lock on key %s failed: %s", key, err)
+		p.forget(token)
+		p.endTx(lock.tx)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *PostgresLocker) Release(_ context.Context, key, token string) (bool, error) {
+	lock, ok := p.lock(key, token)
+	if !ok {
+		return false, nil
+	}
+	p.forget(token)
+
+	// transaction level locks cannot be unlocked, they are released when their transaction ends
+	err := lock.tx.Rollback()
+	p.returnConn()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// endTx rolls tx back, releasing any lock it holds, and gives its connection slot back.
+func (p *PostgresLocker) endTx(tx *sql.Tx) {
+	_ = tx.Rollback()
+	p.returnConn()
+}
+
+func (p *PostgresLocker) takeConn() bool {
+	if p.conns == nil {
+		return true
+	}
+	select {
+	case p.conns <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *PostgresLocker) returnConn() {
+	if p.conns != nil {
+		<-p.conns
+	}
+}
+
+func (p *PostgresLocker) lock(key, token string) (postgresLock, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lock, ok := p.locks[token]
+	return lock, ok && lock.key == key
+}
+
+func (p *PostgresLocker) forget(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.locks, token)
+}
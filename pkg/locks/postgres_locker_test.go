@@ -0,0 +1,157 @@
+package locks
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	tryLockQuery = regexp.QuoteMeta("SELECT pg_try_advisory_xact_lock(hashtextextended($1, 0))")
+	nextvalQuery = regexp.QuoteMeta("SELECT nextval('lock_fencing_tokens')")
+)
+
+func newTestPostgresLocker(t *testing.T) (*PostgresLocker, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewPostgresLocker(db), mock
+}
+
+func TestPostgresLocker_AcquireExtendRelease(t *testing.T) {
+	ctx := context.Background()
+	locker, mock := newTestPostgresLocker(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(tryLockQuery).WithArgs("abc").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectQuery(nextvalQuery).WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(int64(7)))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT 1")).WillReturnResult(sqlmock.NewResult(0, 1))
+	// ending the transaction releases the lock
+	mock.ExpectRollback()
+
+	fencingToken, err := locker.Acquire(ctx, "abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), fencingToken)
+
+	extended, err := locker.Extend(ctx, "abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+	assert.True(t, extended)
+
+	// another owner's token does not release the lock
+	released, err := locker.Release(ctx, "abc", "owner-2")
+	assert.NoError(t, err)
+	assert.False(t, released)
+
+	released, err = locker.Release(ctx, "abc", "owner-1")
+	assert.NoError(t, err)
+	assert.True(t, released)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresLocker_AcquireWhenHeld(t *testing.T) {
+	locker, mock := newTestPostgresLocker(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(tryLockQuery).WithArgs("abc").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+	mock.ExpectRollback()
+
+	fencingToken, err := locker.Acquire(context.Background(), "abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+	assert.Zero(t, fencingToken)
+	assert.Empty(t, locker.locks)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresLocker_ExtendReportsLostTransaction(t *testing.T) {
+	ctx := context.Background()
+	locker, mock := newTestPostgresLocker(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(tryLockQuery).WithArgs("abc").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectQuery(nextvalQuery).WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(int64(1)))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT 1")).WillReturnError(errors.New("connection reset by peer"))
+	mock.ExpectRollback()
+
+	_, err := locker.Acquire(ctx, "abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+
+	extended, err := locker.Extend(ctx, "abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+	assert.False(t, extended)
+
+	released, err := locker.Release(ctx, "abc", "owner-1")
+	assert.NoError(t, err)
+	assert.False(t, released)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresLocker_AcquireFailsFastOncePoolIsUsedUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+	locker := NewPostgresLocker(db)
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(tryLockQuery).WithArgs("abc").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectQuery(nextvalQuery).WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(int64(1)))
+	// ending the transaction releases the lock
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectQuery(tryLockQuery).WithArgs("xyz").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectQuery(nextvalQuery).WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(int64(2)))
+
+	_, err = locker.Acquire(ctx, "abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+
+	// the only connection holds a lock, so another key is reported busy instead of waiting for it
+	done := make(chan int64)
+	go func() {
+		fencingToken, _ := locker.Acquire(ctx, "xyz", "owner-2", time.Second)
+		done <- fencingToken
+	}()
+	select {
+	case fencingToken := <-done:
+		assert.Zero(t, fencingToken)
+	case <-time.After(time.Second):
+		t.Fatal("Acquire waited for a connection")
+	}
+
+	released, err := locker.Release(ctx, "abc", "owner-1")
+	assert.NoError(t, err)
+	assert.True(t, released)
+
+	fencingToken, err := locker.Acquire(ctx, "xyz", "owner-2", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), fencingToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresLocker_HoldsTheLockAfterTheAcquiringContextEnds(t *testing.T) {
+	locker, mock := newTestPostgresLocker(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(tryLockQuery).WithArgs("abc").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectQuery(nextvalQuery).WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(int64(1)))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT 1")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := locker.Acquire(ctx, "abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+	cancel()
+
+	extended, err := locker.Extend(context.Background(), "abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+	assert.True(t, extended)
+	released, err := locker.Release(context.Background(), "abc", "owner-1")
+	assert.NoError(t, err)
+	assert.True(t, released)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
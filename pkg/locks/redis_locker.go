@@ -0,0 +1,82 @@
+package locks
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/shahbaz275817/prismo/pkg/cache"
+)
+
+// fencingCounterKey is the counter every fencing token is drawn from. It is shared by all keys and never expires, so
+// tokens increase per key without being consecutive, and keys locked only once, such as transfer client references,
+// leave nothing behind in Redis.
+const fencingCounterKey = "lock-fencing-token"
+
+// acquireScript sets the lock to the caller's owner token if it is free and bumps the fencing counter, returning the
+// new fencing token or 0 when the lock is held by someone else. Tokens used to come from a counter per key, KEYS[3];
+// one still left over moves the shared counter past it, so the key's tokens do not go backwards.
+var acquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	local token = redis.call("INCR", KEYS[2])
+	local previous = tonumber(redis.call("GET", KEYS[3]))
+	if previous then
+		if previous >= token then
+			token = previous + 1
+			redis.call("SET", KEYS[2], token)
+		end
+		redis.call("DEL", KEYS[3])
+	end
+	return token
+end
+return 0
+`)
+
+// releaseScript deletes the lock only if it is still held by the caller's owner token.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// extendScript resets the lock's expiry only if it is still held by the caller's owner token.
+var extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// RedisLocker keeps locks as Redis keys holding the owner token, shared by every instance using the same Redis.
+type RedisLocker struct {
+	client cache.Client
+}
+
+// NewRedisLocker creates a Locker on top of the given cache client.
+func NewRedisLocker(client cache.Client) *RedisLocker {
+	return &RedisLocker{client: client}
+}
+
+func (r *RedisLocker) Acquire(ctx context.Context, key, token string, expiry time.Duration) (int64, error) {
+	return acquireScript.Run(ctx, r.client, []string{key, fencingCounterKey, key + ":fencing_token"}, token, milliseconds(expiry)).Int64()
+}
+
+func (r *RedisLocker) Extend(ctx context.Context, key, token string, expiry time.Duration) (bool, error) {
+	extended, err := extendScript.Run(ctx, r.client, []string{key}, token, milliseconds(expiry)).Int()
+	return extended == 1, err
+}
+
+func (r *RedisLocker) Release(ctx context.Context, key, token string) (bool, error) {
+	released, err := releaseScript.Run(ctx, r.client, []string{key}, token).Int()
+	return released == 1, err
+}
+
+// milliseconds converts d for PX and PEXPIRE, which reject or immediately expire on values below one.
+func milliseconds(d time.Duration) int64 {
+	if ms := d.Milliseconds(); ms > 0 {
+		return ms
+	}
+	return 1
+}
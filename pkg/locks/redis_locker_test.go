@@ -0,0 +1,44 @@
+package locks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisLocker_FencingTokensNeverGoBackwards(t *testing.T) {
+	client, mr := newTestRedisClient(t)
+	locker := NewRedisLocker(client)
+	ctx := context.Background()
+
+	fencingToken, err := locker.Acquire(ctx, "lock-transfer-abc", "owner-1", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), fencingToken)
+	_, err = locker.Acquire(ctx, "lock-transfer-def", "owner-2", time.Second)
+	assert.NoError(t, err)
+
+	// however long a key goes unlocked, its next token is greater than all earlier ones
+	mr.FastForward(365 * 24 * time.Hour)
+	fencingToken, err = locker.Acquire(ctx, "lock-transfer-abc", "owner-3", time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), fencingToken)
+	assert.Equal(t, time.Duration(0), mr.TTL(fencingCounterKey))
+	assert.False(t, mr.Exists("lock-transfer-abc:fencing_token"))
+}
+
+func TestRedisLocker_FencingTokensContinueAfterPerKeyCounters(t *testing.T) {
+	client, mr := newTestRedisClient(t)
+	locker := NewRedisLocker(client)
+	assert.NoError(t, mr.Set("lock-transfer-abc:fencing_token", "41"))
+
+	fencingToken, err := locker.Acquire(context.Background(), "lock-transfer-abc", "owner-1", time.Second)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), fencingToken)
+	assert.False(t, mr.Exists("lock-transfer-abc:fencing_token"))
+	counter, err := mr.Get(fencingCounterKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", counter)
+}
@@ -0,0 +1,149 @@
+package locks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/shahbaz275817/prismo/pkg/cache"
+	"github.com/shahbaz275817/prismo/pkg/errors"
+	"github.com/shahbaz275817/prismo/pkg/logger"
+)
+
+// semaphoreAcquireScript drops the holders whose lease ran out and adds the caller if fewer than the limit remain.
+// Holders are kept in a sorted set scored by the unix millisecond their lease ends.
+var semaphoreAcquireScript = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[4])
+redis.call("PEXPIRE", KEYS[1], ARGV[5])
+return 1
+`)
+
+// semaphoreRenewScript extends the caller's lease only if it still holds a slot.
+var semaphoreRenewScript = redis.NewScript(`
+if not redis.call("ZSCORE", KEYS[1], ARGV[2]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], "XX", ARGV[1], ARGV[2])
+redis.call("PEXPIRE", KEYS[1], ARGV[3])
+return 1
+`)
+
+// Semaphore allows at most limit holders at a time across every instance using the same Redis, e.g. to cap
+// concurrent exports. Slots are leased, so a holder that dies frees its slot once the lease runs out. Lease ends are
+// computed from the clocks of the instances, which should be kept in sync.
+type Semaphore struct {
+	client cache.Client
+	key    string
+	limit  int
+	lease  time.Duration
+	now    func() time.Time
+}
+
+// NewSemaphore creates a semaphore with limit slots kept under key.
+func NewSemaphore(client cache.Client, key string, limit int, lease time.Duration) *Semaphore {
+	return &Semaphore{
+		client: client,
+		key:    key,
+		limit:  limit,
+		lease:  lease,
+		now:    time.Now,
+	}
+}
+
+// Permit is a slot held in a Semaphore. Its lease is renewed until it is released.
+type Permit struct {
+	semaphore *Semaphore
+	token     string
+	done      chan struct{}
+	wg        sync.WaitGroup
+	once      sync.Once
+}
+
+// Acquire takes a slot, failing with a TooManyRequestsError when all of them are held.
+func (s *Semaphore) Acquire(ctx context.Context) (*Permit, error) {
+	token, err := newOwnerToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	acquired, err := semaphoreAcquireScript.Run(ctx, s.client, []string{s.key},
+		now.UnixMilli(), now.Add(s.lease).UnixMilli(), s.limit, token, milliseconds(s.lease)).Int()
+	if err != nil {
+		return nil, err
+	}
+	if acquired == 0 {
+		return nil, errors.NewTooManyRequestsError("semaphore_full", &errors.ErrDetails{
+			Message: fmt.Sprintf("All %d slots of %s are taken", s.limit, s.key),
+		})
+	}
+
+	permit := &Permit{semaphore: s, token: token, done: make(chan struct{})}
+	permit.keepAlive()
+	return permit, nil
+}
+
+// Execute runs f while holding a slot.
+func (s *Semaphore) Execute(ctx context.Context, f func(ctx context.Context) error) error {
+	permit, err := s.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer permit.Release(context.Background())
+
+	return f(ctx)
+}
+
+// Release stops renewing the permit's lease and frees its slot. Releasing a permit more than once is a no-op.
+func (p *Permit) Release(ctx context.Context) error {
+	released := false
+	p.once.Do(func() {
+		close(p.done)
+		p.wg.Wait()
+		released = true
+	})
+	if !released {
+		return nil
+	}
+	return p.semaphore.client.ZRem(ctx, p.semaphore.key, p.token).Err()
+}
+
+// keepAlive renews the lease every third of its length until the permit is released or its slot is lost.
+func (p *Permit) keepAlive() {
+	interval := p.semaphore.lease / 3
+	if interval <= 0 {
+		return
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				s := p.semaphore
+				renewed, err := semaphoreRenewScript.Run(context.Background(), s.client, []string{s.key},
+					s.now().Add(s.lease).UnixMilli(), p.token, milliseconds(s.lease)).Int()
+				if err != nil {
+					logger.Warnf("Unable to renew slot of semaphore %s: %s", s.key, err)
+					continue
+				}
+				if renewed == 0 {
+					logger.Errorf("Slot of semaphore %s was lost before it was released", s.key)
+					return
+				}
+			}
+		}
+	}()
+}
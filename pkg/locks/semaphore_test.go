@@ -0,0 +1,89 @@
+package locks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shahbaz275817/prismo/pkg/errors"
+)
+
+func newTestRedisClient(t *testing.T) (*redis.Client, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return client, mr
+}
+
+func TestSemaphore_LimitsConcurrentHolders(t *testing.T) {
+	ctx := context.Background()
+	client, mr := newTestRedisClient(t)
+	semaphore := NewSemaphore(client, "exports", 2, time.Minute)
+
+	first, err := semaphore.Acquire(ctx)
+	assert.NoError(t, err)
+	second, err := semaphore.Acquire(ctx)
+	assert.NoError(t, err)
+
+	_, err = semaphore.Acquire(ctx)
+	assert.IsType(t, errors.TooManyRequestsError{}, err)
+
+	assert.NoError(t, first.Release(ctx))
+	assert.NoError(t, first.Release(ctx))
+	third, err := semaphore.Acquire(ctx)
+	assert.NoError(t, err)
+
+	members, _ := mr.ZMembers("exports")
+	assert.ElementsMatch(t, []string{second.token, third.token}, members)
+
+	assert.NoError(t, second.Release(ctx))
+	assert.NoError(t, third.Release(ctx))
+	assert.False(t, mr.Exists("exports"))
+}
+
+func TestSemaphore_FreesSlotsWhoseLeaseRanOut(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestRedisClient(t)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	semaphore := NewSemaphore(client, "exports", 1, time.Minute)
+	semaphore.now = func() time.Time { return now }
+
+	crashed, err := semaphore.Acquire(ctx)
+	assert.NoError(t, err)
+	// the holder dies without releasing or renewing its slot
+	close(crashed.done)
+	crashed.wg.Wait()
+
+	_, err = semaphore.Acquire(ctx)
+	assert.Error(t, err)
+
+	now = now.Add(time.Minute)
+	permit, err := semaphore.Acquire(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, permit.Release(ctx))
+}
+
+func TestSemaphore_RenewsLeaseWhileHeld(t *testing.T) {
+	ctx := context.Background()
+	client, mr := newTestRedisClient(t)
+	semaphore := NewSemaphore(client, "exports", 1, 30*time.Millisecond)
+
+	err := semaphore.Execute(ctx, func(ctx context.Context) error {
+		members, _ := mr.ZMembers("exports")
+		assert.Len(t, members, 1)
+		initial, _ := mr.ZScore("exports", members[0])
+
+		assert.Eventually(t, func() bool {
+			score, _ := mr.ZScore("exports", members[0])
+			return score > initial
+		}, time.Second, 5*time.Millisecond)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, mr.Exists("exports"))
+}
@@ -9,6 +9,7 @@ import (
 type MetricReporter interface {
 	Incr(key string)
 	Gauge(key string, value interface{})
+	Timing(key string, value interface{})
 	getClient() *statsd.Client
 }
 
@@ -21,6 +22,7 @@ type ReporterEntry struct {
 	parent       string
 	incrMetrics  []string
 	gaugeMetrics map[string]interface{}
+	timings      map[string]interface{}
 }
 
 func (reporter *Reporter) Report(parent string) *ReporterEntry {
@@ -33,6 +35,7 @@ func (reporter *Reporter) Report(parent string) *ReporterEntry {
 		parent:       parent,
 		incrMetrics:  []string{},
 		gaugeMetrics: map[string]interface{}{},
+		timings:      map[string]interface{}{},
 	}
 }
 
@@ -74,6 +77,12 @@ func (entry *ReporterEntry) Publish() {
 			entry.mr.Gauge(key, value)
 		}
 	}()
+
+	go func() {
+		for key, value := range entry.timings {
+			entry.mr.Timing(key, value)
+		}
+	}()
 }
 
 func (entry *ReporterEntry) Failure() {
@@ -101,6 +110,19 @@ func (entry *ReporterEntry) SetGauge(gauge string, value interface{}) {
 	entry.gaugeMetrics[metricKey] = value
 }
 
+// Timing records how long something took, in milliseconds, so statsd can aggregate it into percentiles.
+func (entry *ReporterEntry) Timing(timer string, d time.Duration) {
+	if entry == nil || entry.mr == nil {
+		return
+	}
+	if entry.timings == nil {
+		entry.timings = map[string]interface{}{}
+	}
+
+	metricKey := fmt.Sprintf("timers.%s.%s", entry.parent, timer)
+	entry.timings[metricKey] = float64(d) / float64(time.Millisecond)
+}
+
 func (reporter *Reporter) RegisterPeriodicMetrics(f func(), period time.Duration) {
 	if reporter == nil {
 		return
@@ -66,6 +66,12 @@ func (reporter *StatsD) Gauge(key string, value interface{}) {
 	}
 }
 
+func (reporter *StatsD) Timing(key string, value interface{}) {
+	if reporter.client != nil {
+		reporter.client.Timing(key, value)
+	}
+}
+
 func (reporter *StatsD) getClient() *statsd.Client {
 	return reporter.client
 }